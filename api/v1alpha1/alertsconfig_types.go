@@ -23,6 +23,18 @@ import (
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+const (
+	// AlertPackAnnotation on a Deployment, StatefulSet or Namespace generates an AlertsConfig for it.
+	// Templates with the same label and value are selected by the generated AlertsConfig
+	AlertPackAnnotation = "alertmanager.keikoproj.io/alert-pack"
+	// AlertPackKindAnnotation (Optional) can be used to select ClusterWavefrontAlert templates instead of WavefrontAlert
+	AlertPackKindAnnotation = "alertmanager.keikoproj.io/alert-pack-kind"
+	// GeneratedByLabel is added to the AlertsConfig generated from a workload
+	GeneratedByLabel = "alertmanager.keikoproj.io/generated-by"
+	// AlertsConfigKind is the kind of AlertsConfig resource
+	AlertsConfigKind = "AlertsConfig"
+)
+
 // AlertsConfigSpec defines the desired state of AlertsConfig
 type AlertsConfigSpec struct {
 	//GlobalGVK- This is a global GVK config but user can overwrite it if an AlertsConfig supports multiple type of Alerts in future.
@@ -30,11 +42,42 @@ type AlertsConfigSpec struct {
 	GlobalGVK GVK `json:"globalGVK,omitempty"`
 	//Alerts- Provide each individual alert config
 	Alerts map[string]Config `json:"alerts,omitempty"`
+	//TemplateSelector (Optional) selects the alert templates by labels instead of listing each and every template in alerts section.
+	//Templates of GlobalGVK kind are selected and global params are applied to all of them. If a selected template is also part of
+	//alerts section, params from alerts section overwrite the global params for that template
+	// +optional
+	TemplateSelector *metav1.LabelSelector `json:"templateSelector,omitempty"`
 	//GlobalParams is the place holder to provide any global param values which can be used in individual config sections.
 	//Please note that if a param is mentioned in both global param section and individual config params section,
 	//later will be taken into consideration and NOT the value from global param section
 	// +optional
 	GlobalParams OrderedMap `json:"globalParams,omitempty"`
+	//DeletionPolicy (Optional) decides what happens to the wavefront alerts when this resource or an alert from it is deleted.
+	//Defaults to Delete. This can be overwritten for an individual alert in alerts section
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+	//Dashboards (Optional) renders the WavefrontDashboard templates in the same namespace, keyed by the template name.
+	//Links to the rendered dashboards are added to the additional information of every alert rendered by this alerts config
+	// +optional
+	Dashboards map[string]DashboardConfig `json:"dashboards,omitempty"`
+	//DerivedMetrics (Optional) renders the WavefrontDerivedMetric templates in the same namespace, keyed by the template name.
+	//Derived metrics are applied before the alerts so the alerts can query them
+	// +optional
+	DerivedMetrics map[string]DerivedMetricConfig `json:"derivedMetrics,omitempty"`
+}
+
+// DashboardConfig provides the param values for a dashboard template. Global params are applied as well
+type DashboardConfig struct {
+	//Params section can be used to provide exportParams key values
+	// +optional
+	Params OrderedMap `json:"params,omitempty"`
+}
+
+// DerivedMetricConfig provides the param values for a derived metric template. Global params are applied as well
+type DerivedMetricConfig struct {
+	//Params section can be used to provide exportParams key values
+	// +optional
+	Params OrderedMap `json:"params,omitempty"`
 }
 
 // GVK struct represents the alert type and can be used as a global as well as in individual alert section
@@ -51,11 +94,15 @@ type GVK struct {
 // +optional
 type Config struct {
 	//GVK can be used to provide CRD group, version and kind- If there is a global GVK already provided this will overwrite it
+	//Use kind ClusterWavefrontAlert to refer a cluster scoped template instead of a WavefrontAlert in the same namespace
 	// +optional
 	GVK GVK `json:"gvk,omitempty"`
 	//Params section can be used to provide exportParams key values
 	// +optional
 	Params OrderedMap `json:"params,omitempty"`
+	//DeletionPolicy can be used to overwrite the alerts config deletion policy for this alert
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 // AlertsConfigStatus defines the observed state of AlertsConfig
@@ -70,15 +117,27 @@ type AlertsConfigStatus struct {
 	ErrorDescription string `json:"errorDescription,omitempty"`
 	//AlertsStatus details includes individual alert details
 	AlertsStatus map[string]AlertStatus `json:"alertsStatus,omitempty"`
+	//LastHandledReconcileRequest is the last reconcile request annotation value handled by the controller
+	// +optional
+	LastHandledReconcileRequest string `json:"lastHandledReconcileRequest,omitempty"`
+	//DashboardsStatus has the dashboards rendered by this alerts config keyed by the template name
+	// +optional
+	DashboardsStatus map[string]DashboardStatus `json:"dashboardsStatus,omitempty"`
+	//DerivedMetricsStatus has the derived metrics rendered by this alerts config keyed by the template name
+	// +optional
+	DerivedMetricsStatus map[string]DerivedMetricStatus `json:"derivedMetricsStatus,omitempty"`
 }
 
 type AssociatedAlert struct {
 	CR         string `json:"CR,omitempty"`
 	Generation int64  `json:"generation,omitempty"`
+	//Kind of the template CR. Empty means WavefrontAlert in the same namespace
+	Kind string `json:"kind,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="current state of the alerts config"
 // +kubebuilder:printcolumn:name="RetryCount",type="integer",JSONPath=".status.retryCount",description="Retry count"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="time passed since alerts config creation"
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ClusterWavefrontAlertKind is the kind AlertsConfig entries use in GVK to refer a cluster scoped template
+	ClusterWavefrontAlertKind = "ClusterWavefrontAlert"
+)
+
+// ClusterWavefrontAlertSpec defines the desired state of ClusterWavefrontAlert
+// +kubebuilder:validation:XValidation:rule="!has(self.rolloutStrategy)",message="rolloutStrategy is not supported for ClusterWavefrontAlert"
+type ClusterWavefrontAlertSpec struct {
+	//WavefrontAlertSpec is the alert template. exportedParams must be provided since cluster templates are only used by AlertsConfig.
+	//rolloutStrategy is rejected since the template change is rolled out to all the namespaces in a single batch
+	WavefrontAlertSpec `json:",inline"`
+
+	//AllowedNamespaces (Optional) restricts the namespaces which can consume this template. All namespaces can consume it if empty
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+
+	//NamespaceSelector (Optional) restricts the namespaces which can consume this template by namespace labels.
+	//If both allowedNamespaces and namespaceSelector are provided, namespace must be part of both
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// ClusterWavefrontAlertStatus defines the observed state of ClusterWavefrontAlert
+type ClusterWavefrontAlertStatus struct {
+	//State of the resource
+	State State `json:"state,omitempty"`
+	//RetryCount in case of error
+	RetryCount int `json:"retryCount"`
+	//ErrorDescription in case of error
+	ErrorDescription string `json:"errorDescription,omitempty"`
+	//ObservedGeneration will have the last generation from spec metadata
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	//Namespaces includes the alert details for each consuming namespace
+	Namespaces map[string]NamespaceAlertsStatus `json:"namespaces,omitempty"`
+	//LastHandledReconcileRequest is the last reconcile request annotation value handled by the controller
+	// +optional
+	LastHandledReconcileRequest string `json:"lastHandledReconcileRequest,omitempty"`
+}
+
+// NamespaceAlertsStatus consists of the alerts created from a cluster template in a namespace
+type NamespaceAlertsStatus struct {
+	//AlertsStatus details includes individual alert details keyed by AlertsConfig name
+	AlertsStatus map[string]AlertStatus `json:"alertsStatus,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=clusterwavefrontalerts,scope=Cluster,shortName=cwfalerts,singular=clusterwavefrontalert
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="current state of the cluster wavefront alert"
+// +kubebuilder:printcolumn:name="RetryCount",type="integer",JSONPath=".status.retryCount",description="Retry count"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="time passed since cluster wavefront alert creation"
+
+// ClusterWavefrontAlert is the Schema for the clusterwavefrontalerts API
+type ClusterWavefrontAlert struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterWavefrontAlertSpec   `json:"spec,omitempty"`
+	Status ClusterWavefrontAlertStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterWavefrontAlertList contains a list of ClusterWavefrontAlert
+type ClusterWavefrontAlertList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterWavefrontAlert `json:"items"`
+}
+
+// AsWavefrontAlert converts the cluster scoped template to an in-memory WavefrontAlert
+// so the same rendering functions can be used for both template kinds
+func (in *ClusterWavefrontAlert) AsWavefrontAlert() *WavefrontAlert {
+	return &WavefrontAlert{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        in.Name,
+			Generation:  in.Generation,
+			Annotations: in.Annotations,
+		},
+		Spec: *in.Spec.WavefrontAlertSpec.DeepCopy(),
+		Status: WavefrontAlertStatus{
+			ObservedGeneration: in.Status.ObservedGeneration,
+		},
+	}
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterWavefrontAlert{}, &ClusterWavefrontAlertList{})
+}
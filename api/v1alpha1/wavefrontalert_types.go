@@ -18,11 +18,17 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+const (
+	// WavefrontAlertKind is the kind of WavefrontAlert resource
+	WavefrontAlertKind = "WavefrontAlert"
+)
+
 // WavefrontAlertSpec defines the desired state of WavefrontAlert
 type WavefrontAlertSpec struct {
 	// Important: Run "make" to regenerate code after modifying this file
@@ -43,13 +49,13 @@ type WavefrontAlertSpec struct {
 	// +required
 	Severity string `json:"severity"`
 
-	//Minutes where alert is in "true" state continuously to trigger an alert
+	//Minutes where alert is in "true" state continuously to trigger an alert. String can be used to provide a go lang template
 	// +required
-	Minutes *int32 `json:"minutes"`
+	Minutes *intstr.IntOrString `json:"minutes"`
 
-	//Minutes after the alert got back to "false" state to resolve the incident
+	//Minutes after the alert got back to "false" state to resolve the incident. String can be used to provide a go lang template
 	// +required
-	ResolveAfter *int32 `json:"resolveAfterMinutes"`
+	ResolveAfter *intstr.IntOrString `json:"resolveAfterMinutes"`
 
 	//Target (Optional) A comma-separated list of the email address or integration endpoint (such as PagerDuty or web hook)
 	// to notify when the alert status changes.
@@ -61,7 +67,7 @@ type WavefrontAlertSpec struct {
 	// +optional
 	AdditionalInformation string `json:"additionalInformation,omitempty"`
 
-	//Tags assigned to the alert.
+	//Tags assigned to the alert. A templated tag rendering to a comma separated list is split into multiple tags so a list param can be used
 	// +optional
 	Tags []string `json:"tags,omitempty"`
 
@@ -80,12 +86,45 @@ type WavefrontAlertSpec struct {
 	// wants to use go lang template for a field but majority of the alerts can use the default values instead of providing in each and every alert config files.
 	// +optional
 	ExportedParamsDefaultValues OrderedMap `json:"exportedParamsDefaultValues,omitempty"`
+	//exportedParamsSchema (Optional) can be used to declare the type and the validation rules of the exportedParams.
+	//Values provided by alerts config are validated against the schema before rendering the template
+	// +optional
+	ExportedParamsSchema map[string]ParamSchema `json:"exportedParamsSchema,omitempty"`
 
 	//AlertCheckFrequency can be used to provide a different alert check frequency then the default 1min. Optional. This is in minutes
+	//String can be used to provide a go lang template
+	// +optional
+	AlertCheckFrequency *intstr.IntOrString `json:"alertCheckFrequency,omitempty"`
+
+	//RolloutStrategy (Optional) controls how a template change is rolled out to the alerts configs using this template.
+	//All the alerts configs are updated in a single batch if not specified. Not supported for ClusterWavefrontAlert
 	// +optional
-	AlertCheckFrequency int `json:"alertCheckFrequency,omitempty"`
+	RolloutStrategy *RolloutStrategy `json:"rolloutStrategy,omitempty"`
+
+	//DeletionPolicy (Optional) decides what happens to the wavefront alerts when this resource is deleted. Defaults to Delete
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
+// DeletionPolicy decides what happens to the wavefront alert when the resource managing it is deleted
+// +kubebuilder:validation:Enum=Delete;Retain;Snooze
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the wavefront alert. This is the default
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain leaves the wavefront alert as is. For ex: while moving the resource to another namespace or cluster
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+	// DeletionPolicySnooze keeps the wavefront alert but snoozes it
+	DeletionPolicySnooze DeletionPolicy = "Snooze"
+)
+
+const (
+	// DeletionProtectionAnnotation set to "true" blocks deleting the resource and its wavefront alerts.
+	// Annotation must be removed first to let the deletion go through
+	DeletionProtectionAnnotation = "alertmanager.keikoproj.io/deletion-protection"
+)
+
 // AlertType represents the type of the Alert in Wavefront. Defaults to CLASSIC alert
 // +kubebuilder:default=CLASSIC
 // +kubebuilder:validation:Enum=CLASSIC;THRESHOLD
@@ -99,6 +138,117 @@ const (
 	ThresholdAlert AlertType = "THRESHOLD"
 )
 
+// ParamType represents the type of an exported param
+// +kubebuilder:validation:Enum=string;int;duration;enum;list
+type ParamType string
+
+const (
+	// ParamTypeString is any string. Default type if none specified
+	ParamTypeString ParamType = "string"
+	// ParamTypeInt is an integer
+	ParamTypeInt ParamType = "int"
+	// ParamTypeDuration is a go lang duration for ex: 5m, 1h30m
+	ParamTypeDuration ParamType = "duration"
+	// ParamTypeEnum must be one of allowedValues
+	ParamTypeEnum ParamType = "enum"
+	// ParamTypeList is a comma separated list. pattern and allowedValues are applied to each item
+	ParamTypeList ParamType = "list"
+)
+
+// ParamSchema defines the type and the validation rules of an exported param
+type ParamSchema struct {
+	//Type of the param. Defaults to string
+	// +optional
+	Type ParamType `json:"type,omitempty"`
+	//Pattern is a regex the value must match
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+	//AllowedValues the value must be one of. Required for enum type
+	// +optional
+	AllowedValues []string `json:"allowedValues,omitempty"`
+	//Required defaults to true. Optional params can be omitted from alerts config and exportedParamsDefaultValues
+	// +optional
+	Required *bool `json:"required,omitempty"`
+	//Description of the param
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// RolloutStrategy controls how a template change is rolled out to the alerts configs
+type RolloutStrategy struct {
+	//BatchSize is the number of alerts configs updated in a batch. Defaults to all of them
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	BatchSize int `json:"batchSize,omitempty"`
+
+	//Canaries are the names of the alerts configs to be updated first in a batch of their own
+	// +optional
+	Canaries []string `json:"canaries,omitempty"`
+
+	//PauseSeconds is the time to wait between two batches
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PauseSeconds int `json:"pauseSeconds,omitempty"`
+
+	//MaxFailures is the number of failed alerts configs after which the rollout is halted.
+	//If not specified, rollout is never halted and the failed alerts configs are retried
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxFailures *int `json:"maxFailures,omitempty"`
+}
+
+// RolloutPhase is the phase of a template change rollout
+type RolloutPhase string
+
+const (
+	RolloutInProgress RolloutPhase = "InProgress"
+	RolloutPaused     RolloutPhase = "Paused"
+	RolloutHalted     RolloutPhase = "Halted"
+	RolloutCompleted  RolloutPhase = "Completed"
+)
+
+// RolloutInstanceState is the rollout state of an alerts config using the template
+type RolloutInstanceState string
+
+const (
+	RolloutPending RolloutInstanceState = "Pending"
+	RolloutUpdated RolloutInstanceState = "Updated"
+	RolloutFailed  RolloutInstanceState = "Failed"
+)
+
+// RolloutStatus tracks the progress of the template change rollout so it can be resumed after a controller restart
+type RolloutStatus struct {
+	//Generation of the template being rolled out
+	Generation int64 `json:"generation"`
+
+	//Phase of the rollout
+	Phase RolloutPhase `json:"phase"`
+
+	//AlertsConfigs has the rollout state per alerts config name
+	// +optional
+	AlertsConfigs map[string]RolloutInstanceState `json:"alertsConfigs,omitempty"`
+
+	//NextBatchTime is the time after which the next batch is rolled out when the rollout is paused
+	// +optional
+	NextBatchTime *metav1.Time `json:"nextBatchTime,omitempty"`
+
+	//Message describes the reason if the rollout is halted
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// FiringState is the live state of the alert in wavefront
+// +kubebuilder:validation:Enum=FIRING;CHECKING;SNOOZED;NO_DATA;IN_MAINTENANCE
+type FiringState string
+
+const (
+	Firing        FiringState = "FIRING"
+	Checking      FiringState = "CHECKING"
+	Snoozed       FiringState = "SNOOZED"
+	NoData        FiringState = "NO_DATA"
+	InMaintenance FiringState = "IN_MAINTENANCE"
+)
+
 type State string
 
 const (
@@ -110,8 +260,73 @@ const (
 	Creating            State = "Creating"
 	Updating            State = "Updating"
 	Deleting            State = "Deleting"
+	DryRun              State = "DryRun"
+	Paused              State = "Paused"
+)
+
+const (
+	// DryRunAnnotation set to "true" puts the reconcilers in dry-run mode for the resource.
+	// Changes are rendered and previewed in the status without calling wavefront create, update or delete APIs
+	DryRunAnnotation = "alertmanager.keikoproj.io/dry-run"
+	// ReconcileRequestAnnotation is set to a new value, for ex: current time, to trigger a reconcile of the resource.
+	// Alerts are pushed to wavefront again even if there is no change in the spec
+	ReconcileRequestAnnotation = "alertmanager.keikoproj.io/reconcile-request"
+	// PausedAnnotation set to "true" stops the reconcilers from calling wavefront for the resource until it is removed
+	PausedAnnotation = "alertmanager.keikoproj.io/paused"
+)
+
+// DryRunOperation is the wavefront operation which would have been performed
+type DryRunOperation string
+
+const (
+	DryRunCreate DryRunOperation = "Create"
+	DryRunUpdate DryRunOperation = "Update"
+	DryRunDelete DryRunOperation = "Delete"
 )
 
+// DryRunStatus is the preview of an alert change recorded in dry-run mode
+type DryRunStatus struct {
+	//Operation which would have been performed in wavefront
+	Operation DryRunOperation `json:"operation"`
+	//PayloadHash is the checksum of the rendered alert payload. Empty for delete
+	// +optional
+	PayloadHash string `json:"payloadHash,omitempty"`
+	//Diff between the live alert and the rendered alert in "field: old -> new" format. Only for update
+	// +optional
+	Diff []string `json:"diff,omitempty"`
+	//Timestamp represents the time of the preview
+	// +optional
+	Timestamp metav1.Time `json:"timestamp,omitempty"`
+}
+
+// AlertOperation is the operation performed on the wavefront alert
+type AlertOperation string
+
+const (
+	AlertCreate AlertOperation = "Create"
+	AlertUpdate AlertOperation = "Update"
+	// AlertRecreate is the create of an alert which got deleted from wavefront outside of alert-manager
+	AlertRecreate AlertOperation = "Recreate"
+	AlertDelete   AlertOperation = "Delete"
+)
+
+// AlertChange is an entry in the change history of an alert
+type AlertChange struct {
+	//Timestamp of the change
+	Timestamp metav1.Time `json:"timestamp"`
+	//Operation performed in wavefront
+	Operation AlertOperation `json:"operation"`
+	//TriggeredBy is the kind/name of the resource whose change got applied
+	// +optional
+	TriggeredBy string `json:"triggeredBy,omitempty"`
+	//Generation of the resource whose change got applied
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+	//Diff between the previous and the rendered alert in "field: old -> new" format. Only for update
+	// +optional
+	Diff []string `json:"diff,omitempty"`
+}
+
 // WavefrontAlertStatus defines the observed state of WavefrontAlert
 type WavefrontAlertStatus struct {
 	//State of the resource
@@ -128,6 +343,12 @@ type WavefrontAlertStatus struct {
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	//AlertsStatus details includes individual alert details
 	AlertsStatus map[string]AlertStatus `json:"alertsStatus,omitempty"`
+	//Rollout has the progress of the template change rollout to the alerts configs
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+	//LastHandledReconcileRequest is the last reconcile request annotation value handled by the controller
+	// +optional
+	LastHandledReconcileRequest string `json:"lastHandledReconcileRequest,omitempty"`
 }
 
 // AlertStatus consists of individual alert details
@@ -143,6 +364,23 @@ type AlertStatus struct {
 	//LastUpdatedTimestamp represents the last time the alert has been modified
 	// +optional
 	LastUpdatedTimestamp metav1.Time `json:"lastUpdatedTimestamp,omitempty"`
+	//PayloadHash is the checksum of the alert payload last applied in wavefront. Alert is not updated again if the rendered payload has the same checksum
+	// +optional
+	PayloadHash string `json:"payloadHash,omitempty"`
+	//DryRun is the preview of the pending change in dry-run mode.
+	//Not omitted when empty so a status patch clears the previous preview
+	// +optional
+	// +nullable
+	DryRun *DryRunStatus `json:"dryRun"`
+	//History has the latest changes of the alert, oldest first. Number of changes kept is capped by the controller
+	// +optional
+	History []AlertChange `json:"history,omitempty"`
+	//FiringState is the live state of the alert in wavefront as last seen by the firing state poller
+	// +optional
+	FiringState FiringState `json:"firingState,omitempty"`
+	//FiringStateSince is the time the alert was first seen in the firing state
+	// +optional
+	FiringStateSince *metav1.Time `json:"firingStateSince,omitempty"`
 }
 
 type AssociatedAlertsConfig struct {
@@ -152,6 +390,7 @@ type AssociatedAlertsConfig struct {
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:path=wavefrontalerts,scope=Namespaced,shortName=wfalerts,singular=wavefrontalert
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="current state of the wavefront alert"
 // +kubebuilder:printcolumn:name="RetryCount",type="integer",JSONPath=".status.retryCount",description="Retry count"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="time passed since wavefront alert creation"
@@ -0,0 +1,201 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// WavefrontDashboardKind is the kind of WavefrontDashboard resource
+	WavefrontDashboardKind = "WavefrontDashboard"
+)
+
+// WavefrontDashboardSpec defines the desired state of WavefrontDashboard
+type WavefrontDashboardSpec struct {
+	//DashboardName is the name of the dashboard in wavefront
+	// +required
+	DashboardName string `json:"dashboardName"`
+
+	//URL is the id of the dashboard in wavefront which is also the last part of the dashboard link. Only letters, numbers, '_' and '-' are allowed.
+	//Defaults to <namespace>-<name> for a standalone dashboard and <namespace>-<alerts config>-<name> for a dashboard rendered by an alerts config.
+	//Cluster id is added as a prefix to the default if configured
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	//Description of the dashboard
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	//Tags of the dashboard
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	//Sections of the dashboard
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	Sections []DashboardSection `json:"sections"`
+
+	//ExportedParams makes the dashboard a template which is rendered by the alerts configs with the param values, same as a WavefrontAlert template
+	// +optional
+	ExportedParams []string `json:"exportedParams,omitempty"`
+
+	//ExportedParamsDefaultValues is used to provide default values for exportedParams
+	// +optional
+	ExportedParamsDefaultValues map[string]string `json:"exportedParamsDefaultValues,omitempty"`
+
+	//DeletionPolicy (Optional) decides what happens to the wavefront dashboard when this resource is deleted. Defaults to Delete.
+	//Snooze retains the dashboard. Dashboards rendered by an alerts config follow the alerts config deletion policy
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// DashboardSection is a section of the dashboard
+type DashboardSection struct {
+	//Name of the section
+	// +required
+	Name string `json:"name"`
+
+	//Rows of the section
+	// +required
+	Rows []DashboardRow `json:"rows"`
+}
+
+// DashboardRow is a row of charts in a section
+type DashboardRow struct {
+	//Name of the row
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	//HeightFactor sets the height of the row. Defaults to 50
+	// +optional
+	HeightFactor int `json:"heightFactor,omitempty"`
+
+	//Charts of the row
+	// +required
+	Charts []DashboardChart `json:"charts"`
+}
+
+// DashboardChart is a chart in a row
+type DashboardChart struct {
+	//Name of the chart
+	// +required
+	Name string `json:"name"`
+
+	//Description of the chart
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	//Units of the y axis
+	// +optional
+	Units string `json:"units,omitempty"`
+
+	//Summarization is the strategy used to aggregate the points. Defaults to MEAN
+	// +kubebuilder:validation:Enum=MEAN;MEDIAN;MIN;MAX;SUM;COUNT;LAST;FIRST
+	// +optional
+	Summarization string `json:"summarization,omitempty"`
+
+	//Sources are the queries plotted in the chart
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	Sources []DashboardSource `json:"sources"`
+}
+
+// DashboardSource is a query plotted in a chart
+type DashboardSource struct {
+	//Name of the source
+	// +required
+	Name string `json:"name"`
+
+	//Query is the wavefront query
+	// +required
+	Query string `json:"query"`
+
+	//Disabled hides the source in the chart
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// DashboardStatus is the status of a dashboard created in wavefront
+type DashboardStatus struct {
+	//ID of the dashboard in wavefront
+	ID string `json:"id"`
+	//Name of the dashboard in wavefront
+	Name string `json:"dashboardName"`
+	//Link to the dashboard in wavefront
+	// +optional
+	Link string `json:"link,omitempty"`
+	// +optional
+	State State `json:"state,omitempty"`
+	// +optional
+	ErrorDescription string `json:"errorDescription,omitempty"`
+	//LastChangeChecksum is the checksum of the rendered dashboard last applied in wavefront. Dashboard is not updated again if it is the same
+	// +optional
+	LastChangeChecksum string `json:"lastChangeChecksum,omitempty"`
+	//LastUpdatedTimestamp represents the last time the dashboard has been modified
+	// +optional
+	LastUpdatedTimestamp metav1.Time `json:"lastUpdatedTimestamp,omitempty"`
+}
+
+// WavefrontDashboardStatus defines the observed state of WavefrontDashboard
+type WavefrontDashboardStatus struct {
+	//State of the resource
+	State State `json:"state,omitempty"`
+	//RetryCount in case of error
+	RetryCount int `json:"retryCount"`
+	//ErrorDescription in case of error
+	ErrorDescription string `json:"errorDescription,omitempty"`
+	//ObservedGeneration will have the last generation from spec metadata
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	//DashboardsStatus has the dashboard created from a standalone dashboard keyed by the resource name.
+	//Dashboards rendered from a template are tracked in the status of the alerts configs
+	// +optional
+	DashboardsStatus map[string]DashboardStatus `json:"dashboardsStatus,omitempty"`
+	//LastHandledReconcileRequest is the last reconcile request annotation value handled by the controller
+	// +optional
+	LastHandledReconcileRequest string `json:"lastHandledReconcileRequest,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=wavefrontdashboards,scope=Namespaced,shortName=wfdashboards,singular=wavefrontdashboard
+// +kubebuilder:printcolumn:name="Name",type="string",JSONPath=".spec.dashboardName",description="name of the dashboard in wavefront"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="current state of the wavefront dashboard"
+// +kubebuilder:printcolumn:name="RetryCount",type="integer",JSONPath=".status.retryCount",description="Retry count"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="time passed since wavefront dashboard creation"
+
+// WavefrontDashboard is the Schema for the wavefrontdashboards API
+type WavefrontDashboard struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WavefrontDashboardSpec   `json:"spec,omitempty"`
+	Status WavefrontDashboardStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// WavefrontDashboardList contains a list of WavefrontDashboard
+type WavefrontDashboardList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WavefrontDashboard `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WavefrontDashboard{}, &WavefrontDashboardList{})
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// WavefrontDerivedMetricKind is the kind of WavefrontDerivedMetric resource
+	WavefrontDerivedMetricKind = "WavefrontDerivedMetric"
+)
+
+// WavefrontDerivedMetricSpec defines the desired state of WavefrontDerivedMetric
+type WavefrontDerivedMetricSpec struct {
+	//DerivedMetricName is the name of the derived metric in wavefront
+	// +required
+	DerivedMetricName string `json:"derivedMetricName"`
+
+	//Query is the wavefront query whose result is stored as the derived metric. aliasMetric() can be used to name the stored metric
+	// +required
+	Query string `json:"query"`
+
+	//Minutes is the interval in minutes the query runs at
+	// +kubebuilder:validation:Minimum=1
+	// +required
+	Minutes int `json:"minutes"`
+
+	//IncludeObsoleteMetrics includes the metrics which haven't reported any data in the past 4 weeks in the query. Excluded by default
+	// +optional
+	IncludeObsoleteMetrics bool `json:"includeObsoleteMetrics,omitempty"`
+
+	//AdditionalInformation about the derived metric
+	// +optional
+	AdditionalInformation string `json:"additionalInformation,omitempty"`
+
+	//Tags of the derived metric
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	//ExportedParams makes the derived metric a template which is rendered by the alerts configs with the param values, same as a WavefrontAlert template
+	// +optional
+	ExportedParams []string `json:"exportedParams,omitempty"`
+
+	//ExportedParamsDefaultValues is used to provide default values for exportedParams
+	// +optional
+	ExportedParamsDefaultValues map[string]string `json:"exportedParamsDefaultValues,omitempty"`
+
+	//DeletionPolicy (Optional) decides what happens to the wavefront derived metric when this resource is deleted. Defaults to Delete.
+	//Snooze retains the derived metric. Derived metrics rendered by an alerts config follow the alerts config deletion policy
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// DerivedMetricStatus is the status of a derived metric created in wavefront
+type DerivedMetricStatus struct {
+	//ID of the derived metric in wavefront
+	ID string `json:"id"`
+	//Name of the derived metric in wavefront
+	Name string `json:"derivedMetricName"`
+	// +optional
+	State State `json:"state,omitempty"`
+	// +optional
+	ErrorDescription string `json:"errorDescription,omitempty"`
+	//LastChangeChecksum is the checksum of the rendered derived metric last applied in wavefront. Derived metric is not updated again if it is the same
+	// +optional
+	LastChangeChecksum string `json:"lastChangeChecksum,omitempty"`
+	//LastUpdatedTimestamp represents the last time the derived metric has been modified
+	// +optional
+	LastUpdatedTimestamp metav1.Time `json:"lastUpdatedTimestamp,omitempty"`
+}
+
+// WavefrontDerivedMetricStatus defines the observed state of WavefrontDerivedMetric
+type WavefrontDerivedMetricStatus struct {
+	//State of the resource
+	State State `json:"state,omitempty"`
+	//RetryCount in case of error
+	RetryCount int `json:"retryCount"`
+	//ErrorDescription in case of error
+	ErrorDescription string `json:"errorDescription,omitempty"`
+	//ObservedGeneration will have the last generation from spec metadata
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	//DerivedMetricsStatus has the derived metric created from a standalone derived metric keyed by the resource name.
+	//Derived metrics rendered from a template are tracked in the status of the alerts configs
+	// +optional
+	DerivedMetricsStatus map[string]DerivedMetricStatus `json:"derivedMetricsStatus,omitempty"`
+	//LastHandledReconcileRequest is the last reconcile request annotation value handled by the controller
+	// +optional
+	LastHandledReconcileRequest string `json:"lastHandledReconcileRequest,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=wavefrontderivedmetrics,scope=Namespaced,shortName=wfderivedmetrics,singular=wavefrontderivedmetric
+// +kubebuilder:printcolumn:name="Name",type="string",JSONPath=".spec.derivedMetricName",description="name of the derived metric in wavefront"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="current state of the wavefront derived metric"
+// +kubebuilder:printcolumn:name="RetryCount",type="integer",JSONPath=".status.retryCount",description="Retry count"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="time passed since wavefront derived metric creation"
+
+// WavefrontDerivedMetric is the Schema for the wavefrontderivedmetrics API
+type WavefrontDerivedMetric struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WavefrontDerivedMetricSpec   `json:"spec,omitempty"`
+	Status WavefrontDerivedMetricStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// WavefrontDerivedMetricList contains a list of WavefrontDerivedMetric
+type WavefrontDerivedMetricList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WavefrontDerivedMetric `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WavefrontDerivedMetric{}, &WavefrontDerivedMetricList{})
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// WebhookAlertKind is the kind of WebhookAlert resource
+	WebhookAlertKind = "WebhookAlert"
+)
+
+// WebhookAlertSpec defines the desired state of WebhookAlert
+type WebhookAlertSpec struct {
+	//Endpoint of the alerting system which implements the webhook alert contract
+	// +required
+	Endpoint WebhookEndpoint `json:"endpoint"`
+
+	//Payload is the JSON document sent to the endpoint as the alert definition.
+	//It can use go lang template expressions which are substituted with the values from params
+	// +required
+	Payload string `json:"payload"`
+
+	//Params provides the values for the template expressions used in the payload
+	// +optional
+	Params OrderedMap `json:"params,omitempty"`
+}
+
+// WebhookEndpoint represents the http endpoint of an in-house alerting system
+type WebhookEndpoint struct {
+	//URL is the base url of the webhook. Alerts are managed under <url>/alerts and <url>/alerts/<id>
+	// +required
+	URL string `json:"url"`
+
+	//AuthSecretRef (Optional) refers to a key of a secret in the same namespace whose value is sent as a bearer token
+	// +optional
+	AuthSecretRef *v1.SecretKeySelector `json:"authSecretRef,omitempty"`
+
+	//TimeoutSeconds for each call made to the endpoint. Defaults to 30 seconds
+	// +optional
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+}
+
+// WebhookAlertStatus defines the observed state of WebhookAlert
+type WebhookAlertStatus struct {
+	//State of the resource
+	State State `json:"state,omitempty"`
+	//RetryCount in case of error
+	RetryCount int `json:"retryCount"`
+	//ErrorDescription in case of error
+	ErrorDescription string `json:"errorDescription,omitempty"`
+	//ID of the alert returned by the endpoint on create
+	ID string `json:"id,omitempty"`
+	//LastChangeChecksum represents the checksum of the rendered payload last sent to the endpoint
+	LastChangeChecksum string `json:"lastChangeChecksum,omitempty"`
+	//ObservedGeneration will have the last generation from spec metadata
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	//LastUpdatedTimestamp represents the last time the alert has been modified
+	// +optional
+	LastUpdatedTimestamp metav1.Time `json:"lastUpdatedTimestamp,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=webhookalerts,scope=Namespaced,shortName=whalerts,singular=webhookalert
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="current state of the webhook alert"
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description="alert id returned by the endpoint"
+// +kubebuilder:printcolumn:name="RetryCount",type="integer",JSONPath=".status.retryCount",description="Retry count"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="time passed since webhook alert creation"
+
+// WebhookAlert is the Schema for the webhookalerts API
+type WebhookAlert struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WebhookAlertSpec   `json:"spec,omitempty"`
+	Status WebhookAlertStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// WebhookAlertList contains a list of WebhookAlert
+type WebhookAlertList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WebhookAlert `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WebhookAlert{}, &WebhookAlertList{})
+}
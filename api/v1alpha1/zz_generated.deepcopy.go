@@ -21,15 +21,55 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertChange) DeepCopyInto(out *AlertChange) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	if in.Diff != nil {
+		in, out := &in.Diff, &out.Diff
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertChange.
+func (in *AlertChange) DeepCopy() *AlertChange {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AlertStatus) DeepCopyInto(out *AlertStatus) {
 	*out = *in
 	out.AssociatedAlert = in.AssociatedAlert
 	out.AssociatedAlertsConfig = in.AssociatedAlertsConfig
 	in.LastUpdatedTimestamp.DeepCopyInto(&out.LastUpdatedTimestamp)
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(DryRunStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]AlertChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FiringStateSince != nil {
+		in, out := &in.FiringStateSince, &out.FiringStateSince
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertStatus.
@@ -112,6 +152,11 @@ func (in *AlertsConfigSpec) DeepCopyInto(out *AlertsConfigSpec) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.TemplateSelector != nil {
+		in, out := &in.TemplateSelector, &out.TemplateSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.GlobalParams != nil {
 		in, out := &in.GlobalParams, &out.GlobalParams
 		*out = make(OrderedMap, len(*in))
@@ -119,6 +164,20 @@ func (in *AlertsConfigSpec) DeepCopyInto(out *AlertsConfigSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Dashboards != nil {
+		in, out := &in.Dashboards, &out.Dashboards
+		*out = make(map[string]DashboardConfig, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.DerivedMetrics != nil {
+		in, out := &in.DerivedMetrics, &out.DerivedMetrics
+		*out = make(map[string]DerivedMetricConfig, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertsConfigSpec.
@@ -141,6 +200,20 @@ func (in *AlertsConfigStatus) DeepCopyInto(out *AlertsConfigStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.DashboardsStatus != nil {
+		in, out := &in.DashboardsStatus, &out.DashboardsStatus
+		*out = make(map[string]DashboardStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.DerivedMetricsStatus != nil {
+		in, out := &in.DerivedMetricsStatus, &out.DerivedMetricsStatus
+		*out = make(map[string]DerivedMetricStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertsConfigStatus.
@@ -183,6 +256,113 @@ func (in *AssociatedAlertsConfig) DeepCopy() *AssociatedAlertsConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterWavefrontAlert) DeepCopyInto(out *ClusterWavefrontAlert) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterWavefrontAlert.
+func (in *ClusterWavefrontAlert) DeepCopy() *ClusterWavefrontAlert {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterWavefrontAlert)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterWavefrontAlert) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterWavefrontAlertList) DeepCopyInto(out *ClusterWavefrontAlertList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterWavefrontAlert, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterWavefrontAlertList.
+func (in *ClusterWavefrontAlertList) DeepCopy() *ClusterWavefrontAlertList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterWavefrontAlertList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterWavefrontAlertList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterWavefrontAlertSpec) DeepCopyInto(out *ClusterWavefrontAlertSpec) {
+	*out = *in
+	in.WavefrontAlertSpec.DeepCopyInto(&out.WavefrontAlertSpec)
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterWavefrontAlertSpec.
+func (in *ClusterWavefrontAlertSpec) DeepCopy() *ClusterWavefrontAlertSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterWavefrontAlertSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterWavefrontAlertStatus) DeepCopyInto(out *ClusterWavefrontAlertStatus) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make(map[string]NamespaceAlertsStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterWavefrontAlertStatus.
+func (in *ClusterWavefrontAlertStatus) DeepCopy() *ClusterWavefrontAlertStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterWavefrontAlertStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Config) DeepCopyInto(out *Config) {
 	*out = *in
@@ -207,123 +387,198 @@ func (in *Config) DeepCopy() *Config {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GVK) DeepCopyInto(out *GVK) {
+func (in *DashboardChart) DeepCopyInto(out *DashboardChart) {
 	*out = *in
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]DashboardSource, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GVK.
-func (in *GVK) DeepCopy() *GVK {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardChart.
+func (in *DashboardChart) DeepCopy() *DashboardChart {
 	if in == nil {
 		return nil
 	}
-	out := new(GVK)
+	out := new(DashboardChart)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *WavefrontAlert) DeepCopyInto(out *WavefrontAlert) {
+func (in *DashboardConfig) DeepCopyInto(out *DashboardConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(OrderedMap, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WavefrontAlert.
-func (in *WavefrontAlert) DeepCopy() *WavefrontAlert {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardConfig.
+func (in *DashboardConfig) DeepCopy() *DashboardConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(WavefrontAlert)
+	out := new(DashboardConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *WavefrontAlert) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardRow) DeepCopyInto(out *DashboardRow) {
+	*out = *in
+	if in.Charts != nil {
+		in, out := &in.Charts, &out.Charts
+		*out = make([]DashboardChart, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	return nil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardRow.
+func (in *DashboardRow) DeepCopy() *DashboardRow {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardRow)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *WavefrontAlertList) DeepCopyInto(out *WavefrontAlertList) {
+func (in *DashboardSection) DeepCopyInto(out *DashboardSection) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]WavefrontAlert, len(*in))
+	if in.Rows != nil {
+		in, out := &in.Rows, &out.Rows
+		*out = make([]DashboardRow, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WavefrontAlertList.
-func (in *WavefrontAlertList) DeepCopy() *WavefrontAlertList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardSection.
+func (in *DashboardSection) DeepCopy() *DashboardSection {
 	if in == nil {
 		return nil
 	}
-	out := new(WavefrontAlertList)
+	out := new(DashboardSection)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *WavefrontAlertList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardSource) DeepCopyInto(out *DashboardSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardSource.
+func (in *DashboardSource) DeepCopy() *DashboardSource {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(DashboardSource)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *WavefrontAlertSpec) DeepCopyInto(out *WavefrontAlertSpec) {
+func (in *DashboardStatus) DeepCopyInto(out *DashboardStatus) {
 	*out = *in
-	if in.Minutes != nil {
-		in, out := &in.Minutes, &out.Minutes
-		*out = new(int32)
-		**out = **in
+	in.LastUpdatedTimestamp.DeepCopyInto(&out.LastUpdatedTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardStatus.
+func (in *DashboardStatus) DeepCopy() *DashboardStatus {
+	if in == nil {
+		return nil
 	}
-	if in.ResolveAfter != nil {
-		in, out := &in.ResolveAfter, &out.ResolveAfter
-		*out = new(int32)
-		**out = **in
+	out := new(DashboardStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DerivedMetricConfig) DeepCopyInto(out *DerivedMetricConfig) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(OrderedMap, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DerivedMetricConfig.
+func (in *DerivedMetricConfig) DeepCopy() *DerivedMetricConfig {
+	if in == nil {
+		return nil
 	}
-	if in.ExportedParams != nil {
-		in, out := &in.ExportedParams, &out.ExportedParams
+	out := new(DerivedMetricConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DerivedMetricStatus) DeepCopyInto(out *DerivedMetricStatus) {
+	*out = *in
+	in.LastUpdatedTimestamp.DeepCopyInto(&out.LastUpdatedTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DerivedMetricStatus.
+func (in *DerivedMetricStatus) DeepCopy() *DerivedMetricStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DerivedMetricStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRunStatus) DeepCopyInto(out *DryRunStatus) {
+	*out = *in
+	if in.Diff != nil {
+		in, out := &in.Diff, &out.Diff
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.ExportedParamsDefaultValues != nil {
-		in, out := &in.ExportedParamsDefaultValues, &out.ExportedParamsDefaultValues
-		*out = make(OrderedMap, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunStatus.
+func (in *DryRunStatus) DeepCopy() *DryRunStatus {
+	if in == nil {
+		return nil
 	}
+	out := new(DryRunStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WavefrontAlertSpec.
-func (in *WavefrontAlertSpec) DeepCopy() *WavefrontAlertSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GVK) DeepCopyInto(out *GVK) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GVK.
+func (in *GVK) DeepCopy() *GVK {
 	if in == nil {
 		return nil
 	}
-	out := new(WavefrontAlertSpec)
+	out := new(GVK)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *WavefrontAlertStatus) DeepCopyInto(out *WavefrontAlertStatus) {
+func (in *NamespaceAlertsStatus) DeepCopyInto(out *NamespaceAlertsStatus) {
 	*out = *in
 	if in.AlertsStatus != nil {
 		in, out := &in.AlertsStatus, &out.AlertsStatus
@@ -334,12 +589,584 @@ func (in *WavefrontAlertStatus) DeepCopyInto(out *WavefrontAlertStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WavefrontAlertStatus.
-func (in *WavefrontAlertStatus) DeepCopy() *WavefrontAlertStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceAlertsStatus.
+func (in *NamespaceAlertsStatus) DeepCopy() *NamespaceAlertsStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(WavefrontAlertStatus)
+	out := new(NamespaceAlertsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParamSchema) DeepCopyInto(out *ParamSchema) {
+	*out = *in
+	if in.AllowedValues != nil {
+		in, out := &in.AllowedValues, &out.AllowedValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Required != nil {
+		in, out := &in.Required, &out.Required
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParamSchema.
+func (in *ParamSchema) DeepCopy() *ParamSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(ParamSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	if in.AlertsConfigs != nil {
+		in, out := &in.AlertsConfigs, &out.AlertsConfigs
+		*out = make(map[string]RolloutInstanceState, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NextBatchTime != nil {
+		in, out := &in.NextBatchTime, &out.NextBatchTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
+	*out = *in
+	if in.Canaries != nil {
+		in, out := &in.Canaries, &out.Canaries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxFailures != nil {
+		in, out := &in.MaxFailures, &out.MaxFailures
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategy.
+func (in *RolloutStrategy) DeepCopy() *RolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WavefrontAlert) DeepCopyInto(out *WavefrontAlert) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WavefrontAlert.
+func (in *WavefrontAlert) DeepCopy() *WavefrontAlert {
+	if in == nil {
+		return nil
+	}
+	out := new(WavefrontAlert)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WavefrontAlert) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WavefrontAlertList) DeepCopyInto(out *WavefrontAlertList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WavefrontAlert, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WavefrontAlertList.
+func (in *WavefrontAlertList) DeepCopy() *WavefrontAlertList {
+	if in == nil {
+		return nil
+	}
+	out := new(WavefrontAlertList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WavefrontAlertList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WavefrontAlertSpec) DeepCopyInto(out *WavefrontAlertSpec) {
+	*out = *in
+	if in.Minutes != nil {
+		in, out := &in.Minutes, &out.Minutes
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.ResolveAfter != nil {
+		in, out := &in.ResolveAfter, &out.ResolveAfter
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExportedParams != nil {
+		in, out := &in.ExportedParams, &out.ExportedParams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExportedParamsDefaultValues != nil {
+		in, out := &in.ExportedParamsDefaultValues, &out.ExportedParamsDefaultValues
+		*out = make(OrderedMap, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExportedParamsSchema != nil {
+		in, out := &in.ExportedParamsSchema, &out.ExportedParamsSchema
+		*out = make(map[string]ParamSchema, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.AlertCheckFrequency != nil {
+		in, out := &in.AlertCheckFrequency, &out.AlertCheckFrequency
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(RolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WavefrontAlertSpec.
+func (in *WavefrontAlertSpec) DeepCopy() *WavefrontAlertSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WavefrontAlertSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WavefrontAlertStatus) DeepCopyInto(out *WavefrontAlertStatus) {
+	*out = *in
+	if in.AlertsStatus != nil {
+		in, out := &in.AlertsStatus, &out.AlertsStatus
+		*out = make(map[string]AlertStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WavefrontAlertStatus.
+func (in *WavefrontAlertStatus) DeepCopy() *WavefrontAlertStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WavefrontAlertStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WavefrontDashboard) DeepCopyInto(out *WavefrontDashboard) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WavefrontDashboard.
+func (in *WavefrontDashboard) DeepCopy() *WavefrontDashboard {
+	if in == nil {
+		return nil
+	}
+	out := new(WavefrontDashboard)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WavefrontDashboard) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WavefrontDashboardList) DeepCopyInto(out *WavefrontDashboardList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WavefrontDashboard, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WavefrontDashboardList.
+func (in *WavefrontDashboardList) DeepCopy() *WavefrontDashboardList {
+	if in == nil {
+		return nil
+	}
+	out := new(WavefrontDashboardList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WavefrontDashboardList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WavefrontDashboardSpec) DeepCopyInto(out *WavefrontDashboardSpec) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sections != nil {
+		in, out := &in.Sections, &out.Sections
+		*out = make([]DashboardSection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExportedParams != nil {
+		in, out := &in.ExportedParams, &out.ExportedParams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExportedParamsDefaultValues != nil {
+		in, out := &in.ExportedParamsDefaultValues, &out.ExportedParamsDefaultValues
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WavefrontDashboardSpec.
+func (in *WavefrontDashboardSpec) DeepCopy() *WavefrontDashboardSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WavefrontDashboardSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WavefrontDashboardStatus) DeepCopyInto(out *WavefrontDashboardStatus) {
+	*out = *in
+	if in.DashboardsStatus != nil {
+		in, out := &in.DashboardsStatus, &out.DashboardsStatus
+		*out = make(map[string]DashboardStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WavefrontDashboardStatus.
+func (in *WavefrontDashboardStatus) DeepCopy() *WavefrontDashboardStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WavefrontDashboardStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WavefrontDerivedMetric) DeepCopyInto(out *WavefrontDerivedMetric) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WavefrontDerivedMetric.
+func (in *WavefrontDerivedMetric) DeepCopy() *WavefrontDerivedMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(WavefrontDerivedMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WavefrontDerivedMetric) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WavefrontDerivedMetricList) DeepCopyInto(out *WavefrontDerivedMetricList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WavefrontDerivedMetric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WavefrontDerivedMetricList.
+func (in *WavefrontDerivedMetricList) DeepCopy() *WavefrontDerivedMetricList {
+	if in == nil {
+		return nil
+	}
+	out := new(WavefrontDerivedMetricList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WavefrontDerivedMetricList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WavefrontDerivedMetricSpec) DeepCopyInto(out *WavefrontDerivedMetricSpec) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExportedParams != nil {
+		in, out := &in.ExportedParams, &out.ExportedParams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExportedParamsDefaultValues != nil {
+		in, out := &in.ExportedParamsDefaultValues, &out.ExportedParamsDefaultValues
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WavefrontDerivedMetricSpec.
+func (in *WavefrontDerivedMetricSpec) DeepCopy() *WavefrontDerivedMetricSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WavefrontDerivedMetricSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WavefrontDerivedMetricStatus) DeepCopyInto(out *WavefrontDerivedMetricStatus) {
+	*out = *in
+	if in.DerivedMetricsStatus != nil {
+		in, out := &in.DerivedMetricsStatus, &out.DerivedMetricsStatus
+		*out = make(map[string]DerivedMetricStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WavefrontDerivedMetricStatus.
+func (in *WavefrontDerivedMetricStatus) DeepCopy() *WavefrontDerivedMetricStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WavefrontDerivedMetricStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookAlert) DeepCopyInto(out *WebhookAlert) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookAlert.
+func (in *WebhookAlert) DeepCopy() *WebhookAlert {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookAlert)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WebhookAlert) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookAlertList) DeepCopyInto(out *WebhookAlertList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WebhookAlert, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookAlertList.
+func (in *WebhookAlertList) DeepCopy() *WebhookAlertList {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookAlertList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WebhookAlertList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookAlertSpec) DeepCopyInto(out *WebhookAlertSpec) {
+	*out = *in
+	in.Endpoint.DeepCopyInto(&out.Endpoint)
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(OrderedMap, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookAlertSpec.
+func (in *WebhookAlertSpec) DeepCopy() *WebhookAlertSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookAlertSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookAlertStatus) DeepCopyInto(out *WebhookAlertStatus) {
+	*out = *in
+	in.LastUpdatedTimestamp.DeepCopyInto(&out.LastUpdatedTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookAlertStatus.
+func (in *WebhookAlertStatus) DeepCopy() *WebhookAlertStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookAlertStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookEndpoint) DeepCopyInto(out *WebhookEndpoint) {
+	*out = *in
+	if in.AuthSecretRef != nil {
+		in, out := &in.AuthSecretRef, &out.AuthSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookEndpoint.
+func (in *WebhookEndpoint) DeepCopy() *WebhookEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookEndpoint)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/keikoproj/alert-manager/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AlertsConfigKind is the kind of AlertsConfig resource
+	AlertsConfigKind = "AlertsConfig"
+)
+
+// AlertsConfigSpec defines the desired state of AlertsConfig
+type AlertsConfigSpec struct {
+	//GlobalGVK- This is a global GVK config but user can overwrite it in individual alert section.
+	//This CRD must be installed in the cluster otherwise AlertsConfig will go into error state
+	// +optional
+	GlobalGVK v1alpha1.GVK `json:"globalGVK,omitempty"`
+	//Alerts- Provide each individual alert config keyed by the template name
+	// +optional
+	Alerts map[string]v1alpha1.Config `json:"alerts,omitempty"`
+	//TemplateSelector (Optional) selects the alert templates by labels instead of listing each and every template in alerts section.
+	//Templates of GlobalGVK kind are selected and global params are applied to all of them
+	// +optional
+	TemplateSelector *metav1.LabelSelector `json:"templateSelector,omitempty"`
+	//GlobalParams is the place holder to provide any global param values which can be used in individual config sections.
+	//Params from individual config sections take precedence over global params
+	// +optional
+	GlobalParams v1alpha1.OrderedMap `json:"globalParams,omitempty"`
+	//DeletionPolicy (Optional) decides what happens to the wavefront alerts when this resource or an alert from it is deleted.
+	//Defaults to Delete. This can be overwritten for an individual alert in alerts section
+	// +optional
+	DeletionPolicy v1alpha1.DeletionPolicy `json:"deletionPolicy,omitempty"`
+	//Dashboards (Optional) renders the WavefrontDashboard templates in the same namespace, keyed by the template name
+	// +optional
+	Dashboards map[string]v1alpha1.DashboardConfig `json:"dashboards,omitempty"`
+	//DerivedMetrics (Optional) renders the WavefrontDerivedMetric templates in the same namespace, keyed by the template name
+	// +optional
+	DerivedMetrics map[string]v1alpha1.DerivedMetricConfig `json:"derivedMetrics,omitempty"`
+}
+
+// AlertsConfigStatus defines the observed state of AlertsConfig
+type AlertsConfigStatus struct {
+	//State of the resource
+	// +optional
+	State v1alpha1.State `json:"state,omitempty"`
+	//RetryCount in case of error
+	// +optional
+	RetryCount int `json:"retryCount,omitempty"`
+	//AlertsCount provides total number of alerts configured
+	// +optional
+	AlertsCount int `json:"alertsCount,omitempty"`
+	//ErrorDescription in case of error
+	// +optional
+	ErrorDescription string `json:"errorDescription,omitempty"`
+	//Instances are the alerts rendered by the resource, keyed by the template name
+	// +listType=map
+	// +listMapKey=key
+	// +optional
+	Instances []AlertInstance `json:"instances,omitempty"`
+	//LastHandledReconcileRequest is the last reconcile request annotation value handled by the controller
+	// +optional
+	LastHandledReconcileRequest string `json:"lastHandledReconcileRequest,omitempty"`
+	//DashboardsStatus has the dashboards rendered by this alerts config keyed by the template name
+	// +optional
+	DashboardsStatus map[string]v1alpha1.DashboardStatus `json:"dashboardsStatus,omitempty"`
+	//DerivedMetricsStatus has the derived metrics rendered by this alerts config keyed by the template name
+	// +optional
+	DerivedMetricsStatus map[string]v1alpha1.DerivedMetricStatus `json:"derivedMetricsStatus,omitempty"`
+	//Conditions are computed from the state of the resource. They are read only and not stored
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="current state of the alerts config"
+// +kubebuilder:printcolumn:name="RetryCount",type="integer",JSONPath=".status.retryCount",description="Retry count"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="time passed since alerts config creation"
+// AlertsConfig is the Schema for the alertsconfigs API
+type AlertsConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AlertsConfigSpec   `json:"spec,omitempty"`
+	Status AlertsConfigStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// AlertsConfigList contains a list of AlertsConfig
+type AlertsConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AlertsConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AlertsConfig{}, &AlertsConfigList{})
+}
@@ -0,0 +1,481 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keikoproj/alert-manager/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// Conversion is lossless: spec fields which don't convert back to the same value, for ex: "1h" converted to 60 minutes,
+// are kept in an annotation of the converted object and restored when it is converted back unless the field got changed
+const (
+	// V1alpha1FieldsAnnotation keeps the v1alpha1 fields of a v1beta1 object which don't convert back to the same value
+	V1alpha1FieldsAnnotation = "alertmanager.keikoproj.io/v1alpha1-fields"
+	// V1beta1FieldsAnnotation keeps the v1beta1 fields of a v1alpha1 object which don't convert back to the same value
+	V1beta1FieldsAnnotation = "alertmanager.keikoproj.io/v1beta1-fields"
+)
+
+const (
+	// pagerDutyPrefix is the prefix of a PagerDuty key in v1alpha1 target
+	pagerDutyPrefix = "pd:"
+	// targetPrefix is the prefix of a wavefront alert target ID in v1alpha1 target
+	targetPrefix = "target:"
+)
+
+// v1alpha1Fields are the v1alpha1 fields kept in V1alpha1FieldsAnnotation
+type v1alpha1Fields struct {
+	Minutes             *intstr.IntOrString `json:"minutes,omitempty"`
+	ResolveAfter        *intstr.IntOrString `json:"resolveAfterMinutes,omitempty"`
+	AlertCheckFrequency *intstr.IntOrString `json:"alertCheckFrequency,omitempty"`
+	Target              *string             `json:"target,omitempty"`
+}
+
+// v1beta1Fields are the v1beta1 fields kept in V1beta1FieldsAnnotation
+type v1beta1Fields struct {
+	For            *Duration      `json:"for,omitempty"`
+	ResolveAfter   *Duration      `json:"resolveAfter,omitempty"`
+	CheckFrequency *Duration      `json:"checkFrequency,omitempty"`
+	Targets        *[]AlertTarget `json:"targets,omitempty"`
+}
+
+// ConvertTo converts this WavefrontAlert to the hub version (v1alpha1)
+func (src *WavefrontAlert) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.WavefrontAlert)
+	in := src.DeepCopy()
+	dst.ObjectMeta = in.ObjectMeta
+
+	dst.Spec = v1alpha1.WavefrontAlertSpec{
+		AlertType:                   in.Spec.AlertType,
+		AlertName:                   in.Spec.AlertName,
+		Condition:                   in.Spec.Condition,
+		Severity:                    in.Spec.Severity,
+		Minutes:                     durationToMinutes(in.Spec.For),
+		ResolveAfter:                durationToMinutes(in.Spec.ResolveAfter),
+		Target:                      targetsToString(in.Spec.Targets),
+		AdditionalInformation:       in.Spec.AdditionalInformation,
+		Tags:                        in.Spec.Tags,
+		Description:                 in.Spec.Description,
+		DisplayExpression:           in.Spec.DisplayExpression,
+		ExportedParams:              in.Spec.ExportedParams,
+		ExportedParamsDefaultValues: in.Spec.ExportedParamsDefaultValues,
+		ExportedParamsSchema:        in.Spec.ExportedParamsSchema,
+		AlertCheckFrequency:         durationToMinutes(in.Spec.CheckFrequency),
+		RolloutStrategy:             in.Spec.RolloutStrategy,
+		DeletionPolicy:              in.Spec.DeletionPolicy,
+	}
+
+	// restore the v1alpha1 fields unless they got changed in v1beta1
+	var kept v1alpha1Fields
+	popFields(&dst.ObjectMeta, V1alpha1FieldsAnnotation, &kept)
+	if kept.Minutes != nil && minutesToDuration(kept.Minutes) == in.Spec.For {
+		dst.Spec.Minutes = kept.Minutes
+	}
+	if kept.ResolveAfter != nil && minutesToDuration(kept.ResolveAfter) == in.Spec.ResolveAfter {
+		dst.Spec.ResolveAfter = kept.ResolveAfter
+	}
+	if kept.AlertCheckFrequency != nil && minutesToDuration(kept.AlertCheckFrequency) == in.Spec.CheckFrequency {
+		dst.Spec.AlertCheckFrequency = kept.AlertCheckFrequency
+	}
+	if kept.Target != nil && reflect.DeepEqual(stringToTargets(*kept.Target), in.Spec.Targets) {
+		dst.Spec.Target = *kept.Target
+	}
+
+	// keep the v1beta1 fields which don't convert back to the same value
+	var lost v1beta1Fields
+	if minutesToDuration(dst.Spec.Minutes) != in.Spec.For {
+		lost.For = &in.Spec.For
+	}
+	if minutesToDuration(dst.Spec.ResolveAfter) != in.Spec.ResolveAfter {
+		lost.ResolveAfter = &in.Spec.ResolveAfter
+	}
+	if minutesToDuration(dst.Spec.AlertCheckFrequency) != in.Spec.CheckFrequency {
+		lost.CheckFrequency = &in.Spec.CheckFrequency
+	}
+	if !reflect.DeepEqual(stringToTargets(dst.Spec.Target), in.Spec.Targets) {
+		lost.Targets = &in.Spec.Targets
+	}
+	if err := pushFields(&dst.ObjectMeta, V1beta1FieldsAnnotation, &lost); err != nil {
+		return err
+	}
+
+	dst.Status = v1alpha1.WavefrontAlertStatus{
+		State:                       in.Status.State,
+		RetryCount:                  in.Status.RetryCount,
+		ErrorDescription:            in.Status.ErrorDescription,
+		ExportParamsChecksum:        in.Status.ExportParamsChecksum,
+		LastChangeChecksum:          in.Status.LastChangeChecksum,
+		ObservedGeneration:          in.Status.ObservedGeneration,
+		AlertsStatus:                instancesToAlertsStatus(in.Status.Instances),
+		Rollout:                     in.Status.Rollout,
+		LastHandledReconcileRequest: in.Status.LastHandledReconcileRequest,
+	}
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version
+func (dst *WavefrontAlert) ConvertFrom(srcRaw conversion.Hub) error {
+	in := srcRaw.(*v1alpha1.WavefrontAlert).DeepCopy()
+	dst.ObjectMeta = in.ObjectMeta
+
+	dst.Spec = WavefrontAlertSpec{
+		AlertType:                   in.Spec.AlertType,
+		AlertName:                   in.Spec.AlertName,
+		Condition:                   in.Spec.Condition,
+		Severity:                    in.Spec.Severity,
+		For:                         minutesToDuration(in.Spec.Minutes),
+		ResolveAfter:                minutesToDuration(in.Spec.ResolveAfter),
+		Targets:                     stringToTargets(in.Spec.Target),
+		AdditionalInformation:       in.Spec.AdditionalInformation,
+		Tags:                        in.Spec.Tags,
+		Description:                 in.Spec.Description,
+		DisplayExpression:           in.Spec.DisplayExpression,
+		ExportedParams:              in.Spec.ExportedParams,
+		ExportedParamsDefaultValues: in.Spec.ExportedParamsDefaultValues,
+		ExportedParamsSchema:        in.Spec.ExportedParamsSchema,
+		CheckFrequency:              minutesToDuration(in.Spec.AlertCheckFrequency),
+		RolloutStrategy:             in.Spec.RolloutStrategy,
+		DeletionPolicy:              in.Spec.DeletionPolicy,
+	}
+
+	// restore the v1beta1 fields unless they got changed in v1alpha1
+	var kept v1beta1Fields
+	popFields(&dst.ObjectMeta, V1beta1FieldsAnnotation, &kept)
+	if kept.For != nil && reflect.DeepEqual(durationToMinutes(*kept.For), in.Spec.Minutes) {
+		dst.Spec.For = *kept.For
+	}
+	if kept.ResolveAfter != nil && reflect.DeepEqual(durationToMinutes(*kept.ResolveAfter), in.Spec.ResolveAfter) {
+		dst.Spec.ResolveAfter = *kept.ResolveAfter
+	}
+	if kept.CheckFrequency != nil && reflect.DeepEqual(durationToMinutes(*kept.CheckFrequency), in.Spec.AlertCheckFrequency) {
+		dst.Spec.CheckFrequency = *kept.CheckFrequency
+	}
+	if kept.Targets != nil && targetsToString(*kept.Targets) == in.Spec.Target {
+		dst.Spec.Targets = *kept.Targets
+	}
+
+	// keep the v1alpha1 fields which don't convert back to the same value
+	var lost v1alpha1Fields
+	if !reflect.DeepEqual(durationToMinutes(dst.Spec.For), in.Spec.Minutes) {
+		lost.Minutes = in.Spec.Minutes
+	}
+	if !reflect.DeepEqual(durationToMinutes(dst.Spec.ResolveAfter), in.Spec.ResolveAfter) {
+		lost.ResolveAfter = in.Spec.ResolveAfter
+	}
+	if !reflect.DeepEqual(durationToMinutes(dst.Spec.CheckFrequency), in.Spec.AlertCheckFrequency) {
+		lost.AlertCheckFrequency = in.Spec.AlertCheckFrequency
+	}
+	if targetsToString(dst.Spec.Targets) != in.Spec.Target {
+		lost.Target = &in.Spec.Target
+	}
+	if err := pushFields(&dst.ObjectMeta, V1alpha1FieldsAnnotation, &lost); err != nil {
+		return err
+	}
+
+	instances := alertsStatusToInstances(in.Status.AlertsStatus)
+	dst.Status = WavefrontAlertStatus{
+		State:                       in.Status.State,
+		RetryCount:                  in.Status.RetryCount,
+		ErrorDescription:            in.Status.ErrorDescription,
+		ExportParamsChecksum:        in.Status.ExportParamsChecksum,
+		LastChangeChecksum:          in.Status.LastChangeChecksum,
+		ObservedGeneration:          in.Status.ObservedGeneration,
+		Instances:                   instances,
+		Rollout:                     in.Status.Rollout,
+		LastHandledReconcileRequest: in.Status.LastHandledReconcileRequest,
+		Conditions:                  conditions(in.ObjectMeta, in.Status.State, in.Status.ErrorDescription, in.Status.ObservedGeneration, instances),
+	}
+	return nil
+}
+
+// ConvertTo converts this AlertsConfig to the hub version (v1alpha1)
+func (src *AlertsConfig) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.AlertsConfig)
+	in := src.DeepCopy()
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Spec = v1alpha1.AlertsConfigSpec{
+		GlobalGVK:        in.Spec.GlobalGVK,
+		Alerts:           in.Spec.Alerts,
+		TemplateSelector: in.Spec.TemplateSelector,
+		GlobalParams:     in.Spec.GlobalParams,
+		DeletionPolicy:   in.Spec.DeletionPolicy,
+		Dashboards:       in.Spec.Dashboards,
+		DerivedMetrics:   in.Spec.DerivedMetrics,
+	}
+	dst.Status = v1alpha1.AlertsConfigStatus{
+		State:                       in.Status.State,
+		RetryCount:                  in.Status.RetryCount,
+		AlertsCount:                 in.Status.AlertsCount,
+		ErrorDescription:            in.Status.ErrorDescription,
+		AlertsStatus:                instancesToAlertsStatus(in.Status.Instances),
+		LastHandledReconcileRequest: in.Status.LastHandledReconcileRequest,
+		DashboardsStatus:            in.Status.DashboardsStatus,
+		DerivedMetricsStatus:        in.Status.DerivedMetricsStatus,
+	}
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version
+func (dst *AlertsConfig) ConvertFrom(srcRaw conversion.Hub) error {
+	in := srcRaw.(*v1alpha1.AlertsConfig).DeepCopy()
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Spec = AlertsConfigSpec{
+		GlobalGVK:        in.Spec.GlobalGVK,
+		Alerts:           in.Spec.Alerts,
+		TemplateSelector: in.Spec.TemplateSelector,
+		GlobalParams:     in.Spec.GlobalParams,
+		DeletionPolicy:   in.Spec.DeletionPolicy,
+		Dashboards:       in.Spec.Dashboards,
+		DerivedMetrics:   in.Spec.DerivedMetrics,
+	}
+	instances := alertsStatusToInstances(in.Status.AlertsStatus)
+	dst.Status = AlertsConfigStatus{
+		State:                       in.Status.State,
+		RetryCount:                  in.Status.RetryCount,
+		AlertsCount:                 in.Status.AlertsCount,
+		ErrorDescription:            in.Status.ErrorDescription,
+		Instances:                   instances,
+		LastHandledReconcileRequest: in.Status.LastHandledReconcileRequest,
+		DashboardsStatus:            in.Status.DashboardsStatus,
+		DerivedMetricsStatus:        in.Status.DerivedMetricsStatus,
+		// alerts config doesn't track the observed generation
+		Conditions: conditions(in.ObjectMeta, in.Status.State, in.Status.ErrorDescription, 0, instances),
+	}
+	return nil
+}
+
+// durationToMinutes function converts the duration to minutes. Anything which is not a duration in whole minutes,
+// for ex: a go lang template, is kept as a string and validated after rendering
+func durationToMinutes(d Duration) *intstr.IntOrString {
+	if d == "" {
+		return nil
+	}
+	parsed, err := time.ParseDuration(string(d))
+	if err != nil || parsed%time.Minute != 0 {
+		value := intstr.FromString(string(d))
+		return &value
+	}
+	value := intstr.FromInt32(int32(parsed / time.Minute))
+	return &value
+}
+
+// minutesToDuration function converts the minutes to a duration. String is kept as is
+func minutesToDuration(minutes *intstr.IntOrString) Duration {
+	if minutes == nil {
+		return ""
+	}
+	if minutes.Type == intstr.Int {
+		return Duration(fmt.Sprintf("%dm", minutes.IntVal))
+	}
+	return Duration(minutes.StrVal)
+}
+
+// targetsToString function converts the targets to the wavefront comma separated target list
+func targetsToString(targets []AlertTarget) string {
+	list := make([]string, 0, len(targets))
+	for _, target := range targets {
+		switch {
+		case target.PagerDuty != "":
+			list = append(list, pagerDutyPrefix+target.PagerDuty)
+		case target.Target != "":
+			list = append(list, targetPrefix+target.Target)
+		default:
+			list = append(list, target.Email)
+		}
+	}
+	return strings.Join(list, ",")
+}
+
+// stringToTargets function splits the wavefront comma separated target list. Entries without a known prefix are emails
+func stringToTargets(target string) []AlertTarget {
+	var targets []AlertTarget
+	for _, t := range strings.Split(target, ",") {
+		t = strings.TrimSpace(t)
+		switch {
+		case t == "":
+		case strings.HasPrefix(t, pagerDutyPrefix):
+			targets = append(targets, AlertTarget{PagerDuty: strings.TrimPrefix(t, pagerDutyPrefix)})
+		case strings.HasPrefix(t, targetPrefix):
+			targets = append(targets, AlertTarget{Target: strings.TrimPrefix(t, targetPrefix)})
+		default:
+			targets = append(targets, AlertTarget{Email: t})
+		}
+	}
+	return targets
+}
+
+// alertsStatusToInstances function converts the alerts status map to the instances list sorted by key
+func alertsStatusToInstances(alertsStatus map[string]v1alpha1.AlertStatus) []AlertInstance {
+	if len(alertsStatus) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(alertsStatus))
+	for key := range alertsStatus {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	instances := make([]AlertInstance, 0, len(keys))
+	for _, key := range keys {
+		alertStatus := alertsStatus[key]
+		instance := AlertInstance{
+			Key:                  key,
+			ID:                   alertStatus.ID,
+			AlertName:            alertStatus.Name,
+			Link:                 alertStatus.Link,
+			State:                alertStatus.State,
+			ErrorDescription:     alertStatus.ErrorDescription,
+			LastChangeChecksum:   alertStatus.LastChangeChecksum,
+			AlertsConfig:         alertStatus.AssociatedAlertsConfig.CR,
+			LastUpdatedTimestamp: alertStatus.LastUpdatedTimestamp,
+			PayloadHash:          alertStatus.PayloadHash,
+			DryRun:               alertStatus.DryRun,
+			History:              alertStatus.History,
+			FiringState:          alertStatus.FiringState,
+			FiringStateSince:     alertStatus.FiringStateSince,
+		}
+		if alertStatus.AssociatedAlert != (v1alpha1.AssociatedAlert{}) {
+			instance.Template = &TemplateReference{
+				Kind:       alertStatus.AssociatedAlert.Kind,
+				Name:       alertStatus.AssociatedAlert.CR,
+				Generation: alertStatus.AssociatedAlert.Generation,
+			}
+		}
+		instances = append(instances, instance)
+	}
+	return instances
+}
+
+// instancesToAlertsStatus function converts the instances list to the alerts status map
+func instancesToAlertsStatus(instances []AlertInstance) map[string]v1alpha1.AlertStatus {
+	if len(instances) == 0 {
+		return nil
+	}
+	alertsStatus := make(map[string]v1alpha1.AlertStatus, len(instances))
+	for _, instance := range instances {
+		alertStatus := v1alpha1.AlertStatus{
+			ID:                     instance.ID,
+			Name:                   instance.AlertName,
+			Link:                   instance.Link,
+			State:                  instance.State,
+			ErrorDescription:       instance.ErrorDescription,
+			LastChangeChecksum:     instance.LastChangeChecksum,
+			AssociatedAlertsConfig: v1alpha1.AssociatedAlertsConfig{CR: instance.AlertsConfig},
+			LastUpdatedTimestamp:   instance.LastUpdatedTimestamp,
+			PayloadHash:            instance.PayloadHash,
+			DryRun:                 instance.DryRun,
+			History:                instance.History,
+			FiringState:            instance.FiringState,
+			FiringStateSince:       instance.FiringStateSince,
+		}
+		if instance.Template != nil {
+			alertStatus.AssociatedAlert = v1alpha1.AssociatedAlert{
+				CR:         instance.Template.Name,
+				Generation: instance.Template.Generation,
+				Kind:       instance.Template.Kind,
+			}
+		}
+		alertsStatus[instance.Key] = alertStatus
+	}
+	return alertsStatus
+}
+
+// conditions function computes the conditions from the state of the resource. Last transition time is the last time
+// an alert got updated so the conditions are the same every time the resource is converted
+func conditions(meta metav1.ObjectMeta, state v1alpha1.State, errorDescription string, observedGeneration int64, instances []AlertInstance) []metav1.Condition {
+	if state == "" {
+		return nil
+	}
+	transitionTime := meta.CreationTimestamp
+	for _, instance := range instances {
+		if transitionTime.Before(&instance.LastUpdatedTimestamp) {
+			transitionTime = instance.LastUpdatedTimestamp
+		}
+	}
+
+	ready := metav1.Condition{
+		Type:               ConditionReady,
+		Status:             metav1.ConditionUnknown,
+		ObservedGeneration: observedGeneration,
+		LastTransitionTime: transitionTime,
+		Reason:             string(state),
+		Message:            errorDescription,
+	}
+	switch state {
+	case v1alpha1.Ready, v1alpha1.ReadyToBeUsed:
+		ready.Status = metav1.ConditionTrue
+	case v1alpha1.Error, v1alpha1.MalformedSpec, v1alpha1.ClientExceededLimit:
+		ready.Status = metav1.ConditionFalse
+	}
+
+	paused := metav1.Condition{
+		Type:               ConditionPaused,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: observedGeneration,
+		LastTransitionTime: transitionTime,
+		Reason:             "NotPaused",
+	}
+	if state == v1alpha1.Paused {
+		paused.Status = metav1.ConditionTrue
+		paused.Reason = string(v1alpha1.Paused)
+		paused.Message = "reconcile is paused by " + v1alpha1.PausedAnnotation + " annotation"
+	}
+	return []metav1.Condition{ready, paused}
+}
+
+// popFields function removes the annotation and unmarshals it into fields.
+// Annotation which can't be unmarshalled, for ex: edited by hand, is dropped rather than failing the conversion
+func popFields(meta *metav1.ObjectMeta, annotation string, fields interface{}) {
+	value, ok := meta.Annotations[annotation]
+	if !ok {
+		return
+	}
+	removeAnnotation(meta, annotation)
+	_ = json.Unmarshal([]byte(value), fields)
+}
+
+// pushFields function marshals the fields into the annotation. Annotation is removed if none of the fields is set
+func pushFields(meta *metav1.ObjectMeta, annotation string, fields interface{}) error {
+	if reflect.ValueOf(fields).Elem().IsZero() {
+		removeAnnotation(meta, annotation)
+		return nil
+	}
+	value, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("unable to marshal %s annotation: %w", annotation, err)
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[annotation] = string(value)
+	return nil
+}
+
+func removeAnnotation(meta *metav1.ObjectMeta, annotation string) {
+	delete(meta.Annotations, annotation)
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/keikoproj/alert-manager/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+)
+
+func TestIsConvertible(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, v1alpha1.AddToScheme(scheme))
+	assert.NoError(t, v1beta1.AddToScheme(scheme))
+	for _, obj := range []runtime.Object{&v1alpha1.WavefrontAlert{}, &v1alpha1.AlertsConfig{}} {
+		ok, err := conversion.IsConvertible(scheme, obj)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	}
+}
+
+func alphaWavefrontAlert() *v1alpha1.WavefrontAlert {
+	minutes := intstr.FromInt32(5)
+	resolveAfter := intstr.FromString("{{ .resolveAfter }}")
+	updated := metav1.NewTime(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+	return &v1alpha1.WavefrontAlert{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "cpu", Annotations: map[string]string{"team": "a"}},
+		Spec: v1alpha1.WavefrontAlertSpec{
+			AlertName:         "cpu",
+			Condition:         "ts(cpu) > 80",
+			DisplayExpression: "ts(cpu)",
+			Severity:          "warn",
+			Minutes:           &minutes,
+			ResolveAfter:      &resolveAfter,
+			Target:            "oncall@example.com, pd:key,target:abc",
+			ExportedParams:    []string{"resolveAfter"},
+		},
+		Status: v1alpha1.WavefrontAlertStatus{
+			State:              v1alpha1.ReadyToBeUsed,
+			ObservedGeneration: 2,
+			AlertsStatus: map[string]v1alpha1.AlertStatus{
+				"checkout": {
+					ID:                     "100",
+					Name:                   "cpu-checkout",
+					State:                  v1alpha1.Ready,
+					AssociatedAlertsConfig: v1alpha1.AssociatedAlertsConfig{CR: "checkout"},
+					LastUpdatedTimestamp:   updated,
+					FiringState:            v1alpha1.Firing,
+					FiringStateSince:       &updated,
+				},
+				"billing": {ID: "101", Name: "cpu-billing", State: v1alpha1.Error, ErrorDescription: "wavefront returned 400"},
+			},
+		},
+	}
+}
+
+func TestWavefrontAlert_ConvertFrom(t *testing.T) {
+	var beta v1beta1.WavefrontAlert
+	assert.NoError(t, beta.ConvertFrom(alphaWavefrontAlert()))
+
+	assert.Equal(t, v1beta1.Duration("5m"), beta.Spec.For)
+	assert.Equal(t, v1beta1.Duration("{{ .resolveAfter }}"), beta.Spec.ResolveAfter)
+	assert.Equal(t, []v1beta1.AlertTarget{{Email: "oncall@example.com"}, {PagerDuty: "key"}, {Target: "abc"}}, beta.Spec.Targets)
+	assert.Contains(t, beta.Annotations[v1beta1.V1alpha1FieldsAnnotation], "target", "target with spaces doesn't convert back to the same value")
+
+	assert.Len(t, beta.Status.Instances, 2)
+	assert.Equal(t, "billing", beta.Status.Instances[0].Key, "instances are sorted by key")
+	assert.Equal(t, "checkout", beta.Status.Instances[1].AlertsConfig)
+
+	assert.Len(t, beta.Status.Conditions, 2)
+	assert.Equal(t, v1beta1.ConditionReady, beta.Status.Conditions[0].Type)
+	assert.Equal(t, metav1.ConditionTrue, beta.Status.Conditions[0].Status)
+	assert.Equal(t, int64(2), beta.Status.Conditions[0].ObservedGeneration)
+	assert.Equal(t, 2025, beta.Status.Conditions[0].LastTransitionTime.Year())
+	assert.Equal(t, metav1.ConditionFalse, beta.Status.Conditions[1].Status)
+}
+
+func TestWavefrontAlert_RoundTrip(t *testing.T) {
+	t.Run("v1alpha1 to v1beta1 and back", func(t *testing.T) {
+		alpha := alphaWavefrontAlert()
+		var beta v1beta1.WavefrontAlert
+		assert.NoError(t, beta.ConvertFrom(alpha))
+		var got v1alpha1.WavefrontAlert
+		assert.NoError(t, beta.ConvertTo(&got))
+		assert.Equal(t, alpha, &got)
+	})
+
+	t.Run("v1beta1 to v1alpha1 and back", func(t *testing.T) {
+		beta := &v1beta1.WavefrontAlert{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "cpu"},
+			Spec: v1beta1.WavefrontAlertSpec{
+				AlertName:         "cpu",
+				Condition:         "ts(cpu) > 80",
+				DisplayExpression: "ts(cpu)",
+				Severity:          "warn",
+				For:               "1h",
+				ResolveAfter:      "10m",
+				CheckFrequency:    "{{ .frequency }}",
+				Targets:           []v1beta1.AlertTarget{{PagerDuty: "key"}},
+			},
+			Status: v1beta1.WavefrontAlertStatus{
+				Instances: []v1beta1.AlertInstance{
+					{Key: "checkout", ID: "100", Template: &v1beta1.TemplateReference{Kind: v1alpha1.WavefrontAlertKind, Name: "cpu", Generation: 3}},
+				},
+			},
+		}
+		var alpha v1alpha1.WavefrontAlert
+		assert.NoError(t, beta.ConvertTo(&alpha))
+		assert.Equal(t, 60, alpha.Spec.Minutes.IntValue())
+		assert.Equal(t, 10, alpha.Spec.ResolveAfter.IntValue())
+		assert.Equal(t, "{{ .frequency }}", alpha.Spec.AlertCheckFrequency.StrVal)
+		assert.Equal(t, "pd:key", alpha.Spec.Target)
+		assert.Equal(t, "cpu", alpha.Status.AlertsStatus["checkout"].AssociatedAlert.CR)
+		assert.Equal(t, `{"for":"1h"}`, alpha.Annotations[v1beta1.V1beta1FieldsAnnotation])
+
+		var got v1beta1.WavefrontAlert
+		assert.NoError(t, got.ConvertFrom(&alpha))
+		assert.Equal(t, beta, &got)
+	})
+
+	t.Run("changed field is not restored", func(t *testing.T) {
+		beta := &v1beta1.WavefrontAlert{Spec: v1beta1.WavefrontAlertSpec{For: "1h", ResolveAfter: "5m"}}
+		var alpha v1alpha1.WavefrontAlert
+		assert.NoError(t, beta.ConvertTo(&alpha))
+		minutes := intstr.FromInt32(30)
+		alpha.Spec.Minutes = &minutes
+
+		var got v1beta1.WavefrontAlert
+		assert.NoError(t, got.ConvertFrom(&alpha))
+		assert.Equal(t, v1beta1.Duration("30m"), got.Spec.For)
+		assert.Empty(t, got.Annotations)
+	})
+}
+
+func TestAlertsConfig_RoundTrip(t *testing.T) {
+	alpha := &v1alpha1.AlertsConfig{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "checkout"},
+		Spec: v1alpha1.AlertsConfigSpec{
+			GlobalGVK:    v1alpha1.GVK{Kind: v1alpha1.WavefrontAlertKind},
+			GlobalParams: v1alpha1.OrderedMap{"app": "checkout"},
+			Alerts: map[string]v1alpha1.Config{
+				"cpu": {Params: v1alpha1.OrderedMap{"threshold": "80"}},
+			},
+		},
+		Status: v1alpha1.AlertsConfigStatus{
+			State:            v1alpha1.Error,
+			RetryCount:       1,
+			AlertsCount:      1,
+			ErrorDescription: "unable to update the alert",
+			AlertsStatus: map[string]v1alpha1.AlertStatus{
+				"cpu": {ID: "200", Name: "cpu-checkout", AssociatedAlert: v1alpha1.AssociatedAlert{CR: "cpu", Generation: 2}},
+			},
+		},
+	}
+	var beta v1beta1.AlertsConfig
+	assert.NoError(t, beta.ConvertFrom(alpha))
+	assert.Equal(t, "cpu", beta.Status.Instances[0].Key)
+	assert.Equal(t, "cpu", beta.Status.Instances[0].Template.Name)
+	assert.Equal(t, metav1.ConditionFalse, beta.Status.Conditions[0].Status)
+	assert.Equal(t, "unable to update the alert", beta.Status.Conditions[0].Message)
+
+	var got v1alpha1.AlertsConfig
+	assert.NoError(t, beta.ConvertTo(&got))
+	assert.Equal(t, alpha, &got)
+}
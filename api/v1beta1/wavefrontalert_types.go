@@ -0,0 +1,252 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/keikoproj/alert-manager/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// WavefrontAlertKind is the kind of WavefrontAlert resource
+	WavefrontAlertKind = "WavefrontAlert"
+)
+
+// Duration is a go lang duration in whole minutes for ex: 5m, 1h30m. String can be a go lang template as well
+type Duration string
+
+// AlertTarget is a target to notify when the alert status changes. Only one of the fields must be provided
+// +kubebuilder:validation:MinProperties=1
+// +kubebuilder:validation:MaxProperties=1
+type AlertTarget struct {
+	//Email address to notify
+	// +optional
+	Email string `json:"email,omitempty"`
+	//PagerDuty integration key to notify
+	// +optional
+	PagerDuty string `json:"pagerDuty,omitempty"`
+	//Target is the ID of an alert target configured in wavefront, for ex: a web hook
+	// +optional
+	Target string `json:"target,omitempty"`
+}
+
+// WavefrontAlertSpec defines the desired state of WavefrontAlert
+type WavefrontAlertSpec struct {
+	// AlertType represents the type of the Alert in Wavefront. Defaults to CLASSIC alert
+	// +optional
+	AlertType v1alpha1.AlertType `json:"alertType,omitempty"`
+
+	//Name of the alert to be created in Wavefront
+	// +required
+	AlertName string `json:"alertName"`
+
+	//A conditional expression that defines the threshold for the Classic alert. For CLASSIC (or default alerts) condition must be provided
+	// +required
+	Condition string `json:"condition"`
+
+	//For classic alert type, mention the severity of the incident. This will be ignored for threshold type of alerts
+	// +required
+	Severity string `json:"severity"`
+
+	//For is the time the alert condition must be "true" continuously to trigger an alert, for ex: 5m
+	// +required
+	For Duration `json:"for"`
+
+	//ResolveAfter is the time the alert condition must be back to "false" to resolve the incident, for ex: 10m
+	// +required
+	ResolveAfter Duration `json:"resolveAfter"`
+
+	//Targets (Optional) to notify when the alert status changes
+	// +optional
+	Targets []AlertTarget `json:"targets,omitempty"`
+
+	//Any additional information, such as a link to a run book.
+	// +optional
+	AdditionalInformation string `json:"additionalInformation,omitempty"`
+
+	//Tags assigned to the alert. A templated tag rendering to a comma separated list is split into multiple tags so a list param can be used
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	//Describe the functionality of the alert in simple words. This is just for CR and not used it to send it to wavefront
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	//Specify a display expression to get more details when the alert changes state
+	// +required
+	DisplayExpression string `json:"displayExpression"`
+
+	//exportedParams can be used when AlertsConfig CRD used to provide config to WavefrontAlert CRD at the runtime for multiple alerts
+	//when the exportedParams length is not empty, Alert will not be created when Alert CR is created but rather alerts will be created when AlertsConfig CR created.
+	// +optional
+	ExportedParams []string `json:"exportedParams,omitempty"`
+	//exportedParamsDefaultValues can be used to provide the default values and will be used if alerts config doesn't provide any values
+	// +optional
+	ExportedParamsDefaultValues v1alpha1.OrderedMap `json:"exportedParamsDefaultValues,omitempty"`
+	//exportedParamsSchema (Optional) can be used to declare the type and the validation rules of the exportedParams.
+	//Values provided by alerts config are validated against the schema before rendering the template
+	// +optional
+	ExportedParamsSchema map[string]v1alpha1.ParamSchema `json:"exportedParamsSchema,omitempty"`
+
+	//CheckFrequency (Optional) is the interval the alert condition is checked at. Defaults to 1m
+	// +optional
+	CheckFrequency Duration `json:"checkFrequency,omitempty"`
+
+	//RolloutStrategy (Optional) controls how a template change is rolled out to the alerts configs using this template.
+	//All the alerts configs are updated in a single batch if not specified
+	// +optional
+	RolloutStrategy *v1alpha1.RolloutStrategy `json:"rolloutStrategy,omitempty"`
+
+	//DeletionPolicy (Optional) decides what happens to the wavefront alerts when this resource is deleted. Defaults to Delete
+	// +optional
+	DeletionPolicy v1alpha1.DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+const (
+	// ConditionReady is True when all the alerts of the resource are applied in wavefront
+	ConditionReady = "Ready"
+	// ConditionPaused is True when the resource has the paused annotation
+	ConditionPaused = "Paused"
+)
+
+// TemplateReference refers the template an alert is rendered from
+type TemplateReference struct {
+	//Kind of the template. Empty means WavefrontAlert in the same namespace
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	//Name of the template
+	// +optional
+	Name string `json:"name,omitempty"`
+	//Generation of the template the alert is rendered from
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+}
+
+// AlertInstance is the status of an alert managed by the resource
+type AlertInstance struct {
+	//Key of the alert in the resource. Name of the alert for a WavefrontAlert without exportedParams,
+	//name of the AlertsConfig for a template and name of the template for an AlertsConfig
+	Key string `json:"key"`
+	//ID of the alert in wavefront
+	// +optional
+	ID string `json:"id,omitempty"`
+	//AlertName is the rendered name of the alert
+	// +optional
+	AlertName string `json:"alertName,omitempty"`
+	//Link to the alert in wavefront
+	// +optional
+	Link string `json:"link,omitempty"`
+	//State of the alert
+	// +optional
+	State v1alpha1.State `json:"state,omitempty"`
+	//ErrorDescription in case of error
+	// +optional
+	ErrorDescription string `json:"errorDescription,omitempty"`
+	//LastChangeChecksum is the checksum of the spec the alert is rendered from
+	// +optional
+	LastChangeChecksum string `json:"lastChangeChecksum,omitempty"`
+	//Template the alert is rendered from. Only for the alerts rendered by an AlertsConfig
+	// +optional
+	Template *TemplateReference `json:"template,omitempty"`
+	//AlertsConfig is the name of the AlertsConfig which rendered the alert from this template
+	// +optional
+	AlertsConfig string `json:"alertsConfig,omitempty"`
+	//LastUpdatedTimestamp represents the last time the alert has been modified
+	// +optional
+	LastUpdatedTimestamp metav1.Time `json:"lastUpdatedTimestamp,omitempty"`
+	//PayloadHash is the checksum of the alert payload last applied in wavefront
+	// +optional
+	PayloadHash string `json:"payloadHash,omitempty"`
+	//DryRun is the preview of the pending change in dry-run mode
+	// +optional
+	DryRun *v1alpha1.DryRunStatus `json:"dryRun,omitempty"`
+	//History has the latest changes of the alert, oldest first
+	// +optional
+	History []v1alpha1.AlertChange `json:"history,omitempty"`
+	//FiringState is the live state of the alert in wavefront
+	// +optional
+	FiringState v1alpha1.FiringState `json:"firingState,omitempty"`
+	//FiringStateSince is the time the alert was first seen in the firing state
+	// +optional
+	FiringStateSince *metav1.Time `json:"firingStateSince,omitempty"`
+}
+
+// WavefrontAlertStatus defines the observed state of WavefrontAlert
+type WavefrontAlertStatus struct {
+	//State of the resource
+	// +optional
+	State v1alpha1.State `json:"state,omitempty"`
+	//RetryCount in case of error
+	// +optional
+	RetryCount int `json:"retryCount,omitempty"`
+	//ErrorDescription in case of error
+	// +optional
+	ErrorDescription string `json:"errorDescription,omitempty"`
+	//Checksum of the exportedParams if exists
+	// +optional
+	ExportParamsChecksum string `json:"exportParamsChecksum,omitempty"`
+	//This represents the checksum of the spec
+	// +optional
+	LastChangeChecksum string `json:"lastChangeChecksum,omitempty"`
+	//ObservedGeneration will have the last generation from spec metadata
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	//Instances are the alerts managed by the resource
+	// +listType=map
+	// +listMapKey=key
+	// +optional
+	Instances []AlertInstance `json:"instances,omitempty"`
+	//Rollout has the progress of the template change rollout to the alerts configs
+	// +optional
+	Rollout *v1alpha1.RolloutStatus `json:"rollout,omitempty"`
+	//LastHandledReconcileRequest is the last reconcile request annotation value handled by the controller
+	// +optional
+	LastHandledReconcileRequest string `json:"lastHandledReconcileRequest,omitempty"`
+	//Conditions are computed from the state of the resource. They are read only and not stored
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=wavefrontalerts,scope=Namespaced,shortName=wfalerts,singular=wavefrontalert
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="current state of the wavefront alert"
+// +kubebuilder:printcolumn:name="RetryCount",type="integer",JSONPath=".status.retryCount",description="Retry count"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="time passed since wavefront alert creation"
+// WavefrontAlert is the Schema for the wavefrontalerts API
+type WavefrontAlert struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WavefrontAlertSpec   `json:"spec,omitempty"`
+	Status WavefrontAlertStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// WavefrontAlertList contains a list of WavefrontAlert
+type WavefrontAlertList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WavefrontAlert `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WavefrontAlert{}, &WavefrontAlertList{})
+}
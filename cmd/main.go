@@ -20,12 +20,18 @@ import (
 	"context"
 	"flag"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/keikoproj/alert-manager/internal/config"
 	"github.com/keikoproj/alert-manager/internal/controllers/common"
+	"github.com/keikoproj/alert-manager/pkg/audit"
 	"github.com/keikoproj/alert-manager/pkg/k8s"
 	"github.com/keikoproj/alert-manager/pkg/log"
+	"github.com/keikoproj/alert-manager/pkg/tracing"
 	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	webhookclient "github.com/keikoproj/alert-manager/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -34,17 +40,23 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	coordinationv1 "k8s.io/api/coordination/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	wf "github.com/WavefrontHQ/go-wavefront-management-api"
 	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
-	configcommon "github.com/keikoproj/alert-manager/internal/config/common"
+	alertmanagerv1beta1 "github.com/keikoproj/alert-manager/api/v1beta1"
 	"github.com/keikoproj/alert-manager/internal/controllers"
+	webhookv1alpha1 "github.com/keikoproj/alert-manager/internal/webhook/v1alpha1"
 )
 
 var (
@@ -56,18 +68,83 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(alertmanagerv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(alertmanagerv1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
 func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
+	var dryRun bool
+	var orphanCollectorInterval time.Duration
+	var orphanCollectorDryRun bool
+	var orphanCollectorMaxDeletes int
+	var firingStatePollInterval time.Duration
+	var alertHistoryLimit int
+	var alertHistoryExportLimit int
+	var auditSinks string
+	var auditBufferSize int
+	var tracingEndpoint string
+	var tracingInsecure bool
+	var tracingSampleRatio float64
+	var watchNamespaces string
+	var watchNamespaceSelector string
+	var shardCount int
+	var shardNamespaceLabel string
+	var shardLeaseDuration time.Duration
+	var shardRenewInterval time.Duration
+	var enableWebhooks bool
 	var probeAddr string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8082", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"Preview the alert changes in status and events without calling wavefront create, update or delete APIs. "+
+			"Same as adding "+alertmanagerv1alpha1.DryRunAnnotation+"=true annotation to every resource.")
+	flag.DurationVar(&orphanCollectorInterval, "orphan-collector-interval", 0,
+		"Interval to look for the wavefront alerts created from this cluster whose resource doesn't exist anymore. "+
+			"Requires cluster.id in the config map. Disabled if 0.")
+	flag.BoolVar(&orphanCollectorDryRun, "orphan-collector-dry-run", false,
+		"Only report the orphan wavefront alerts without deleting them.")
+	flag.IntVar(&orphanCollectorMaxDeletes, "orphan-collector-max-deletes", 10,
+		"Max number of orphan wavefront alerts deleted in a run. Nothing is deleted if more orphan alerts are found.")
+	flag.DurationVar(&firingStatePollInterval, "firing-state-poll-interval", 0,
+		"Interval to record the live state of the wavefront alerts, for ex: FIRING, in the alert status and metrics. "+
+			"Requires cluster.id in the config map, the controller refuses to start without it. Disabled if 0.")
+	flag.IntVar(&alertHistoryLimit, "alert-history-limit", 3,
+		"Number of changes kept in the history of every alert in the status. History is not kept if 0.")
+	flag.IntVar(&alertHistoryExportLimit, "alert-history-export-limit", 0,
+		"Number of changes per alert kept in the alert history config maps. History is not exported if 0.")
+	flag.StringVar(&auditSinks, "audit-sinks", "",
+		"Comma separated list of sinks receiving the audit records of the wavefront and webhook changes: stdout, file:<path> or webhook:<url>. "+
+			"Webhook bearer token is read from "+audit.WebhookTokenEnv+" environment variable and the key chaining the records from "+
+			audit.HMACKeyEnv+" environment variable. Disabled if empty.")
+	flag.IntVar(&auditBufferSize, "audit-buffer-size", 1000,
+		"Number of audit records buffered for delivery. New records are dropped when the buffer is full.")
+	flag.StringVar(&tracingEndpoint, "tracing-otlp-endpoint", "",
+		"OTLP gRPC endpoint receiving the traces, for ex: otel-collector:4317. "+
+			"OTEL_EXPORTER_OTLP_* environment variables are used if empty. Tracing is disabled if no endpoint is configured.")
+	flag.BoolVar(&tracingInsecure, "tracing-otlp-insecure", false,
+		"Disable TLS to the OTLP endpoint.")
+	flag.Float64Var(&tracingSampleRatio, "tracing-sample-ratio", 1,
+		"Ratio of the reconciles traced, between 0 and 1.")
+	flag.StringVar(&watchNamespaces, "watch-namespaces", "",
+		"Comma separated list of namespaces to watch. All namespaces are watched if empty.")
+	flag.StringVar(&watchNamespaceSelector, "watch-namespace-selector", "",
+		"Label selector of the namespaces to watch. For ex: tenant=team-a. All namespaces are watched if empty.")
+	flag.IntVar(&shardCount, "shard-count", 0,
+		"Number of shards to split the namespaces into. Every replica reconciles only the namespaces of the shards it owns. Requires --leader-elect. Disabled if 0.")
+	flag.StringVar(&shardNamespaceLabel, "shard-namespace-label", "",
+		"Namespace label with the shard number of the namespace. Namespaces are hashed to shards if empty or the label is missing.")
+	flag.DurationVar(&shardLeaseDuration, "shard-lease-duration", 15*time.Second,
+		"Duration a shard stays owned by a replica without renewal.")
+	flag.DurationVar(&shardRenewInterval, "shard-renew-interval", 5*time.Second,
+		"Interval between two shard lease renewals. Must be less than the shard lease duration.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", true,
+		"Serve the conversion webhook converting WavefrontAlert and AlertsConfig between v1alpha1 and v1beta1 and "+
+			"the validating webhooks linting the wavefront queries and validating the alerts config params. Requires the serving certificate. Disable it when running outside of the cluster.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -78,22 +155,81 @@ func main() {
 	ctx := context.Background()
 	log := log.Logger(ctx, "main", "setup")
 
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		Endpoint:    tracingEndpoint,
+		Insecure:    tracingInsecure,
+		SampleRatio: tracingSampleRatio,
+		ServiceName: "alert-manager",
+	})
+	if err != nil {
+		log.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
+	controllerNamespace := config.Namespace()
+	var namespaces *controllers.NamespaceFilter
+	cacheOpts := cache.Options{
+		// leases are only used in the controller namespace
+		ByObject: map[client.Object]cache.ByObject{
+			&coordinationv1.Lease{}: {Namespaces: map[string]cache.Config{controllerNamespace: {}}},
+			// only the alert history config maps are read
+			&v1.ConfigMap{}: {Label: labels.SelectorFromSet(labels.Set{common.AlertHistoryLabel: "true"})},
+		},
+	}
+	if watchNamespaces != "" || watchNamespaceSelector != "" {
+		namespaces = &controllers.NamespaceFilter{}
+		for _, ns := range strings.Split(watchNamespaces, ",") {
+			if ns = strings.TrimSpace(ns); ns != "" {
+				namespaces.Namespaces = append(namespaces.Namespaces, ns)
+			}
+		}
+		if len(namespaces.Namespaces) > 0 {
+			cacheOpts.DefaultNamespaces = make(map[string]cache.Config, len(namespaces.Namespaces))
+			historyNamespaces := map[string]cache.Config{controllerNamespace: {}}
+			for _, ns := range namespaces.Namespaces {
+				cacheOpts.DefaultNamespaces[ns] = cache.Config{}
+				historyNamespaces[ns] = cache.Config{}
+			}
+			// history of the cluster scoped resources is kept in the controller namespace
+			for obj, byObject := range cacheOpts.ByObject {
+				if _, ok := obj.(*v1.ConfigMap); ok {
+					byObject.Namespaces = historyNamespaces
+					cacheOpts.ByObject[obj] = byObject
+				}
+			}
+		}
+		if watchNamespaceSelector != "" {
+			selector, err := labels.Parse(watchNamespaceSelector)
+			if err != nil {
+				log.Error(err, "invalid watch namespace selector")
+				os.Exit(1)
+			}
+			namespaces.Selector = selector
+		}
+		log.Info("watching selected namespaces", "namespaces", namespaces.Namespaces, "selector", watchNamespaceSelector)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
+		Cache:  cacheOpts,
 		Metrics: metricsserver.Options{
 			BindAddress:    metricsAddr,
 			SecureServing:  true,
 			FilterProvider: filters.WithAuthenticationAndAuthorization,
 		},
-		WebhookServer:          webhook.NewServer(webhook.Options{Port: 9443}),
-		HealthProbeBindAddress: probeAddr,
-		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "0cecb213.keikoproj.io",
+		WebhookServer:           webhook.NewServer(webhook.Options{Port: 9443}),
+		HealthProbeBindAddress:  probeAddr,
+		LeaderElection:          enableLeaderElection,
+		LeaderElectionID:        "0cecb213.keikoproj.io",
+		LeaderElectionNamespace: controllerNamespace,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
+	if namespaces != nil {
+		namespaces.Client = mgr.GetClient()
+	}
 
 	k8sSelfClient := k8s.NewK8sSelfClientDoOrDie()
 	recorder := k8sSelfClient.SetUpEventHandler(ctx)
@@ -101,7 +237,7 @@ func main() {
 	// Get the config map
 	// retrieve k8s secret
 	// Call for wavefront new client
-	wfTokenSecret, err := k8sSelfClient.GetK8sSecret(ctx, config.Props.WavefrontAPITokenSecretName(), configcommon.AlertManagerNamespaceName)
+	wfTokenSecret, err := k8sSelfClient.GetK8sSecret(ctx, config.Props.WavefrontAPITokenSecretName(), controllerNamespace)
 	if err != nil {
 		log.Error(err, "unable to get wavefront api token secret")
 		os.Exit(1)
@@ -112,6 +248,13 @@ func main() {
 		os.Exit(1)
 	}
 	wavefront.ApiToken = string(wfToken)
+	// Cluster identity is read only once. Changing it renames the alerts so it needs a restart
+	clusterIdentity, err := wavefront.NewClusterIdentity(config.Props.ClusterID(), config.Props.ClusterAlertNamePolicy(), config.Props.ClusterAlertNameSeparator())
+	if err != nil {
+		log.Error(err, "invalid cluster identity config")
+		os.Exit(1)
+	}
+	wavefront.Cluster = clusterIdentity
 	wfClient, wfErr := wavefront.NewClient(ctx, &wf.Config{
 		Address: config.Props.WavefrontAPIUrl(),
 		Token:   string(wfToken),
@@ -120,16 +263,79 @@ func main() {
 		log.Error(wfErr, "unable to create wavefront client")
 		os.Exit(1)
 	}
+	var wavefrontClient wavefront.Interface = wfClient
+	var webhookClient webhookclient.Interface = webhookclient.NewClient(ctx)
+	sinks, err := audit.ParseSinks(auditSinks)
+	if err != nil {
+		log.Error(err, "invalid audit sinks")
+		os.Exit(1)
+	}
+	if len(sinks) > 0 {
+		auditKey := os.Getenv(audit.HMACKeyEnv)
+		if auditKey == "" {
+			log.Error(nil, "audit sinks require the hmac key in "+audit.HMACKeyEnv+" environment variable")
+			os.Exit(1)
+		}
+		hostname, err := os.Hostname()
+		if err != nil {
+			log.Error(err, "unable to get the hostname")
+			os.Exit(1)
+		}
+		auditor := audit.NewAuditor(config.Props.ClusterID(), hostname, []byte(auditKey), auditBufferSize, sinks...)
+		if err := mgr.Add(auditor); err != nil {
+			log.Error(err, "unable to add auditor")
+			os.Exit(1)
+		}
+		wavefrontClient = &audit.WavefrontClient{Interface: wfClient, Auditor: auditor}
+		webhookClient = &audit.WebhookClient{Interface: webhookClient, Auditor: auditor}
+	}
+
+	var shards *controllers.ShardManager
+	if shardCount > 0 {
+		if !enableLeaderElection {
+			// workload controllers, orphan collector and firing state poller would run on every replica otherwise
+			log.Error(nil, "sharding requires leader election. set --leader-elect along with --shard-count")
+			os.Exit(1)
+		}
+		if shardRenewInterval >= shardLeaseDuration {
+			log.Error(nil, "shard renew interval must be less than the shard lease duration")
+			os.Exit(1)
+		}
+		hostname, err := os.Hostname()
+		if err != nil {
+			log.Error(err, "unable to get the hostname")
+			os.Exit(1)
+		}
+		shards = &controllers.ShardManager{
+			Client:         mgr.GetClient(),
+			Namespace:      controllerNamespace,
+			LeasePrefix:    "alert-manager",
+			Identity:       hostname + "-" + uuid.New().String()[:8],
+			ShardCount:     shardCount,
+			NamespaceLabel: shardNamespaceLabel,
+			LeaseDuration:  shardLeaseDuration,
+			RenewInterval:  shardRenewInterval,
+		}
+		if err := mgr.Add(shards); err != nil {
+			log.Error(err, "unable to add shard manager")
+			os.Exit(1)
+		}
+	}
 
 	if err = (&controllers.WavefrontAlertReconciler{
 		Client:          mgr.GetClient(),
 		Log:             log.WithValues("controllers", "WavefrontAlert"),
 		Scheme:          mgr.GetScheme(),
 		Recorder:        recorder,
-		WavefrontClient: wfClient,
+		WavefrontClient: wavefrontClient,
+		DryRun:          dryRun,
+		Shards:          shards,
+		Namespaces:      namespaces,
 		CommonClient: &common.Client{
-			Client:   mgr.GetClient(),
-			Recorder: recorder,
+			Client:             mgr.GetClient(),
+			Recorder:           recorder,
+			HistoryLimit:       alertHistoryLimit,
+			HistoryExportLimit: alertHistoryExportLimit,
 		},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "WavefrontAlert")
@@ -141,15 +347,153 @@ func main() {
 		Log:             log.WithValues("controllers", "AlertsConfig"),
 		Scheme:          mgr.GetScheme(),
 		Recorder:        recorder,
-		WavefrontClient: wfClient,
+		WavefrontClient: wavefrontClient,
+		DryRun:          dryRun,
+		Shards:          shards,
+		Namespaces:      namespaces,
+		CommonClient: &common.Client{
+			Client:             mgr.GetClient(),
+			Recorder:           recorder,
+			HistoryLimit:       alertHistoryLimit,
+			HistoryExportLimit: alertHistoryExportLimit,
+		},
+	}).SetupWithManager(mgr); err != nil {
+		log.Error(err, "unable to create controller", "controller", "AlertsConfig")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.ClusterWavefrontAlertReconciler{
+		Client:          mgr.GetClient(),
+		Log:             log.WithValues("controllers", "ClusterWavefrontAlert"),
+		Scheme:          mgr.GetScheme(),
+		Recorder:        recorder,
+		WavefrontClient: wavefrontClient,
+		DryRun:          dryRun,
+		Shards:          shards,
+		Namespaces:      namespaces,
+		CommonClient: &common.Client{
+			Client:             mgr.GetClient(),
+			Recorder:           recorder,
+			HistoryLimit:       alertHistoryLimit,
+			HistoryExportLimit: alertHistoryExportLimit,
+		},
+	}).SetupWithManager(mgr); err != nil {
+		log.Error(err, "unable to create controller", "controller", "ClusterWavefrontAlert")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.WavefrontDashboardReconciler{
+		Client:          mgr.GetClient(),
+		Log:             log.WithValues("controllers", "WavefrontDashboard"),
+		Scheme:          mgr.GetScheme(),
+		Recorder:        recorder,
+		WavefrontClient: wavefrontClient,
+		DryRun:          dryRun,
+		Shards:          shards,
+		Namespaces:      namespaces,
 		CommonClient: &common.Client{
 			Client:   mgr.GetClient(),
 			Recorder: recorder,
 		},
 	}).SetupWithManager(mgr); err != nil {
-		log.Error(err, "unable to create controller", "controller", "AlertsConfig")
+		log.Error(err, "unable to create controller", "controller", "WavefrontDashboard")
 		os.Exit(1)
 	}
+
+	if err = (&controllers.WavefrontDerivedMetricReconciler{
+		Client:          mgr.GetClient(),
+		Log:             log.WithValues("controllers", "WavefrontDerivedMetric"),
+		Scheme:          mgr.GetScheme(),
+		Recorder:        recorder,
+		WavefrontClient: wavefrontClient,
+		DryRun:          dryRun,
+		Shards:          shards,
+		Namespaces:      namespaces,
+		CommonClient: &common.Client{
+			Client:   mgr.GetClient(),
+			Recorder: recorder,
+		},
+	}).SetupWithManager(mgr); err != nil {
+		log.Error(err, "unable to create controller", "controller", "WavefrontDerivedMetric")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.WebhookAlertReconciler{
+		Client:        mgr.GetClient(),
+		Log:           log.WithValues("controllers", "WebhookAlert"),
+		Scheme:        mgr.GetScheme(),
+		Recorder:      recorder,
+		K8sClient:     k8sSelfClient,
+		WebhookClient: webhookClient,
+		Shards:        shards,
+		Namespaces:    namespaces,
+		CommonClient: &common.Client{
+			Client:   mgr.GetClient(),
+			Recorder: recorder,
+		},
+	}).SetupWithManager(mgr); err != nil {
+		log.Error(err, "unable to create controller", "controller", "WebhookAlert")
+		os.Exit(1)
+	}
+
+	for _, kind := range []string{controllers.DeploymentKind, controllers.StatefulSetKind, controllers.NamespaceKind} {
+		if err = (&controllers.WorkloadReconciler{
+			Client:     mgr.GetClient(),
+			Log:        log.WithValues("controllers", kind),
+			Scheme:     mgr.GetScheme(),
+			Recorder:   recorder,
+			Kind:       kind,
+			Namespaces: namespaces,
+		}).SetupWithManager(mgr); err != nil {
+			log.Error(err, "unable to create controller", "controller", kind)
+			os.Exit(1)
+		}
+	}
+	if orphanCollectorInterval > 0 {
+		if err := mgr.Add(&controllers.OrphanCollector{
+			Client:          mgr.GetClient(),
+			WavefrontClient: wavefrontClient,
+			ClusterID:       config.Props.ClusterID(),
+			Interval:        orphanCollectorInterval,
+			DryRun:          orphanCollectorDryRun || dryRun,
+			MaxDeletes:      orphanCollectorMaxDeletes,
+			Namespaces:      namespaces,
+		}); err != nil {
+			log.Error(err, "unable to add orphan alert collector")
+			os.Exit(1)
+		}
+	}
+	if firingStatePollInterval > 0 {
+		if config.Props.ClusterID() == "" {
+			// managed alerts are found by the cluster tag
+			log.Error(nil, "firing state poller requires cluster.id in the config map. set cluster.id or --firing-state-poll-interval=0")
+			os.Exit(1)
+		}
+		if err := mgr.Add(&controllers.FiringStatePoller{
+			Client:          mgr.GetClient(),
+			WavefrontClient: wavefrontClient,
+			ClusterID:       config.Props.ClusterID(),
+			Interval:        firingStatePollInterval,
+			Namespaces:      namespaces,
+		}); err != nil {
+			log.Error(err, "unable to add firing state poller")
+			os.Exit(1)
+		}
+	}
+	if enableWebhooks {
+		if err = webhookv1alpha1.SetupWavefrontAlertWebhookWithManager(mgr); err != nil {
+			log.Error(err, "unable to create webhook", "webhook", "WavefrontAlert")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupClusterWavefrontAlertWebhookWithManager(mgr); err != nil {
+			log.Error(err, "unable to create webhook", "webhook", "ClusterWavefrontAlert")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupAlertsConfigWebhookWithManager(mgr); err != nil {
+			log.Error(err, "unable to create webhook", "webhook", "AlertsConfig")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -164,6 +508,11 @@ func main() {
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		log.Error(err, "problem running manager")
+		_ = shutdownTracing(ctx)
 		os.Exit(1)
 	}
+	// flush the remaining spans
+	if err := shutdownTracing(ctx); err != nil {
+		log.Error(err, "unable to flush the traces")
+	}
 }
@@ -84,6 +84,14 @@ if [[ ! $CORRECT_CLUSTER =~ ^[Yy]$ ]]; then
   exit 1
 fi
 
+# The admission webhooks are served with a certificate issued by cert-manager
+if ! kubectl get crd certificates.cert-manager.io &> /dev/null; then
+  echo "Error: cert-manager is not installed in the cluster."
+  echo "alert-manager serves its admission webhooks with a certificate issued by cert-manager."
+  echo "Please install cert-manager (https://cert-manager.io/docs/installation/) and try again."
+  exit 1
+fi
+
 echo "Installing alert-manager in namespace: $NAMESPACE with monitoring backend: $MONITORING_URL"
 echo "---"
 
@@ -102,6 +110,8 @@ cp $SCRIPT_DIR/alert-manager.yaml $TEMP_DIR/alert-manager.yaml
 if [ "$NAMESPACE" != "alert-manager-system" ]; then
   echo "Setting custom namespace: $NAMESPACE"
   sed -i.bak "s/namespace: alert-manager-system/namespace: $NAMESPACE/g" $TEMP_DIR/alert-manager.yaml
+  # The webhook certificate DNS names and the CA injection annotation also carry the namespace
+  sed -i.bak "s/\.alert-manager-system\.svc/.$NAMESPACE.svc/g; s|alert-manager-system/alert-manager-serving-cert|$NAMESPACE/alert-manager-serving-cert|g" $TEMP_DIR/alert-manager.yaml
   rm -f $TEMP_DIR/alert-manager.yaml.bak
 fi
 
@@ -20,9 +20,13 @@ package common
 
 const (
 
-	// AlertManagerNamespaceName is the namespace name where alert-manager controllers are running
+	// AlertManagerNamespaceName is the default namespace name where alert-manager controllers are running
 	AlertManagerNamespaceName = "alert-manager-system"
 
+	// AlertManagerNamespaceEnv is the environment variable with the namespace alert-manager controllers are running in.
+	// Usually set with the downward api. Defaults to AlertManagerNamespaceName
+	AlertManagerNamespaceEnv = "POD_NAMESPACE"
+
 	// AlertManagerConfigMapName is the config map name for alert-manager namespace
 	AlertManagerConfigMapName = "alert-manager-configmap"
 
@@ -31,4 +35,19 @@ const (
 
 	//WavefrontAPIUrl is the address of wavefront api
 	WavefrontAPIUrl = "wavefront.api.url"
+
+	//WorkloadParamsFromLabels maps alerts config params to workload labels. Format is param=label,param2=label2
+	WorkloadParamsFromLabels = "workload.params.from.labels"
+
+	//WorkloadParamsFromAnnotations maps alerts config params to workload annotations. Format is param=annotation,param2=annotation2
+	WorkloadParamsFromAnnotations = "workload.params.from.annotations"
+
+	//ClusterID identifies the cluster in the ownership tags added to the wavefront alerts. Orphan alert collector is disabled without it
+	ClusterID = "cluster.id"
+
+	//ClusterAlertNamePolicy adds the cluster id to the alert names. One of none (default), prefix or suffix
+	ClusterAlertNamePolicy = "cluster.alert.name.policy"
+
+	//ClusterAlertNameSeparator is used between the alert name and the cluster id. Defaults to " - "
+	ClusterAlertNameSeparator = "cluster.alert.name.separator"
 )
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/keikoproj/alert-manager/internal/config/common"
 	"github.com/keikoproj/alert-manager/pkg/k8s"
@@ -34,8 +35,13 @@ var (
 )
 
 type Properties struct {
-	wavefrontAPITokenSecretName string
-	wavefrontAPIUrl             string
+	wavefrontAPITokenSecretName   string
+	wavefrontAPIUrl               string
+	workloadParamsFromLabels      map[string]string
+	workloadParamsFromAnnotations map[string]string
+	clusterID                     string
+	clusterAlertNamePolicy        string
+	clusterAlertNameSeparator     string
 }
 
 func init() {
@@ -51,7 +57,7 @@ func init() {
 		return
 	}
 
-	res := k8s.NewK8sSelfClientDoOrDie().GetConfigMap(context.Background(), common.AlertManagerNamespaceName, common.AlertManagerConfigMapName)
+	res := k8s.NewK8sSelfClientDoOrDie().GetConfigMap(context.Background(), Namespace(), common.AlertManagerConfigMapName)
 
 	// load properties into a global variable
 	if err := LoadProperties("", res); err != nil {
@@ -61,6 +67,14 @@ func init() {
 	logger.Info("Loaded properties in init func")
 }
 
+// Namespace returns the namespace alert-manager controllers are running in. Config map, api token secret and leases are in this namespace
+func Namespace() string {
+	if ns := strings.TrimSpace(os.Getenv(common.AlertManagerNamespaceEnv)); ns != "" {
+		return ns
+	}
+	return common.AlertManagerNamespaceName
+}
+
 func LoadProperties(env string, cm ...*v1.ConfigMap) error {
 	logger := log.Logger(context.Background(), "internal.config.properties", "LoadProperties")
 	Props = &Properties{}
@@ -89,9 +103,45 @@ func LoadProperties(env string, cm ...*v1.ConfigMap) error {
 	}
 	Props.wavefrontAPIUrl = WavefrontAPIUrl
 
+	workloadParamsFromLabels, err := parseParamsMapping(cm[0].Data[common.WorkloadParamsFromLabels])
+	if err != nil {
+		logger.Error(err, "invalid workload params mapping", "key", common.WorkloadParamsFromLabels)
+		return err
+	}
+	Props.workloadParamsFromLabels = workloadParamsFromLabels
+
+	workloadParamsFromAnnotations, err := parseParamsMapping(cm[0].Data[common.WorkloadParamsFromAnnotations])
+	if err != nil {
+		logger.Error(err, "invalid workload params mapping", "key", common.WorkloadParamsFromAnnotations)
+		return err
+	}
+	Props.workloadParamsFromAnnotations = workloadParamsFromAnnotations
+	Props.clusterID = strings.TrimSpace(cm[0].Data[common.ClusterID])
+	Props.clusterAlertNamePolicy = strings.TrimSpace(cm[0].Data[common.ClusterAlertNamePolicy])
+	// separator is not trimmed since it usually has spaces
+	Props.clusterAlertNameSeparator = cm[0].Data[common.ClusterAlertNameSeparator]
+
 	return nil
 }
 
+// parseParamsMapping parses param=key,param2=key2 into a map of param to key
+func parseParamsMapping(value string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		param, key, ok := strings.Cut(pair, "=")
+		param, key = strings.TrimSpace(param), strings.TrimSpace(key)
+		if !ok || param == "" || key == "" {
+			return nil, fmt.Errorf("invalid mapping %q. must be in param=key format", pair)
+		}
+		mapping[param] = key
+	}
+	return mapping, nil
+}
+
 func (p *Properties) WavefrontAPITokenSecretName() string {
 	return p.wavefrontAPITokenSecretName
 }
@@ -100,9 +150,34 @@ func (p *Properties) WavefrontAPIUrl() string {
 	return p.wavefrontAPIUrl
 }
 
+// WorkloadParamsFromLabels returns the alerts config param to workload label mapping
+func (p *Properties) WorkloadParamsFromLabels() map[string]string {
+	return p.workloadParamsFromLabels
+}
+
+// WorkloadParamsFromAnnotations returns the alerts config param to workload annotation mapping
+func (p *Properties) WorkloadParamsFromAnnotations() map[string]string {
+	return p.workloadParamsFromAnnotations
+}
+
+// ClusterID returns the id of the cluster used in the ownership tags. Empty if not configured
+func (p *Properties) ClusterID() string {
+	return p.clusterID
+}
+
+// ClusterAlertNamePolicy returns how the cluster id is added to the alert names
+func (p *Properties) ClusterAlertNamePolicy() string {
+	return p.clusterAlertNamePolicy
+}
+
+// ClusterAlertNameSeparator returns the separator between the alert name and the cluster id
+func (p *Properties) ClusterAlertNameSeparator() string {
+	return p.clusterAlertNameSeparator
+}
+
 func RunConfigMapInformer(ctx context.Context) {
 	logger := log.Logger(context.Background(), "internal.config.properties", "RunConfigMapInformer")
-	cmInformer := k8s.GetConfigMapInformer(ctx, common.AlertManagerNamespaceName, common.AlertManagerConfigMapName)
+	cmInformer := k8s.GetConfigMapInformer(ctx, Namespace(), common.AlertManagerConfigMapName)
 	// AddEventHandler returns a handle and a registration error.
 	// We don't need to use the handle as we run the informer for the lifetime of the context
 	_, regErr := cmInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -46,6 +46,9 @@ func TestLoadProperties(t *testing.T) {
 			Data: map[string]string{
 				common.WavefrontAPITokenK8sSecretName: "test-token-secret",
 				common.WavefrontAPIUrl:                "https://test.wavefront.com",
+				common.ClusterID:                      "test-cluster",
+				common.ClusterAlertNamePolicy:         " prefix ",
+				common.ClusterAlertNameSeparator:      " | ",
 			},
 		}
 
@@ -53,6 +56,36 @@ func TestLoadProperties(t *testing.T) {
 		assert.NoError(t, err, "Should load properties from ConfigMap without error")
 		assert.Equal(t, "test-token-secret", Props.WavefrontAPITokenSecretName())
 		assert.Equal(t, "https://test.wavefront.com", Props.WavefrontAPIUrl())
+		assert.Equal(t, "test-cluster", Props.ClusterID())
+		assert.Equal(t, "prefix", Props.ClusterAlertNamePolicy())
+		assert.Equal(t, " | ", Props.ClusterAlertNameSeparator())
+	})
+
+	t.Run("loads workload params mappings from ConfigMap", func(t *testing.T) {
+		testCM := &v1.ConfigMap{
+			Data: map[string]string{
+				common.WavefrontAPIUrl:               "https://test.wavefront.com",
+				common.WorkloadParamsFromLabels:      "app=app.kubernetes.io/name, team=team",
+				common.WorkloadParamsFromAnnotations: "owner=example.com/owner",
+			},
+		}
+
+		err := LoadProperties("", testCM)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"app": "app.kubernetes.io/name", "team": "team"}, Props.WorkloadParamsFromLabels())
+		assert.Equal(t, map[string]string{"owner": "example.com/owner"}, Props.WorkloadParamsFromAnnotations())
+	})
+
+	t.Run("fails on invalid workload params mapping", func(t *testing.T) {
+		testCM := &v1.ConfigMap{
+			Data: map[string]string{
+				common.WavefrontAPIUrl:          "https://test.wavefront.com",
+				common.WorkloadParamsFromLabels: "app",
+			},
+		}
+
+		err := LoadProperties("", testCM)
+		assert.Error(t, err)
 	})
 }
 
@@ -108,3 +141,15 @@ func TestUpdateProperties(t *testing.T) {
 		assert.Equal(t, "https://updated.wavefront.com", Props.WavefrontAPIUrl())
 	})
 }
+
+func TestNamespace(t *testing.T) {
+	t.Run("defaults to alert-manager-system", func(t *testing.T) {
+		t.Setenv(common.AlertManagerNamespaceEnv, "")
+		assert.Equal(t, common.AlertManagerNamespaceName, Namespace())
+	})
+
+	t.Run("uses the namespace from environment", func(t *testing.T) {
+		t.Setenv(common.AlertManagerNamespaceEnv, "tenant-a-alert-manager")
+		assert.Equal(t, "tenant-a-alert-manager", Namespace())
+	})
+}
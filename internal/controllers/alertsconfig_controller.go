@@ -19,7 +19,9 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 
 	wf "github.com/WavefrontHQ/go-wavefront-management-api"
@@ -29,15 +31,21 @@ import (
 	internalconfig "github.com/keikoproj/alert-manager/internal/config"
 	controllercommon "github.com/keikoproj/alert-manager/internal/controllers/common"
 	"github.com/keikoproj/alert-manager/internal/utils"
+	"github.com/keikoproj/alert-manager/pkg/audit"
 	"github.com/keikoproj/alert-manager/pkg/log"
+	"github.com/keikoproj/alert-manager/pkg/tracing"
 	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	"go.opentelemetry.io/otel/attribute"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 const (
@@ -72,11 +80,18 @@ type AlertsConfigReconciler struct {
 	Recorder        record.EventRecorder
 	CommonClient    *controllercommon.Client
 	WavefrontClient wavefront.Interface
+	//DryRun previews the changes for all the alerts configs without calling wavefront create, update or delete APIs
+	DryRun bool
+	//Shards limits the reconciles to the namespaces of the owned shards. nil if sharding is not enabled
+	Shards *ShardManager
+	//Namespaces limits the reconciles to the watched namespaces. nil if all the namespaces are watched
+	Namespaces *NamespaceFilter
 }
 
 //+kubebuilder:rbac:groups=alertmanager.keikoproj.io,resources=alertsconfigs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=alertmanager.keikoproj.io,resources=alertsconfigs/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=alertmanager.keikoproj.io,resources=alertsconfigs/finalizers,verbs=update
+//+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -96,15 +111,32 @@ func (r *AlertsConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		}
 	}()
 	ctx = WithRequestID(ctx, uuid.New())
+	ctx, span := startReconcileSpan(ctx, alertmanagerv1alpha1.AlertsConfigKind, req)
+	defer span.End()
 	log := log.Logger(ctx, "controllers", "alertconfig_controller", "Reconcile")
 	log = log.WithValues("alertconfig_cr", req.NamespacedName)
 	log.Info("Start of the request")
 
+	if !r.Namespaces.Allows(ctx, req.Namespace) {
+		log.V(1).Info("namespace is not watched by this instance. skipping")
+		return ctrl.Result{}, nil
+	}
+	if !r.Shards.Owns(ctx, req.Namespace) {
+		log.V(1).Info("namespace belongs to a shard owned by another replica. skipping")
+		return ctrl.Result{}, nil
+	}
+
 	// Get the CR
 	var alertsConfig alertmanagerv1alpha1.AlertsConfig
 	if err := r.Get(ctx, req.NamespacedName, &alertsConfig); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	// wavefront changes are audited as done by the resource
+	ctx = audit.WithActor(ctx, audit.ActorFor(&alertsConfig, alertmanagerv1alpha1.AlertsConfigKind))
+	// Paused resource is left alone, even if it is being deleted, until the annotation is removed
+	if controllercommon.IsPaused(&alertsConfig) {
+		return r.CommonClient.Pause(ctx, &alertsConfig, &alertsConfig.Status.State)
+	}
 
 	// Check if it is delete request
 	if !alertsConfig.ObjectMeta.DeletionTimestamp.IsZero() {
@@ -129,22 +161,61 @@ func (r *AlertsConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 	alertHashMap := alertsConfig.Status.AlertsStatus
 	globalMap := alertsConfig.Spec.GlobalParams
+	// All the alerts are pushed again if requested. Request is handled once all of them succeed
+	request, forced := controllercommon.ReconcileRequested(&alertsConfig, alertsConfig.Status.LastHandledReconcileRequest)
+	if forced {
+		log.Info("reconcile requested. pushing the alerts again", "request", request)
+	} else {
+		request = ""
+	}
+	// Template changes are not rolled out to a paused alerts config so all the alerts are rendered again once it is resumed
+	if alertsConfig.Status.State == alertmanagerv1alpha1.Paused {
+		forced = true
+	}
+	// Alerts listed in spec + templates selected by the template selector
+	alerts, err := r.getAlertConfigs(ctx, &alertsConfig)
+	if err != nil {
+		log.Error(err, "unable to select the alert templates")
+		r.Recorder.Event(&alertsConfig, v1.EventTypeWarning, err.Error(), "unable to select the alert templates")
+		alertsConfig.Status.ErrorDescription = err.Error()
+		alertsConfig.Status.RetryCount = alertsConfig.Status.RetryCount + 1
+		return r.CommonClient.UpdateStatus(ctx, &alertsConfig, alertmanagerv1alpha1.Error, errRequeueTime)
+	}
+	// Derived metrics and dashboards are applied before the alerts so the alerts can query and link to them.
+	// Failed ones are kept in the status with the error and retried, alerts are still reconciled
+	if err := r.reconcileDerivedMetrics(ctx, &alertsConfig, forced); err != nil {
+		log.Error(err, "unable to reconcile the derived metrics")
+		r.Recorder.Event(&alertsConfig, v1.EventTypeWarning, string(alertmanagerv1alpha1.Error), err.Error())
+	}
+	if err := r.reconcileDashboards(ctx, &alertsConfig, forced); err != nil {
+		log.Error(err, "unable to reconcile the dashboards")
+		r.Recorder.Event(&alertsConfig, v1.EventTypeWarning, string(alertmanagerv1alpha1.Error), err.Error())
+	}
+	links := controllercommon.DashboardLinks(alertsConfig.Status.DashboardsStatus)
 	// Handle create/update here
-	for alertName, config := range alertsConfig.Spec.Alerts {
+	var summary controllercommon.UpdateSummary
+	for alertName, config := range alerts {
 
 		// Calculate checksum and compare it with the status checksum
 		exist, reqChecksum := utils.CalculateAlertConfigChecksum(ctx, config, globalMap)
+		if links != "" {
+			// Alerts are updated once the dashboard links change
+			reqChecksum = utils.CalculateChecksum(ctx, reqChecksum+links)
+		}
 		// if request and status checksum matches then there is NO change in this specific alert config
-		if exist && alertHashMap[alertName].LastChangeChecksum == reqChecksum && alertHashMap[alertName].State != alertmanagerv1alpha1.Error {
+		// previewed alerts are not skipped so they get applied once dry-run is turned off
+		if !forced && exist && alertHashMap[alertName].LastChangeChecksum == reqChecksum && alertHashMap[alertName].State != alertmanagerv1alpha1.Error && alertHashMap[alertName].DryRun == nil {
 			log.V(1).Info("checksum is equal so there is no change. skipping", "alertName", alertName)
 			//skip it
 			continue
 		}
 		// if there is a diff
 		// Get Alert CR
-		var wfAlert alertmanagerv1alpha1.WavefrontAlert
-		wfAlertNamespacedName := types.NamespacedName{Namespace: req.Namespace, Name: alertName}
-		if err := r.Get(ctx, wfAlertNamespacedName, &wfAlert); err != nil {
+		templateKind := getTemplateKind(alertsConfig.Spec.GlobalGVK, config)
+		_, templateSpan := tracing.Start(ctx, "fetch template", attribute.String("template", alertName), attribute.String("kind", templateKind))
+		wfAlert, clusterAlert, err := r.getAlertTemplate(ctx, req.Namespace, alertName, templateKind)
+		tracing.End(templateSpan, err)
+		if err != nil {
 			log.Error(err, "unable to get the wavefront alert details for the requested name", "wfAlertName", alertName)
 			// This means wavefront alert itself is not created.
 			// There could be 2 use cases
@@ -159,9 +230,22 @@ func (r *AlertsConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		//merge the alerts config global params and individual params
 		params := utils.MergeMaps(ctx, globalMap, config.Params)
 
-		if err := controllercommon.GetProcessedWFAlert(ctx, &wfAlert, params, &alert); err != nil {
+		if err := controllercommon.GetProcessedWFAlert(ctx, wfAlert, params, &alert); err != nil {
+			err = fmt.Errorf("alerts config %s entry %s: %w", alertsConfig.Name, alertName, err)
 			return r.PatchIndividualAlertsConfigError(ctx, &alertsConfig, alertName, alertmanagerv1alpha1.Error, err)
 		}
+		wavefront.AddOwnershipTags(&alert, controllercommon.AlertOwner(&alertsConfig, alertmanagerv1alpha1.AlertsConfigKind, alertName))
+		controllercommon.AddDashboardLinks(&alert, links)
+		// Either alerts config or the template can be in dry-run
+		if controllercommon.IsDryRun(&alertsConfig, r.DryRun) || controllercommon.IsDryRun(wfAlert, false) {
+			if err := r.previewIndividualAlert(ctx, &alertsConfig, alertName, templateKind, alert); err != nil {
+				return r.PatchIndividualAlertsConfigError(ctx, &alertsConfig, alertName, alertmanagerv1alpha1.Error, err)
+			}
+			continue
+		}
+		r.CommonClient.CheckPreviewedPayload(ctx, &alertsConfig, alertHashMap[alertName], &alert)
+		// create/update response overwrites the alert so calculate the checksum before
+		payloadHash := wavefront.PayloadHash(ctx, &alert)
 		// Create/Update Alert
 		if alertHashMap[alertName].ID == "" {
 			// Create use case
@@ -185,14 +269,19 @@ func (r *AlertsConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request
 				AssociatedAlert: alertmanagerv1alpha1.AssociatedAlert{
 					CR:         alertName,
 					Generation: wfAlert.Status.ObservedGeneration,
+					Kind:       templateKind,
 				},
 				AssociatedAlertsConfig: alertmanagerv1alpha1.AssociatedAlertsConfig{
 					CR: alertsConfig.Name,
 				},
 				LastUpdatedTimestamp: metav1.Now(),
 				ErrorDescription:     "",
+				PayloadHash:          payloadHash,
+				History:              alertHashMap[alertName].History,
 			}
-			if err := r.CommonClient.PatchWfAlertAndAlertsConfigStatus(ctx, alertmanagerv1alpha1.Ready, &wfAlert, &alertsConfig, alertStatus); err != nil {
+			r.CommonClient.RecordAlertChange(ctx, controllercommon.AlertOwner(&alertsConfig, alertmanagerv1alpha1.AlertsConfigKind, alertName), &alertStatus,
+				controllercommon.NewAlertChange(controllercommon.CreateOperation(alertHashMap[alertName]), &alertsConfig, alertmanagerv1alpha1.AlertsConfigKind, nil))
+			if err := r.patchTemplateAndAlertsConfigStatus(ctx, wfAlert, clusterAlert, &alertsConfig, alertStatus); err != nil {
 				log.Error(err, "unable to patch wfalert and alertsconfig status objects")
 				return r.PatchIndividualAlertsConfigError(ctx, &alertsConfig, alertName, alertmanagerv1alpha1.Error, err)
 			}
@@ -203,7 +292,13 @@ func (r *AlertsConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request
 			alert.ID = &alertID
 			//TODO: Move this to common so it can be used for both wavefront and alerts config
 			//Update use case
-			if err := r.WavefrontClient.UpdateAlert(ctx, &alert); err != nil {
+			// Alert is not updated if the rendered payload is same as the last applied one or the live alert
+			var diff []string
+			var err error
+			if forced || alertHashMap[alertName].PayloadHash != payloadHash || alertHashMap[alertName].State != alertmanagerv1alpha1.Ready {
+				diff, err = r.WavefrontClient.UpdateAlert(ctx, &alert)
+			}
+			if err != nil {
 				r.Recorder.Event(&alertsConfig, v1.EventTypeWarning, err.Error(), "unable to update the alert")
 				state := alertmanagerv1alpha1.Error
 				if strings.Contains(err.Error(), "Exceeded limit setting") {
@@ -219,14 +314,22 @@ func (r *AlertsConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 				return r.PatchIndividualAlertsConfigError(ctx, &alertsConfig, alertName, state, err)
 			}
+			summary.Add(len(diff) > 0)
 
 			alertStatus := alertHashMap[alertName]
+			if len(diff) > 0 {
+				r.CommonClient.RecordAlertChange(ctx, controllercommon.AlertOwner(&alertsConfig, alertmanagerv1alpha1.AlertsConfigKind, alertName), &alertStatus,
+					controllercommon.NewAlertChange(alertmanagerv1alpha1.AlertUpdate, &alertsConfig, alertmanagerv1alpha1.AlertsConfigKind, diff))
+			}
+			alertStatus.DryRun = nil
+			alertStatus.PayloadHash = payloadHash
 			alertStatus.LastChangeChecksum = reqChecksum
 			// Update the individual alert status state to be ready and cleanup the error message
 			alertStatus.State = alertmanagerv1alpha1.Ready
 			alertStatus.AssociatedAlert.Generation = wfAlert.Status.ObservedGeneration
+			alertStatus.AssociatedAlert.Kind = templateKind
 			alertStatus.ErrorDescription = ""
-			if err := r.CommonClient.PatchWfAlertAndAlertsConfigStatus(ctx, alertmanagerv1alpha1.Ready, &wfAlert, &alertsConfig, alertStatus); err != nil {
+			if err := r.patchTemplateAndAlertsConfigStatus(ctx, wfAlert, clusterAlert, &alertsConfig, alertStatus); err != nil {
 				log.Error(err, "unable to patch wfalert and alertsconfig status objects")
 				return r.PatchIndividualAlertsConfigError(ctx, &alertsConfig, alertName, alertmanagerv1alpha1.Error, err)
 			}
@@ -234,13 +337,15 @@ func (r *AlertsConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		}
 	}
 
+	r.CommonClient.RecordUpdateSummary(ctx, &alertsConfig, summary)
 	// Now - lets see if there is any config is removed compared to the status
 	// If there is any, we need to make a call to delete the alert
-	return r.HandleIndividalAlertConfigRemoval(ctx, req.NamespacedName)
+	return r.HandleIndividalAlertConfigRemoval(ctx, req.NamespacedName, request)
 }
 
-// HandleIndividalAlertConfigRemoval function handles if there is any config got removed from the spec, if so- delete that alert in wavefront and also update the status
-func (r *AlertsConfigReconciler) HandleIndividalAlertConfigRemoval(ctx context.Context, namespacedName types.NamespacedName) (ctrl.Result, error) {
+// HandleIndividalAlertConfigRemoval function handles if there is any config got removed from the spec, if so- delete that alert in wavefront and also update the status.
+// handledRequest is the reconcile request handled by this reconcile if any
+func (r *AlertsConfigReconciler) HandleIndividalAlertConfigRemoval(ctx context.Context, namespacedName types.NamespacedName, handledRequest string) (ctrl.Result, error) {
 	log := log.Logger(ctx, "controllers", "alertsconfig_controller", "HandleIndividalAlertConfigRemoval")
 	log = log.WithValues("alertsConfig_cr", namespacedName)
 	// Get the alerts config again
@@ -249,20 +354,54 @@ func (r *AlertsConfigReconciler) HandleIndividalAlertConfigRemoval(ctx context.C
 	if err := r.Get(ctx, namespacedName, &updatedAlertsConfig); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	alerts, err := r.getAlertConfigs(ctx, &updatedAlertsConfig)
+	if err != nil {
+		// Don't delete anything if we are not sure about the selected templates
+		log.Error(err, "unable to select the alert templates")
+		return r.CommonClient.UpdateStatus(ctx, &updatedAlertsConfig, alertmanagerv1alpha1.Error, errRequeueTime)
+	}
 	tempStatusConfig := updatedAlertsConfig.Status.AlertsStatus
 	tempState := updatedAlertsConfig.Status.State
 	var toBeDeleted []string
 	areAlertsReady := true
+	dryRun := controllercommon.IsDryRun(&updatedAlertsConfig, r.DryRun)
+	if !dryRun && tempState == alertmanagerv1alpha1.DryRun {
+		tempState = alertmanagerv1alpha1.Ready
+	}
+	resumed := tempState == alertmanagerv1alpha1.Paused
+	if resumed {
+		log.Info("resource got resumed")
+		tempState = alertmanagerv1alpha1.Ready
+	}
+	if handledRequest != "" {
+		updatedAlertsConfig.Status.LastHandledReconcileRequest = handledRequest
+	}
 
 	for key, status := range updatedAlertsConfig.Status.AlertsStatus {
 		// This is for sure delete use case
-		if _, ok := updatedAlertsConfig.Spec.Alerts[key]; !ok {
+		if _, ok := alerts[key]; !ok {
+			if dryRun && status.ID != "" {
+				// Keep it in the status so the alert gets deleted once dry-run is turned off
+				status.DryRun = controllercommon.PreviewDelete()
+				tempStatusConfig[key] = status
+				r.CommonClient.RecordDryRun(ctx, &updatedAlertsConfig, status.Name, status.DryRun)
+				tempState = alertmanagerv1alpha1.DryRun
+				continue
+			}
 			//This means we didn't find this in spec anymore
-			// Lets delete that then
-			if err := r.DeleteIndividualAlert(ctx, updatedAlertsConfig.Name, status, updatedAlertsConfig.Namespace); err != nil {
-				//Ignore if errors since we can consider it as already deleted
-				log.Error(err, "unable to delete the alert, assuming alerts doesn't exist anymore- proceeding further")
+			// Lets delete that then. Alert is not part of the spec anymore so alerts config deletion policy is applied
+			policy := controllercommon.GetDeletionPolicy(updatedAlertsConfig.Spec.DeletionPolicy)
+			if err := r.DeleteIndividualAlert(ctx, updatedAlertsConfig.Name, status, updatedAlertsConfig.Namespace, policy); err != nil {
+				// Keep it in the status so the deletion gets retried
+				status.State = alertmanagerv1alpha1.Error
+				status.ErrorDescription = err.Error()
+				tempStatusConfig[key] = status
+				tempState = alertmanagerv1alpha1.Error
+				areAlertsReady = false
+				continue
 			}
+			r.CommonClient.RecordAlertChange(ctx, controllercommon.AlertOwner(&updatedAlertsConfig, alertmanagerv1alpha1.AlertsConfigKind, key), nil,
+				controllercommon.NewAlertChange(alertmanagerv1alpha1.AlertDelete, &updatedAlertsConfig, alertmanagerv1alpha1.AlertsConfigKind, nil))
 			toBeDeleted = append(toBeDeleted, key)
 		} else {
 			if status.State == alertmanagerv1alpha1.Error {
@@ -272,35 +411,506 @@ func (r *AlertsConfigReconciler) HandleIndividalAlertConfigRemoval(ctx context.C
 		}
 	}
 
+	for _, dashboard := range updatedAlertsConfig.Status.DashboardsStatus {
+		if dashboard.State == alertmanagerv1alpha1.Error {
+			tempState = alertmanagerv1alpha1.Error
+			areAlertsReady = false
+		}
+	}
+	for _, metric := range updatedAlertsConfig.Status.DerivedMetricsStatus {
+		if metric.State == alertmanagerv1alpha1.Error {
+			tempState = alertmanagerv1alpha1.Error
+			areAlertsReady = false
+		}
+	}
+
 	for _, key := range toBeDeleted {
 		delete(tempStatusConfig, key)
 	}
 	// update the count
-	updatedAlertsConfig.Status.AlertsCount = len(updatedAlertsConfig.Spec.Alerts)
+	updatedAlertsConfig.Status.AlertsCount = len(alerts)
 	updatedAlertsConfig.Status.AlertsStatus = tempStatusConfig
 	// reset the retry count if all the alerts are Ready
 	if areAlertsReady {
 		updatedAlertsConfig.Status.RetryCount = 0
 	}
+	if resumed {
+		// unchanged alerts are skipped so nothing else sets the state
+		updatedAlertsConfig.Status.State = tempState
+	}
 	// update the status
 	return r.CommonClient.UpdateStatus(ctx, &updatedAlertsConfig, tempState, errRequeueTime)
 }
 
-// DeleteIndividualAlert function deletes individual alert and also patches the status on both wavefront alert and also alerts config status
-func (r *AlertsConfigReconciler) DeleteIndividualAlert(ctx context.Context, alertName string, alertStatus alertmanagerv1alpha1.AlertStatus, namespace string) error {
-	log := log.Logger(ctx, "controllers", "alertsconfig_controller", "DeleteIndividualAlert")
-	log = log.WithValues("alertsConfig_cr", alertName)
+// reconcileDashboards function renders the dashboard templates listed in the alerts config and applies them in wavefront.
+// Dashboards removed from the spec are deleted as per the alerts config deletion policy. Status is patched and updated in alertsConfig
+// so the alerts rendered afterwards get the links
+func (r *AlertsConfigReconciler) reconcileDashboards(ctx context.Context, alertsConfig *alertmanagerv1alpha1.AlertsConfig, forced bool) error {
+	log := log.Logger(ctx, "controllers", "alertsconfig_controller", "reconcileDashboards")
+	log = log.WithValues("alertsConfig_cr", alertsConfig.Name, "namespace", alertsConfig.Namespace)
+	if len(alertsConfig.Spec.Dashboards) == 0 && len(alertsConfig.Status.DashboardsStatus) == 0 {
+		return nil
+	}
+
+	dryRun := controllercommon.IsDryRun(alertsConfig, r.DryRun)
+	dashboardsStatus := make(map[string]alertmanagerv1alpha1.DashboardStatus, len(alertsConfig.Spec.Dashboards))
+	var errs []error
+	for name, config := range alertsConfig.Spec.Dashboards {
+		status, err := r.applyDashboard(ctx, alertsConfig, name, config, alertsConfig.Status.DashboardsStatus[name], dryRun, forced)
+		if err != nil {
+			status.State = alertmanagerv1alpha1.Error
+			status.ErrorDescription = err.Error()
+			errs = append(errs, fmt.Errorf("dashboard %s: %w", name, err))
+		}
+		dashboardsStatus[name] = status
+	}
+
+	policy := controllercommon.GetDeletionPolicy(alertsConfig.Spec.DeletionPolicy)
+	for name, status := range alertsConfig.Status.DashboardsStatus {
+		if _, ok := alertsConfig.Spec.Dashboards[name]; ok {
+			continue
+		}
+		if dryRun && status.ID != "" {
+			// Keep it in the status so the dashboard gets deleted once dry-run is turned off
+			log.Info("dry-run: dashboard is not deleted from wavefront", "dashboardID", status.ID)
+			dashboardsStatus[name] = status
+			continue
+		}
+		if err := controllercommon.DeleteDashboard(ctx, r.WavefrontClient, status.ID, policy); err != nil {
+			// Keep it in the status so the deletion gets retried
+			status.State = alertmanagerv1alpha1.Error
+			status.ErrorDescription = err.Error()
+			dashboardsStatus[name] = status
+			errs = append(errs, fmt.Errorf("dashboard %s: %w", name, err))
+		}
+	}
+
+	if reflect.DeepEqual(dashboardsStatus, alertsConfig.Status.DashboardsStatus) {
+		return errors.Join(errs...)
+	}
+	previous := alertsConfig.Status.DashboardsStatus
+	alertsConfig.Status.DashboardsStatus = dashboardsStatus
+	removed := make([]string, 0, len(previous))
+	for name := range previous {
+		if _, ok := dashboardsStatus[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	if err := r.patchRenderedStatus(ctx, alertsConfig, "dashboardsStatus", removed, dashboardsStatus, len(errs) > 0, dryRun); err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+// reconcileDerivedMetrics function renders the derived metric templates listed in the alerts config and applies them in wavefront.
+// Derived metrics removed from the spec are deleted as per the alerts config deletion policy. Status is patched and updated in alertsConfig
+func (r *AlertsConfigReconciler) reconcileDerivedMetrics(ctx context.Context, alertsConfig *alertmanagerv1alpha1.AlertsConfig, forced bool) error {
+	log := log.Logger(ctx, "controllers", "alertsconfig_controller", "reconcileDerivedMetrics")
+	log = log.WithValues("alertsConfig_cr", alertsConfig.Name, "namespace", alertsConfig.Namespace)
+	if len(alertsConfig.Spec.DerivedMetrics) == 0 && len(alertsConfig.Status.DerivedMetricsStatus) == 0 {
+		return nil
+	}
+
+	dryRun := controllercommon.IsDryRun(alertsConfig, r.DryRun)
+	derivedMetricsStatus := make(map[string]alertmanagerv1alpha1.DerivedMetricStatus, len(alertsConfig.Spec.DerivedMetrics))
+	var errs []error
+	for name, config := range alertsConfig.Spec.DerivedMetrics {
+		status, err := r.applyDerivedMetric(ctx, alertsConfig, name, config, alertsConfig.Status.DerivedMetricsStatus[name], dryRun, forced)
+		if err != nil {
+			status.State = alertmanagerv1alpha1.Error
+			status.ErrorDescription = err.Error()
+			errs = append(errs, fmt.Errorf("derived metric %s: %w", name, err))
+		}
+		derivedMetricsStatus[name] = status
+	}
+
+	policy := controllercommon.GetDeletionPolicy(alertsConfig.Spec.DeletionPolicy)
+	for name, status := range alertsConfig.Status.DerivedMetricsStatus {
+		if _, ok := alertsConfig.Spec.DerivedMetrics[name]; ok {
+			continue
+		}
+		if dryRun && status.ID != "" {
+			// Keep it in the status so the derived metric gets deleted once dry-run is turned off
+			log.Info("dry-run: derived metric is not deleted from wavefront", "derivedMetricID", status.ID)
+			derivedMetricsStatus[name] = status
+			continue
+		}
+		if err := controllercommon.DeleteDerivedMetric(ctx, r.WavefrontClient, status.ID, policy); err != nil {
+			// Keep it in the status so the deletion gets retried
+			status.State = alertmanagerv1alpha1.Error
+			status.ErrorDescription = err.Error()
+			derivedMetricsStatus[name] = status
+			errs = append(errs, fmt.Errorf("derived metric %s: %w", name, err))
+		}
+	}
+
+	if reflect.DeepEqual(derivedMetricsStatus, alertsConfig.Status.DerivedMetricsStatus) {
+		return errors.Join(errs...)
+	}
+	previous := alertsConfig.Status.DerivedMetricsStatus
+	alertsConfig.Status.DerivedMetricsStatus = derivedMetricsStatus
+	removed := make([]string, 0, len(previous))
+	for name := range previous {
+		if _, ok := derivedMetricsStatus[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	if err := r.patchRenderedStatus(ctx, alertsConfig, "derivedMetricsStatus", removed, derivedMetricsStatus, len(errs) > 0, dryRun); err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+// patchRenderedStatus function patches the status field of the dashboards or the derived metrics rendered by the alerts config.
+// Removed entries are set to null so they get removed by the merge patch. Error state is cleared if nothing else is in error
+func (r *AlertsConfigReconciler) patchRenderedStatus(ctx context.Context, alertsConfig *alertmanagerv1alpha1.AlertsConfig, field string, removed []string, current interface{}, failed bool, dryRun bool) error {
+	entries := make(map[string]interface{})
+	for _, name := range removed {
+		entries[name] = nil
+	}
+	currentBytes, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(currentBytes, &entries); err != nil {
+		return err
+	}
+	state := alertsConfig.Status.State
+	switch {
+	case failed:
+		state = alertmanagerv1alpha1.Error
+	case dryRun:
+		state = alertmanagerv1alpha1.DryRun
+	case state == alertmanagerv1alpha1.Error && !hasErrors(alertsConfig):
+		state = alertmanagerv1alpha1.Ready
+	}
+	patch, err := json.Marshal(map[string]interface{}{"status": map[string]interface{}{"state": state, field: entries}})
+	if err != nil {
+		return err
+	}
+	return r.Status().Patch(ctx, alertsConfig, client.RawPatch(types.MergePatchType, patch))
+}
+
+// hasErrors function returns true if any of the alerts, dashboards or derived metrics of the alerts config is in error state
+func hasErrors(alertsConfig *alertmanagerv1alpha1.AlertsConfig) bool {
+	for _, status := range alertsConfig.Status.AlertsStatus {
+		if status.State == alertmanagerv1alpha1.Error {
+			return true
+		}
+	}
+	for _, status := range alertsConfig.Status.DashboardsStatus {
+		if status.State == alertmanagerv1alpha1.Error {
+			return true
+		}
+	}
+	for _, status := range alertsConfig.Status.DerivedMetricsStatus {
+		if status.State == alertmanagerv1alpha1.Error {
+			return true
+		}
+	}
+	return false
+}
+
+// applyDerivedMetric function renders the derived metric template with the global and the derived metric params and applies it in wavefront.
+// Returns the new status of the derived metric. Nothing is applied in dry-run
+func (r *AlertsConfigReconciler) applyDerivedMetric(
+	ctx context.Context,
+	alertsConfig *alertmanagerv1alpha1.AlertsConfig,
+	name string,
+	config alertmanagerv1alpha1.DerivedMetricConfig,
+	status alertmanagerv1alpha1.DerivedMetricStatus,
+	dryRun bool,
+	forced bool,
+) (alertmanagerv1alpha1.DerivedMetricStatus, error) {
+	var wfDerivedMetric alertmanagerv1alpha1.WavefrontDerivedMetric
+	if err := r.Get(ctx, types.NamespacedName{Namespace: alertsConfig.Namespace, Name: name}, &wfDerivedMetric); err != nil {
+		return status, err
+	}
+	// standalone derived metrics can't be used with alerts config
+	if len(wfDerivedMetric.Spec.ExportedParams) == 0 {
+		return status, fmt.Errorf("derived metric %s is not a template. exportedParams must be provided", name)
+	}
+	var metric wf.DerivedMetric
+	params := utils.MergeMaps(ctx, alertsConfig.Spec.GlobalParams, config.Params)
+	if err := wavefront.RenderDerivedMetric(ctx, &wfDerivedMetric, params, &metric); err != nil {
+		return status, err
+	}
+	if dryRun {
+		status.Name = metric.Name
+		status.State = alertmanagerv1alpha1.DryRun
+		status.ErrorDescription = ""
+		return status, nil
+	}
+	return controllercommon.ApplyDerivedMetric(ctx, r.WavefrontClient, status, &metric, forced)
+}
 
+// applyDashboard function renders the dashboard template with the global and the dashboard params and applies it in wavefront.
+// Returns the new status of the dashboard. Nothing is applied in dry-run
+func (r *AlertsConfigReconciler) applyDashboard(
+	ctx context.Context,
+	alertsConfig *alertmanagerv1alpha1.AlertsConfig,
+	name string,
+	config alertmanagerv1alpha1.DashboardConfig,
+	status alertmanagerv1alpha1.DashboardStatus,
+	dryRun bool,
+	forced bool,
+) (alertmanagerv1alpha1.DashboardStatus, error) {
+	var wfDashboard alertmanagerv1alpha1.WavefrontDashboard
+	if err := r.Get(ctx, types.NamespacedName{Namespace: alertsConfig.Namespace, Name: name}, &wfDashboard); err != nil {
+		return status, err
+	}
+	// standalone dashboards can't be used with alerts config
+	if len(wfDashboard.Spec.ExportedParams) == 0 {
+		return status, fmt.Errorf("dashboard %s is not a template. exportedParams must be provided", name)
+	}
+	var dashboard wf.Dashboard
+	params := utils.MergeMaps(ctx, alertsConfig.Spec.GlobalParams, config.Params)
+	if err := wavefront.RenderDashboard(ctx, &wfDashboard, params, wavefront.DefaultDashboardURL(alertsConfig.Namespace, alertsConfig.Name, name), &dashboard); err != nil {
+		return status, err
+	}
+	if dryRun {
+		status.Name = dashboard.Name
+		status.State = alertmanagerv1alpha1.DryRun
+		status.ErrorDescription = ""
+		return status, nil
+	}
+	return controllercommon.ApplyDashboard(ctx, r.WavefrontClient, status, &dashboard, forced)
+}
+
+// previewIndividualAlert function records the dry-run preview of the alert in the alerts config status without calling wavefront create or update APIs.
+// Template status is left alone since nothing got created from it yet
+func (r *AlertsConfigReconciler) previewIndividualAlert(ctx context.Context, alertsConfig *alertmanagerv1alpha1.AlertsConfig, alertName string, templateKind string, alert wf.Alert) error {
+	alertStatus, ok := alertsConfig.Status.AlertsStatus[alertName]
+	if !ok {
+		// Alert ID is empty so the alert gets created once dry-run is turned off
+		alertStatus = alertmanagerv1alpha1.AlertStatus{
+			Name:  alert.Name,
+			State: alertmanagerv1alpha1.DryRun,
+			AssociatedAlert: alertmanagerv1alpha1.AssociatedAlert{
+				CR:   alertName,
+				Kind: templateKind,
+			},
+			AssociatedAlertsConfig: alertmanagerv1alpha1.AssociatedAlertsConfig{
+				CR: alertsConfig.Name,
+			},
+		}
+	}
 	if alertStatus.ID != "" {
-		if err := r.WavefrontClient.DeleteAlert(ctx, alertStatus.ID); err != nil {
-			log.Error(err, "skipping alert deletion", "alertID", alertStatus.ID)
-			// Just skip it for now
-			// this is too opinionated but we don't want to stop the delete execution for other alerts as well
-			// if there is any valid reasons not to skip it, we can look into it in future
+		alertID := alertStatus.ID
+		alert.ID = &alertID
+	}
+	preview, err := controllercommon.PreviewAlert(ctx, r.WavefrontClient, &alert)
+	if err != nil {
+		return err
+	}
+	alertStatus.DryRun = preview
+	alertStatus.LastUpdatedTimestamp = metav1.Now()
+	alertStatusBytes, _ := json.Marshal(alertStatus)
+	r.CommonClient.RecordDryRun(ctx, alertsConfig, alert.Name, preview)
+
+	patch := []byte(fmt.Sprintf("{\"status\":{\"state\": \"%s\", \"alertsStatus\":{\"%s\":%s}}}", alertmanagerv1alpha1.DryRun, alertName, string(alertStatusBytes)))
+	_, err = r.CommonClient.PatchStatus(ctx, alertsConfig, client.RawPatch(types.MergePatchType, patch), alertmanagerv1alpha1.DryRun)
+	return err
+}
+
+// getAlertConfigs function returns the alert configs to be reconciled keyed by the template name.
+// This includes all the alerts in spec and the templates selected by the template selector with empty config
+// so only the global params are applied to them
+func (r *AlertsConfigReconciler) getAlertConfigs(ctx context.Context, alertsConfig *alertmanagerv1alpha1.AlertsConfig) (map[string]alertmanagerv1alpha1.Config, error) {
+	alerts := make(map[string]alertmanagerv1alpha1.Config, len(alertsConfig.Spec.Alerts))
+	for name, config := range alertsConfig.Spec.Alerts {
+		alerts[name] = config
+	}
+	if alertsConfig.Spec.TemplateSelector == nil {
+		return alerts, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(alertsConfig.Spec.TemplateSelector)
+	if err != nil {
+		return nil, err
+	}
+	var selected []string
+	if alertsConfig.Spec.GlobalGVK.Kind == alertmanagerv1alpha1.ClusterWavefrontAlertKind {
+		var ns v1.Namespace
+		if err := r.Get(ctx, types.NamespacedName{Name: alertsConfig.Namespace}, &ns); err != nil {
+			return nil, err
+		}
+		var clusterAlerts alertmanagerv1alpha1.ClusterWavefrontAlertList
+		if err := r.List(ctx, &clusterAlerts, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+		for i := range clusterAlerts.Items {
+			// skip the templates this namespace is not allowed to use instead of failing
+			if allowed, err := controllercommon.IsNamespaceAllowed(&clusterAlerts.Items[i], &ns); err != nil || !allowed {
+				continue
+			}
+			selected = append(selected, clusterAlerts.Items[i].Name)
+		}
+	} else {
+		var wfAlerts alertmanagerv1alpha1.WavefrontAlertList
+		if err := r.List(ctx, &wfAlerts, client.InNamespace(alertsConfig.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+		for _, wfAlert := range wfAlerts.Items {
+			// standalone alerts can't be used with alerts config
+			if len(wfAlert.Spec.ExportedParams) == 0 {
+				continue
+			}
+			selected = append(selected, wfAlert.Name)
+		}
+	}
+
+	for _, name := range selected {
+		if _, ok := alerts[name]; !ok {
+			alerts[name] = alertmanagerv1alpha1.Config{}
+		}
+	}
+	return alerts, nil
+}
+
+// alertsConfigsForTemplate function maps a template event to the alerts configs which select the template
+// or already have an alert created from it so added, changed and removed templates get reconciled
+func (r *AlertsConfigReconciler) alertsConfigsForTemplate(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := log.Logger(ctx, "controllers", "alertsconfig_controller", "alertsConfigsForTemplate")
+	// cluster templates can be selected by alerts configs in any namespace
+	isClusterTemplate := obj.GetNamespace() == ""
+	var listOpts []client.ListOption
+	if !isClusterTemplate {
+		listOpts = append(listOpts, client.InNamespace(obj.GetNamespace()))
+	}
+	var alertsConfigs alertmanagerv1alpha1.AlertsConfigList
+	if err := r.List(ctx, &alertsConfigs, listOpts...); err != nil {
+		log.Error(err, "unable to list alerts configs", "template", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, alertsConfig := range alertsConfigs.Items {
+		if alertsConfig.Spec.TemplateSelector == nil {
+			continue
+		}
+		if (alertsConfig.Spec.GlobalGVK.Kind == alertmanagerv1alpha1.ClusterWavefrontAlertKind) != isClusterTemplate {
+			continue
+		}
+		_, exists := alertsConfig.Status.AlertsStatus[obj.GetName()]
+		selector, err := metav1.LabelSelectorAsSelector(alertsConfig.Spec.TemplateSelector)
+		if err != nil {
+			continue
+		}
+		if exists || selector.Matches(labels.Set(obj.GetLabels())) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: alertsConfig.Namespace, Name: alertsConfig.Name}})
+		}
+	}
+	return requests
+}
+
+// alertsConfigsForDashboard function maps a dashboard template event to the alerts configs in the same namespace using it
+func (r *AlertsConfigReconciler) alertsConfigsForDashboard(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := log.Logger(ctx, "controllers", "alertsconfig_controller", "alertsConfigsForDashboard")
+	var alertsConfigs alertmanagerv1alpha1.AlertsConfigList
+	if err := r.List(ctx, &alertsConfigs, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.Error(err, "unable to list alerts configs", "dashboard", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, alertsConfig := range alertsConfigs.Items {
+		_, inSpec := alertsConfig.Spec.Dashboards[obj.GetName()]
+		_, inStatus := alertsConfig.Status.DashboardsStatus[obj.GetName()]
+		if inSpec || inStatus {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: alertsConfig.Namespace, Name: alertsConfig.Name}})
+		}
+	}
+	return requests
+}
+
+// alertsConfigsForDerivedMetric function maps a derived metric template event to the alerts configs in the same namespace using it
+func (r *AlertsConfigReconciler) alertsConfigsForDerivedMetric(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := log.Logger(ctx, "controllers", "alertsconfig_controller", "alertsConfigsForDerivedMetric")
+	var alertsConfigs alertmanagerv1alpha1.AlertsConfigList
+	if err := r.List(ctx, &alertsConfigs, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.Error(err, "unable to list alerts configs", "derivedMetric", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, alertsConfig := range alertsConfigs.Items {
+		_, inSpec := alertsConfig.Spec.DerivedMetrics[obj.GetName()]
+		_, inStatus := alertsConfig.Status.DerivedMetricsStatus[obj.GetName()]
+		if inSpec || inStatus {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: alertsConfig.Namespace, Name: alertsConfig.Name}})
+		}
+	}
+	return requests
+}
+
+// getTemplateKind returns the template kind for an alert config. Individual config GVK overwrites the global GVK
+func getTemplateKind(globalGVK alertmanagerv1alpha1.GVK, config alertmanagerv1alpha1.Config) string {
+	if config.GVK.Kind != "" {
+		return config.GVK.Kind
+	}
+	return globalGVK.Kind
+}
+
+// getAlertTemplate function retrieves the alert template for the alert name.
+// For cluster scoped templates, it also returns the ClusterWavefrontAlert after making sure the namespace can consume it
+func (r *AlertsConfigReconciler) getAlertTemplate(ctx context.Context, namespace string, alertName string, kind string) (*alertmanagerv1alpha1.WavefrontAlert, *alertmanagerv1alpha1.ClusterWavefrontAlert, error) {
+	if kind != alertmanagerv1alpha1.ClusterWavefrontAlertKind {
+		var wfAlert alertmanagerv1alpha1.WavefrontAlert
+		if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: alertName}, &wfAlert); err != nil {
+			return nil, nil, err
 		}
+		return &wfAlert, nil, nil
+	}
+
+	var clusterAlert alertmanagerv1alpha1.ClusterWavefrontAlert
+	if err := r.Get(ctx, types.NamespacedName{Name: alertName}, &clusterAlert); err != nil {
+		return nil, nil, err
+	}
+	if err := r.CommonClient.CheckNamespaceAllowed(ctx, &clusterAlert, namespace); err != nil {
+		return nil, nil, err
+	}
+	return clusterAlert.AsWavefrontAlert(), &clusterAlert, nil
+}
+
+// patchTemplateAndAlertsConfigStatus function patches the individual alert status on alerts config and on the template it was created from
+func (r *AlertsConfigReconciler) patchTemplateAndAlertsConfigStatus(
+	ctx context.Context,
+	wfAlert *alertmanagerv1alpha1.WavefrontAlert,
+	clusterAlert *alertmanagerv1alpha1.ClusterWavefrontAlert,
+	alertsConfig *alertmanagerv1alpha1.AlertsConfig,
+	alertStatus alertmanagerv1alpha1.AlertStatus,
+) error {
+	if clusterAlert != nil {
+		return r.CommonClient.PatchClusterWfAlertAndAlertsConfigStatus(ctx, alertmanagerv1alpha1.Ready, clusterAlert, alertsConfig, alertStatus)
+	}
+	return r.CommonClient.PatchWfAlertAndAlertsConfigStatus(ctx, alertmanagerv1alpha1.Ready, wfAlert, alertsConfig, alertStatus)
+}
+
+// DeleteIndividualAlert function deletes (or retains/snoozes as per the deletion policy) individual alert and also patches the status on wavefront alert.
+// Template status is left alone if the alert couldn't be deleted so it can be retried
+func (r *AlertsConfigReconciler) DeleteIndividualAlert(ctx context.Context, alertName string, alertStatus alertmanagerv1alpha1.AlertStatus, namespace string, policy alertmanagerv1alpha1.DeletionPolicy) error {
+	log := log.Logger(ctx, "controllers", "alertsconfig_controller", "DeleteIndividualAlert")
+	log = log.WithValues("alertsConfig_cr", alertName)
+
+	if err := controllercommon.ApplyDeletionPolicy(ctx, r.WavefrontClient, alertStatus.ID, policy); err != nil {
+		log.Error(err, "unable to delete the alert", "alertID", alertStatus.ID)
+		return err
 	}
 
 	// Update the wavefront alert status
+	if alertStatus.AssociatedAlert.Kind == alertmanagerv1alpha1.ClusterWavefrontAlertKind {
+		var clusterAlert alertmanagerv1alpha1.ClusterWavefrontAlert
+		if err := r.Get(ctx, types.NamespacedName{Name: alertStatus.AssociatedAlert.CR}, &clusterAlert); err != nil {
+			log.Error(err, "unable to get the cluster wavefront alert details for the requested name", "clusterWfAlertName", alertStatus.AssociatedAlert.CR)
+			// This means cluster wavefront alert itself is not there so we can ignore.
+			return nil
+		}
+		if err := r.CommonClient.RemoveClusterWfAlertStatus(ctx, &clusterAlert, namespace, alertName); err != nil {
+			log.Error(err, "Failed to update ClusterWavefrontAlert status")
+		}
+		return nil
+	}
 
 	var wfAlert alertmanagerv1alpha1.WavefrontAlert
 	wfAlertNamespacedName := types.NamespacedName{Namespace: namespace, Name: alertStatus.AssociatedAlert.CR}
@@ -344,19 +954,75 @@ func (r *AlertsConfigReconciler) PatchIndividualAlertsConfigError(ctx context.Co
 func (r *AlertsConfigReconciler) HandleDelete(ctx context.Context, alertsConfig *alertmanagerv1alpha1.AlertsConfig) error {
 	log := log.Logger(ctx, "controllers", "alertsconfig_controller", "HandleDelete")
 	log = log.WithValues("alertsConfig_cr", alertsConfig.Name, "namespace", alertsConfig.Namespace)
+	if controllercommon.IsDeletionProtected(alertsConfig) {
+		// Finalizer is kept. Removing the annotation triggers the reconcile again
+		r.CommonClient.RecordDeletionProtected(ctx, alertsConfig)
+		return nil
+	}
+	if controllercommon.IsDryRun(alertsConfig, r.DryRun) {
+		// Finalizer is kept so the alerts get deleted once dry-run is turned off
+		for name, alert := range alertsConfig.Status.AlertsStatus {
+			if alert.ID == "" {
+				continue
+			}
+			alert.DryRun = controllercommon.PreviewDelete()
+			alertsConfig.Status.AlertsStatus[name] = alert
+			r.CommonClient.RecordDryRun(ctx, alertsConfig, alert.Name, alert.DryRun)
+		}
+		alertsConfig.Status.State = alertmanagerv1alpha1.DryRun
+		_, err := r.CommonClient.UpdateStatus(ctx, alertsConfig, alertmanagerv1alpha1.DryRun)
+		return err
+	}
 	// Lets check the status of the CR and
 	// retrieve all the alerts associated with this CR and delete it
 	//Check if any alerts were created with this config
-	if len(alertsConfig.Status.AlertsStatus) > 0 {
-		//Call wavefront api and delete the alerts one by one
-		for _, alert := range alertsConfig.Status.AlertsStatus {
-			if err := r.DeleteIndividualAlert(ctx, alertsConfig.Name, alert, alertsConfig.Namespace); err != nil {
-				log.Error(err, "skipping alert deletion", "alertID", alert.ID)
-				// Just skip it for now
-				// this is too opinionated but we don't want to stop the delete execution for other alerts as well
-				// if there is any valid reasons not to skip it, we can look into it in future
-			}
+	failed := 0
+	//Call wavefront api and delete the alerts one by one
+	for name, alert := range alertsConfig.Status.AlertsStatus {
+		// Individual alert deletion policy overwrites the alerts config one
+		policy := controllercommon.GetDeletionPolicy(alertsConfig.Spec.Alerts[name].DeletionPolicy, alertsConfig.Spec.DeletionPolicy)
+		if err := r.DeleteIndividualAlert(ctx, alertsConfig.Name, alert, alertsConfig.Namespace, policy); err != nil {
+			// Continue with the other alerts. Failed ones are kept in the status and retried
+			alert.State = alertmanagerv1alpha1.Error
+			alert.ErrorDescription = err.Error()
+			alertsConfig.Status.AlertsStatus[name] = alert
+			failed++
+			continue
 		}
+		delete(alertsConfig.Status.AlertsStatus, name)
+		r.CommonClient.RecordAlertChange(ctx, controllercommon.AlertOwner(alertsConfig, alertmanagerv1alpha1.AlertsConfigKind, name), nil,
+			controllercommon.NewAlertChange(alertmanagerv1alpha1.AlertDelete, alertsConfig, alertmanagerv1alpha1.AlertsConfigKind, nil))
+	}
+	policy := controllercommon.GetDeletionPolicy(alertsConfig.Spec.DeletionPolicy)
+	failedDashboards := 0
+	for name, dashboard := range alertsConfig.Status.DashboardsStatus {
+		if err := controllercommon.DeleteDashboard(ctx, r.WavefrontClient, dashboard.ID, policy); err != nil {
+			dashboard.State = alertmanagerv1alpha1.Error
+			dashboard.ErrorDescription = err.Error()
+			alertsConfig.Status.DashboardsStatus[name] = dashboard
+			failedDashboards++
+			continue
+		}
+		delete(alertsConfig.Status.DashboardsStatus, name)
+	}
+	failedDerivedMetrics := 0
+	for name, metric := range alertsConfig.Status.DerivedMetricsStatus {
+		if err := controllercommon.DeleteDerivedMetric(ctx, r.WavefrontClient, metric.ID, policy); err != nil {
+			metric.State = alertmanagerv1alpha1.Error
+			metric.ErrorDescription = err.Error()
+			alertsConfig.Status.DerivedMetricsStatus[name] = metric
+			failedDerivedMetrics++
+			continue
+		}
+		delete(alertsConfig.Status.DerivedMetricsStatus, name)
+	}
+	if failed > 0 || failedDashboards > 0 || failedDerivedMetrics > 0 {
+		err := fmt.Errorf("unable to delete %d alerts, %d dashboards and %d derived metrics from wavefront", failed, failedDashboards, failedDerivedMetrics)
+		alertsConfig.Status.State = alertmanagerv1alpha1.Error
+		alertsConfig.Status.ErrorDescription = err.Error()
+		alertsConfig.Status.RetryCount = alertsConfig.Status.RetryCount + 1
+		r.CommonClient.UpdateStatus(ctx, alertsConfig, alertmanagerv1alpha1.Error)
+		return err
 	}
 
 	// Ok. Lets delete the finalizer so controller can delete the custom object
@@ -370,8 +1036,13 @@ func (r *AlertsConfigReconciler) HandleDelete(ctx context.Context, alertsConfig
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *AlertsConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&alertmanagerv1alpha1.AlertsConfig{}).
-		WithEventFilter(controllercommon.StatusUpdatePredicate{}).
-		Complete(r)
+		Watches(&alertmanagerv1alpha1.WavefrontAlert{}, handler.EnqueueRequestsFromMapFunc(r.alertsConfigsForTemplate)).
+		Watches(&alertmanagerv1alpha1.ClusterWavefrontAlert{}, handler.EnqueueRequestsFromMapFunc(r.alertsConfigsForTemplate)).
+		Watches(&alertmanagerv1alpha1.WavefrontDashboard{}, handler.EnqueueRequestsFromMapFunc(r.alertsConfigsForDashboard)).
+		Watches(&alertmanagerv1alpha1.WavefrontDerivedMetric{}, handler.EnqueueRequestsFromMapFunc(r.alertsConfigsForDerivedMetric)).
+		WithEventFilter(controllercommon.StatusUpdatePredicate{})
+	b = r.Namespaces.Setup(b, &alertmanagerv1alpha1.AlertsConfigList{})
+	return r.Shards.Setup(b, &alertmanagerv1alpha1.AlertsConfigList{}).Complete(r)
 }
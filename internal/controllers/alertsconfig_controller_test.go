@@ -28,6 +28,7 @@ import (
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -66,11 +67,11 @@ var _ = Describe("AlertsConfigController", Label("controller", "alertsconfig"),
 			Name:      wavefrontAlertName,
 			Tags:      []string{"test", "integration"},
 			Severity:  "warn",
-			Condition: "ts(my.metric > 0)",
+			Condition: "ts(my.metric) > 0",
 		}, nil).AnyTimes()
 
 		// Mock UpdateAlert to simulate successful update operations
-		mockWavefront.EXPECT().UpdateAlert(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		mockWavefront.EXPECT().UpdateAlert(gomock.Any(), gomock.Any()).Return([]string{"condition: old -> new"}, nil).AnyTimes()
 	})
 
 	// Test creation of AlertsConfig custom resource
@@ -84,7 +85,7 @@ var _ = Describe("AlertsConfigController", Label("controller", "alertsconfig"),
 				Spec: alertmanagerv1alpha1.WavefrontAlertSpec{
 					AlertType:         "CLASSIC",
 					AlertName:         wavefrontAlertName,
-					Condition:         "ts(my.metric > 0)",
+					Condition:         "ts(my.metric) > 0",
 					DisplayExpression: "ts(my.metric)",
 					Minutes:           ptr(int32(5)),
 					ResolveAfter:      ptr(int32(5)),
@@ -170,7 +171,7 @@ var _ = Describe("AlertsConfigController", Label("controller", "alertsconfig"),
 				Spec: alertmanagerv1alpha1.WavefrontAlertSpec{
 					AlertType:         "CLASSIC",
 					AlertName:         uniqueWfName,
-					Condition:         "ts(my.metric > 0)",
+					Condition:         "ts(my.metric) > 0",
 					DisplayExpression: "ts(my.metric)",
 					Minutes:           ptr(int32(5)),
 					ResolveAfter:      ptr(int32(5)),
@@ -285,7 +286,7 @@ var _ = Describe("AlertsConfigController", Label("controller", "alertsconfig"),
 				Spec: alertmanagerv1alpha1.WavefrontAlertSpec{
 					AlertType:         "CLASSIC",
 					AlertName:         uniqueWfName,
-					Condition:         "ts(my.metric > 0)",
+					Condition:         "ts(my.metric) > 0",
 					DisplayExpression: "ts(my.metric)",
 					Minutes:           ptr(int32(5)),
 					ResolveAfter:      ptr(int32(5)),
@@ -439,7 +440,7 @@ var _ = Describe("AlertsConfigController", Label("controller", "alertsconfig"),
 				Spec: alertmanagerv1alpha1.WavefrontAlertSpec{
 					AlertType:         "CLASSIC",
 					AlertName:         "params-test-alert",
-					Condition:         "ts(my.metric > ${threshold})",
+					Condition:         "ts(my.metric) > {{ .threshold }}",
 					DisplayExpression: "ts(my.metric)",
 					Minutes:           ptr(int32(5)),
 					ResolveAfter:      ptr(int32(5)),
@@ -547,9 +548,91 @@ var _ = Describe("AlertsConfigController", Label("controller", "alertsconfig"),
 			})
 		})
 	})
+
+	Context("When selecting templates with a label selector", Label("selector"), func() {
+		It("Should create and delete alerts as templates join and leave the selection", func() {
+			ctx := context.Background()
+			packLabel := map[string]string{"alert-pack": "standard-http"}
+
+			newTemplate := func(name string) *alertmanagerv1alpha1.WavefrontAlert {
+				return &alertmanagerv1alpha1.WavefrontAlert{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: packLabel},
+					Spec: alertmanagerv1alpha1.WavefrontAlertSpec{
+						AlertType:         "CLASSIC",
+						AlertName:         name + "-{{ .app }}",
+						Condition:         "ts(my.metric) > {{ .threshold }}",
+						DisplayExpression: "ts(my.metric)",
+						Minutes:           ptr(int32(5)),
+						ResolveAfter:      ptr(int32(5)),
+						Severity:          "warn",
+						ExportedParams:    []string{"app", "threshold"},
+						ExportedParamsDefaultValues: map[string]string{
+							"threshold": "80",
+						},
+					},
+				}
+			}
+
+			By("Creating the templates of the alert pack")
+			first := newTemplate("selector-template-1")
+			second := newTemplate("selector-template-2")
+			Expect(k8sClient.Create(ctx, first)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, second)).Should(Succeed())
+
+			By("Creating an AlertsConfig selecting the alert pack")
+			alertsConfig := &alertmanagerv1alpha1.AlertsConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "selector-alerts-config", Namespace: namespace},
+				Spec: alertmanagerv1alpha1.AlertsConfigSpec{
+					TemplateSelector: &metav1.LabelSelector{MatchLabels: packLabel},
+					GlobalParams: map[string]string{
+						"app": "my-app",
+					},
+					Alerts: map[string]alertmanagerv1alpha1.Config{
+						"selector-template-2": {
+							Params: map[string]string{"threshold": "90"},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, alertsConfig)).Should(Succeed())
+
+			acLookupKey := types.NamespacedName{Name: alertsConfig.Name, Namespace: namespace}
+			createdAC := &alertmanagerv1alpha1.AlertsConfig{}
+			Eventually(func() int {
+				if err := k8sClient.Get(ctx, acLookupKey, createdAC); err != nil {
+					return 0
+				}
+				return len(createdAC.Status.AlertsStatus)
+			}, timeout, interval).Should(Equal(2))
+
+			By("Removing a template from the selection")
+			Eventually(func() error {
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: first.Name, Namespace: namespace}, first); err != nil {
+					return err
+				}
+				first.Labels = nil
+				return k8sClient.Update(ctx, first)
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, acLookupKey, createdAC); err != nil {
+					return false
+				}
+				_, ok := createdAC.Status.AlertsStatus[first.Name]
+				return !ok && len(createdAC.Status.AlertsStatus) == 1
+			}, timeout, interval).Should(BeTrue())
+
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, alertsConfig)).Should(Succeed())
+				Expect(k8sClient.Delete(ctx, first)).Should(Succeed())
+				Expect(k8sClient.Delete(ctx, second)).Should(Succeed())
+			})
+		})
+	})
 })
 
-// Helper function to create integer pointers
-func ptr(i int32) *int32 {
-	return &i
+// Helper function to create int or string pointers
+func ptr(i int32) *intstr.IntOrString {
+	v := intstr.FromInt32(i)
+	return &v
 }
@@ -0,0 +1,374 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	controllercommon "github.com/keikoproj/alert-manager/internal/controllers/common"
+	"github.com/keikoproj/alert-manager/internal/utils"
+	"github.com/keikoproj/alert-manager/pkg/audit"
+	"github.com/keikoproj/alert-manager/pkg/log"
+	"github.com/keikoproj/alert-manager/pkg/tracing"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	"go.opentelemetry.io/otel/attribute"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	clusterWavefrontAlertFinalizerName = "clusterwavefrontalert.finalizers.alertmanager.keikoproj.io"
+)
+
+// ClusterWavefrontAlertReconciler reconciles a ClusterWavefrontAlert object
+type ClusterWavefrontAlertReconciler struct {
+	client.Client
+	Log             logr.Logger
+	Scheme          *runtime.Scheme
+	Recorder        record.EventRecorder
+	CommonClient    *controllercommon.Client
+	WavefrontClient wavefront.Interface
+	//DryRun previews the changes for all the cluster wavefront alerts without calling wavefront update or delete APIs
+	DryRun bool
+	//Shards limits the reconciles to the namespaces of the owned shards. nil if sharding is not enabled
+	Shards *ShardManager
+	//Namespaces limits the reconciles to the watched namespaces. nil if all the namespaces are watched
+	Namespaces *NamespaceFilter
+}
+
+//+kubebuilder:rbac:groups=alertmanager.keikoproj.io,resources=clusterwavefrontalerts,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=alertmanager.keikoproj.io,resources=clusterwavefrontalerts/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=alertmanager.keikoproj.io,resources=clusterwavefrontalerts/finalizers,verbs=update
+//+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
+// Reconcile handles the cluster scoped alert templates.
+// Alerts are created by AlertsConfig in each namespace; this controller takes care of propagating the template
+// changes to all the alerts created from it and deleting them when the template is deleted
+func (r *ClusterWavefrontAlertReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	defer func() {
+		if err := recover(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	ctx = context.WithValue(ctx, requestId, uuid.New())
+	ctx, span := startReconcileSpan(ctx, alertmanagerv1alpha1.ClusterWavefrontAlertKind, req)
+	defer span.End()
+	log := log.Logger(ctx, "controllers", "clusterwavefrontalert_controller", "Reconcile")
+	log = log.WithValues("clusterwavefrontalert_cr", req.Name)
+	log.Info("Start of the request")
+
+	// Cluster templates are reconciled by every replica and instance. Each one handles the alerts in its own namespaces
+	var clusterAlert alertmanagerv1alpha1.ClusterWavefrontAlert
+	// reconciled resource is the template of the alerts rolled out below
+	_, templateSpan := tracing.Start(ctx, "fetch template", attribute.String("template", req.Name), attribute.String("kind", alertmanagerv1alpha1.ClusterWavefrontAlertKind))
+	err := r.Get(ctx, req.NamespacedName, &clusterAlert)
+	tracing.End(templateSpan, err)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	// wavefront changes are audited as done by the resource
+	ctx = audit.WithActor(ctx, audit.ActorFor(&clusterAlert, alertmanagerv1alpha1.ClusterWavefrontAlertKind))
+	// Paused resource is left alone, even if it is being deleted, until the annotation is removed
+	if controllercommon.IsPaused(&clusterAlert) {
+		return r.CommonClient.Pause(ctx, &clusterAlert, &clusterAlert.Status.State)
+	}
+
+	// Check if it is delete request
+	if !clusterAlert.ObjectMeta.DeletionTimestamp.IsZero() {
+		requeueFlag := false
+		if err := r.HandleDelete(ctx, &clusterAlert); err != nil {
+			log.Error(err, "unable to delete the alerts")
+			requeueFlag = true
+		}
+		return ctrl.Result{Requeue: requeueFlag}, nil
+	}
+
+	//First time use case
+	if !utils.ContainsString(clusterAlert.ObjectMeta.Finalizers, clusterWavefrontAlertFinalizerName) {
+		log.Info("New cluster wavefront alert resource. Adding the finalizer", "finalizer", clusterWavefrontAlertFinalizerName)
+		clusterAlert.ObjectMeta.Finalizers = append(clusterAlert.ObjectMeta.Finalizers, clusterWavefrontAlertFinalizerName)
+		r.CommonClient.UpdateMeta(ctx, &clusterAlert)
+		return ctrl.Result{}, nil
+	}
+
+	// Alerts are pushed again if requested or once the resource is resumed even if there is no change in the spec
+	request, forced := controllercommon.ReconcileRequested(&clusterAlert, clusterAlert.Status.LastHandledReconcileRequest)
+	resumed := clusterAlert.Status.State == alertmanagerv1alpha1.Paused
+	if clusterAlert.Status.ObservedGeneration == clusterAlert.ObjectMeta.Generation && clusterAlert.Status.State != alertmanagerv1alpha1.Error && clusterAlert.Status.State != alertmanagerv1alpha1.DryRun &&
+		!forced && !resumed {
+		log.Info("There is no change in the spec.. skipping")
+		return ctrl.Result{}, nil
+	}
+	if resumed {
+		log.Info("resource got resumed")
+	}
+	if forced {
+		log.Info("reconcile requested. pushing the alerts again", "request", request)
+	}
+
+	// Cluster templates can only be used with alerts config
+	if len(clusterAlert.Spec.ExportedParams) == 0 {
+		err := errors.New("cluster wavefront alert must have exportedParams")
+		r.Recorder.Event(&clusterAlert, v1.EventTypeWarning, "MalformedSpec", err.Error())
+		return r.patchClusterAlertState(ctx, &clusterAlert, alertmanagerv1alpha1.MalformedSpec, err)
+	}
+
+	if controllercommon.IsDryRun(&clusterAlert, r.DryRun) {
+		return r.previewClusterAlert(ctx, &clusterAlert)
+	}
+
+	// Template spec changed. Update all the alerts created from it in every namespace
+	var summary controllercommon.UpdateSummary
+	for namespace, nsStatus := range clusterAlert.Status.Namespaces {
+		if !r.handlesNamespace(ctx, namespace) {
+			continue
+		}
+		for alertsConfigName, alertStatus := range nsStatus.AlertsStatus {
+			var alertsConfig alertmanagerv1alpha1.AlertsConfig
+			if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: alertsConfigName}, &alertsConfig); err != nil {
+				return r.patchClusterAlertState(ctx, &clusterAlert, alertmanagerv1alpha1.Error, err)
+			}
+			if controllercommon.IsPaused(&alertsConfig) {
+				// alerts config renders all its alerts again once it is resumed
+				log.Info("alerts config is paused. skipping", "namespace", namespace, "alertsConfig", alertsConfigName)
+				continue
+			}
+			alertStatus.DryRun = nil
+			if forced {
+				alertStatus.PayloadHash = ""
+			}
+			var diff []string
+			err := r.CommonClient.CheckNamespaceAllowed(ctx, &clusterAlert, namespace)
+			if err == nil {
+				wfAlert := clusterAlert.AsWavefrontAlert()
+				diff, err = updateIndividualAlert(ctx, r.WavefrontClient, &alertStatus, alertsConfig, wfAlert, false)
+			}
+			if err != nil {
+				r.CommonClient.RecordUpdateSummary(ctx, &clusterAlert, summary)
+				state := alertmanagerv1alpha1.Error
+				if strings.Contains(err.Error(), "Exceeded limit setting") {
+					state = alertmanagerv1alpha1.ClientExceededLimit
+				}
+				alertStatus.State = state
+				alertStatus.ErrorDescription = err.Error()
+				if patchErr := r.CommonClient.PatchClusterWfAlertAndAlertsConfigStatus(ctx, state, &clusterAlert, &alertsConfig, alertStatus, errRequeueTime); patchErr != nil {
+					log.Error(patchErr, "unable to patch cluster wfalert and alertsconfig status objects")
+				}
+				return r.patchClusterAlertState(ctx, &clusterAlert, state, err)
+			}
+			summary.Add(len(diff) > 0)
+			if len(diff) > 0 {
+				r.CommonClient.RecordAlertChange(ctx, controllercommon.AlertOwner(&alertsConfig, alertmanagerv1alpha1.AlertsConfigKind, alertStatus.AssociatedAlert.CR), &alertStatus,
+					controllercommon.NewAlertChange(alertmanagerv1alpha1.AlertUpdate, &clusterAlert, alertmanagerv1alpha1.ClusterWavefrontAlertKind, diff))
+			}
+			alertStatus.State = alertmanagerv1alpha1.Ready
+			alertStatus.ErrorDescription = ""
+			alertStatus.AssociatedAlert.Generation = clusterAlert.ObjectMeta.Generation
+			if err := r.CommonClient.PatchClusterWfAlertAndAlertsConfigStatus(ctx, alertStatus.State, &clusterAlert, &alertsConfig, alertStatus); err != nil {
+				log.Error(err, "unable to patch cluster wfalert and alertsconfig status objects")
+				return r.patchClusterAlertState(ctx, &clusterAlert, alertmanagerv1alpha1.Error, err)
+			}
+		}
+	}
+
+	r.CommonClient.RecordUpdateSummary(ctx, &clusterAlert, summary)
+	if forced {
+		// Request is handled only once all the alerts got pushed so a failed one retries the request
+		clusterAlert.Status.LastHandledReconcileRequest = request
+	}
+	return r.patchClusterAlertState(ctx, &clusterAlert, alertmanagerv1alpha1.ReadyToBeUsed, nil)
+}
+
+// previewClusterAlert function records the dry-run preview of the alerts in every namespace without calling wavefront update API.
+// Only the cluster wavefront alert status is updated, alerts config status is left alone
+func (r *ClusterWavefrontAlertReconciler) previewClusterAlert(ctx context.Context, clusterAlert *alertmanagerv1alpha1.ClusterWavefrontAlert) (ctrl.Result, error) {
+	for namespace, nsStatus := range clusterAlert.Status.Namespaces {
+		if !r.handlesNamespace(ctx, namespace) {
+			continue
+		}
+		for alertsConfigName, alertStatus := range nsStatus.AlertsStatus {
+			var alertsConfig alertmanagerv1alpha1.AlertsConfig
+			if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: alertsConfigName}, &alertsConfig); err != nil {
+				return r.patchClusterAlertState(ctx, clusterAlert, alertmanagerv1alpha1.Error, err)
+			}
+			wfAlert := clusterAlert.AsWavefrontAlert()
+			if _, err := updateIndividualAlert(ctx, r.WavefrontClient, &alertStatus, alertsConfig, wfAlert, true); err != nil {
+				return r.patchClusterAlertState(ctx, clusterAlert, alertmanagerv1alpha1.Error, err)
+			}
+			nsStatus.AlertsStatus[alertsConfigName] = alertStatus
+			r.CommonClient.RecordDryRun(ctx, clusterAlert, alertStatus.Name, alertStatus.DryRun)
+		}
+	}
+	// Observed generation is not updated so the previewed change gets applied once dry-run is turned off
+	clusterAlert.Status.State = alertmanagerv1alpha1.DryRun
+	return r.CommonClient.UpdateStatus(ctx, clusterAlert, alertmanagerv1alpha1.DryRun)
+}
+
+// patchClusterAlertState function patches the top level state of the cluster wavefront alert.
+// Patch is used instead of update so the per namespace status updated by alerts config controller is not overwritten
+func (r *ClusterWavefrontAlertReconciler) patchClusterAlertState(ctx context.Context, clusterAlert *alertmanagerv1alpha1.ClusterWavefrontAlert, state alertmanagerv1alpha1.State, err error) (ctrl.Result, error) {
+	retryCount := 0
+	errDescription := ""
+	if err != nil {
+		log.Logger(ctx, "controllers", "clusterwavefrontalert_controller", "patchClusterAlertState").Error(err, "error occurred in cluster wavefront alert", "clusterWavefrontAlert", clusterAlert.Name)
+		retryCount = clusterAlert.Status.RetryCount + 1
+		errDescription = err.Error()
+	}
+	patch, _ := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"state":                       state,
+			"retryCount":                  retryCount,
+			"errorDescription":            errDescription,
+			"observedGeneration":          clusterAlert.ObjectMeta.Generation,
+			"lastHandledReconcileRequest": nullIfEmpty(clusterAlert.Status.LastHandledReconcileRequest),
+		},
+	})
+	if state == alertmanagerv1alpha1.MalformedSpec {
+		// There is no use of requeue in this case
+		_, patchErr := r.CommonClient.PatchStatus(ctx, clusterAlert, client.RawPatch(types.MergePatchType, patch), state)
+		return ctrl.Result{}, patchErr
+	}
+	return r.CommonClient.PatchStatus(ctx, clusterAlert, client.RawPatch(types.MergePatchType, patch), state, errRequeueTime)
+}
+
+// HandleDelete function deletes all the alerts created from the cluster template in every namespace and removes them from the alerts configs status
+func (r *ClusterWavefrontAlertReconciler) HandleDelete(ctx context.Context, clusterAlert *alertmanagerv1alpha1.ClusterWavefrontAlert) error {
+	log := log.Logger(ctx, "controllers", "clusterwavefrontalert_controller", "HandleDelete")
+	log = log.WithValues("clusterwavefrontalert_cr", clusterAlert.Name)
+	if controllercommon.IsDeletionProtected(clusterAlert) {
+		// Finalizer is kept. Removing the annotation triggers the reconcile again
+		r.CommonClient.RecordDeletionProtected(ctx, clusterAlert)
+		return nil
+	}
+	if controllercommon.IsDryRun(clusterAlert, r.DryRun) {
+		// Finalizer is kept so the alerts get deleted once dry-run is turned off
+		for namespace, nsStatus := range clusterAlert.Status.Namespaces {
+			if !r.handlesNamespace(ctx, namespace) {
+				continue
+			}
+			for alertsConfigName, alert := range nsStatus.AlertsStatus {
+				if alert.ID == "" {
+					continue
+				}
+				alert.DryRun = controllercommon.PreviewDelete()
+				nsStatus.AlertsStatus[alertsConfigName] = alert
+				r.CommonClient.RecordDryRun(ctx, clusterAlert, alert.Name, alert.DryRun)
+			}
+		}
+		clusterAlert.Status.State = alertmanagerv1alpha1.DryRun
+		_, err := r.CommonClient.UpdateStatus(ctx, clusterAlert, alertmanagerv1alpha1.DryRun)
+		return err
+	}
+	failed := 0
+	pending := 0
+	for namespace, nsStatus := range clusterAlert.Status.Namespaces {
+		if !r.handlesNamespace(ctx, namespace) {
+			// Deleted by the instance watching the namespace or the replica owning its shard
+			pending += len(nsStatus.AlertsStatus)
+			continue
+		}
+		for alertsConfigName, alert := range nsStatus.AlertsStatus {
+			policy, protected, err := r.CommonClient.TemplateAlertDeletionPolicy(ctx, namespace, alertsConfigName, clusterAlert.Name, clusterAlert.Spec.DeletionPolicy)
+			if err == nil && protected {
+				err = fmt.Errorf("alerts config %s is deletion protected", alertsConfigName)
+			}
+			if err == nil {
+				err = controllercommon.ApplyDeletionPolicy(ctx, r.WavefrontClient, alert.ID, policy)
+			}
+			if err != nil {
+				// Continue with the other alerts. Failed ones are kept in the status and retried
+				log.Error(err, "unable to delete the alert", "alertID", alert.ID, "namespace", namespace, "alertsConfig", alertsConfigName)
+				alert.State = alertmanagerv1alpha1.Error
+				alert.ErrorDescription = err.Error()
+				nsStatus.AlertsStatus[alertsConfigName] = alert
+				failed++
+				continue
+			}
+			// Alerts config would keep the deleted alert id otherwise. Deletion is retried if the patch fails and it is a no-op for the deleted alert
+			if err := r.CommonClient.RemoveAlertsConfigAlertStatus(ctx, namespace, alertsConfigName, clusterAlert.Name); err != nil {
+				failed++
+				continue
+			}
+			delete(nsStatus.AlertsStatus, alertsConfigName)
+			owner := controllercommon.AlertOwner(&metav1.ObjectMeta{Namespace: namespace, Name: alertsConfigName}, alertmanagerv1alpha1.AlertsConfigKind, alert.AssociatedAlert.CR)
+			r.CommonClient.RecordAlertChange(ctx, owner, nil,
+				controllercommon.NewAlertChange(alertmanagerv1alpha1.AlertDelete, clusterAlert, alertmanagerv1alpha1.ClusterWavefrontAlertKind, nil))
+		}
+		if len(nsStatus.AlertsStatus) == 0 {
+			delete(clusterAlert.Status.Namespaces, namespace)
+		}
+	}
+	if failed > 0 {
+		err := fmt.Errorf("unable to delete %d alerts from wavefront", failed)
+		clusterAlert.Status.State = alertmanagerv1alpha1.Error
+		clusterAlert.Status.ErrorDescription = err.Error()
+		clusterAlert.Status.RetryCount = clusterAlert.Status.RetryCount + 1
+		r.CommonClient.UpdateStatus(ctx, clusterAlert, alertmanagerv1alpha1.Error)
+		return err
+	}
+	if pending > 0 {
+		// Finalizer is removed by the instance deleting the last alerts. Update conflicts if another instance updated
+		// the status in the meantime and the delete gets retried with its changes
+		log.Info("alerts in the namespaces not handled by this replica are not deleted yet. keeping the finalizer", "pending", pending)
+		return r.Status().Update(ctx, clusterAlert)
+	}
+
+	log.Info("Removing finalizer from ClusterWavefrontAlert")
+	clusterAlert.ObjectMeta.Finalizers = utils.RemoveString(clusterAlert.ObjectMeta.Finalizers, clusterWavefrontAlertFinalizerName)
+	r.CommonClient.UpdateMeta(ctx, clusterAlert)
+	log.Info("Successfully deleted cluster wavefront alert")
+	r.Recorder.Event(clusterAlert, v1.EventTypeNormal, "Deleted", "Successfully deleted ClusterWavefrontAlert")
+	return nil
+}
+
+// handlesNamespace function checks if the alerts of the cluster template in the namespace are handled by this replica.
+// Other instances handle the namespaces they watch and the alerts config status in the namespace is not in the cache of this one.
+// Other replicas of the same instance handle the namespaces of the shards they own
+func (r *ClusterWavefrontAlertReconciler) handlesNamespace(ctx context.Context, namespace string) bool {
+	log := log.Logger(ctx, "controllers", "clusterwavefrontalert_controller", "handlesNamespace")
+	if !r.Namespaces.Allows(ctx, namespace) {
+		log.V(1).Info("namespace is not watched by this instance. skipping", "namespace", namespace)
+		return false
+	}
+	if !r.Shards.Owns(ctx, namespace) {
+		log.V(1).Info("namespace belongs to a shard owned by another replica. skipping", "namespace", namespace)
+		return false
+	}
+	return true
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterWavefrontAlertReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&alertmanagerv1alpha1.ClusterWavefrontAlert{}).
+		WithEventFilter(controllercommon.StatusUpdatePredicate{})
+	b = r.Namespaces.Setup(b, &alertmanagerv1alpha1.ClusterWavefrontAlertList{})
+	return r.Shards.Setup(b, &alertmanagerv1alpha1.ClusterWavefrontAlertList{}).Complete(r)
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/keikoproj/alert-manager/internal/utils"
+	"github.com/keikoproj/alert-manager/pkg/log"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IsNamespaceAllowed checks if the namespace can consume the cluster template
+func IsNamespaceAllowed(clusterAlert *alertmanagerv1alpha1.ClusterWavefrontAlert, ns *v1.Namespace) (bool, error) {
+	if len(clusterAlert.Spec.AllowedNamespaces) > 0 && !utils.ContainsString(clusterAlert.Spec.AllowedNamespaces, ns.Name) {
+		return false, nil
+	}
+	if clusterAlert.Spec.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(clusterAlert.Spec.NamespaceSelector)
+		if err != nil {
+			return false, err
+		}
+		if !selector.Matches(labels.Set(ns.Labels)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// CheckNamespaceAllowed returns an error if the namespace is not allowed to consume the cluster template
+func (r *Client) CheckNamespaceAllowed(ctx context.Context, clusterAlert *alertmanagerv1alpha1.ClusterWavefrontAlert, namespace string) error {
+	var ns v1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: namespace}, &ns); err != nil {
+		return err
+	}
+	allowed, err := IsNamespaceAllowed(clusterAlert, &ns)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return fmt.Errorf("namespace %s is not allowed to use cluster wavefront alert %s", namespace, clusterAlert.Name)
+	}
+	return nil
+}
+
+// PatchClusterWfAlertAndAlertsConfigStatus function patches the individual alert status for both cluster wavefront alert and alerts config
+func (r *Client) PatchClusterWfAlertAndAlertsConfigStatus(
+	ctx context.Context,
+	state alertmanagerv1alpha1.State,
+	clusterAlert *alertmanagerv1alpha1.ClusterWavefrontAlert,
+	alertsConfig *alertmanagerv1alpha1.AlertsConfig,
+	alertStatus alertmanagerv1alpha1.AlertStatus,
+	requeueTime ...float64,
+) error {
+	log := log.Logger(ctx, "controllers", "common", "PatchClusterWfAlertAndAlertsConfigStatus")
+	log = log.WithValues("clusterWfAlertCR", clusterAlert.Name, "alertsConfigCR", alertsConfig.Name, "namespace", alertsConfig.Namespace)
+	alertStatus.LastUpdatedTimestamp = metav1.Now()
+	alertStatusBytes, _ := json.Marshal(alertStatus)
+	retryCount := alertsConfig.Status.RetryCount
+	patch := []byte(fmt.Sprintf("{\"status\":{\"state\": \"%s\", \"retryCount\": %d, \"alertsStatus\":{\"%s\":%s}}}", state, retryCount, clusterAlert.Name, string(alertStatusBytes)))
+	if _, err := r.PatchStatus(ctx, alertsConfig, client.RawPatch(types.MergePatchType, patch), state, requeueTime...); err != nil {
+		log.Error(err, "unable to patch the status for alerts config object")
+		return err
+	}
+	clusterPatch := []byte(fmt.Sprintf("{\"status\":{\"namespaces\":{\"%s\":{\"alertsStatus\":{\"%s\":%s}}}}}", alertsConfig.Namespace, alertsConfig.Name, string(alertStatusBytes)))
+	if _, err := r.PatchStatus(ctx, clusterAlert, client.RawPatch(types.MergePatchType, clusterPatch), state, requeueTime...); err != nil {
+		log.Error(err, "unable to patch the status for cluster wavefront alert object")
+		return err
+	}
+	log.Info("alert successfully got updated for both cluster wavefront alert and alerts config objects")
+	r.Recorder.Event(alertsConfig, v1.EventTypeNormal, "Successful", fmt.Sprintf("successfully created/updated an alert name = %s", alertStatus.Name))
+	return nil
+}
+
+// RemoveClusterWfAlertStatus function removes the alerts config entry from the cluster wavefront alert status
+func (r *Client) RemoveClusterWfAlertStatus(ctx context.Context, clusterAlert *alertmanagerv1alpha1.ClusterWavefrontAlert, namespace string, alertsConfigName string) error {
+	log := log.Logger(ctx, "controllers", "common", "RemoveClusterWfAlertStatus")
+	log = log.WithValues("clusterWfAlertCR", clusterAlert.Name, "alertsConfigCR", alertsConfigName, "namespace", namespace)
+	patch := []byte(fmt.Sprintf("{\"status\":{\"namespaces\":{\"%s\":{\"alertsStatus\":{\"%s\":null}}}}}", namespace, alertsConfigName))
+	if len(clusterAlert.Status.Namespaces[namespace].AlertsStatus) <= 1 {
+		// last alerts config in the namespace - remove the namespace entry itself
+		patch = []byte(fmt.Sprintf("{\"status\":{\"namespaces\":{\"%s\":null}}}", namespace))
+	}
+	if _, err := r.PatchStatus(ctx, clusterAlert, client.RawPatch(types.MergePatchType, patch), clusterAlert.Status.State); err != nil {
+		log.Error(err, "unable to patch the status for cluster wavefront alert object")
+		return err
+	}
+	return nil
+}
+
+// RemoveAlertsConfigAlertStatus function removes the alert entry of the template from the alerts config status.
+// Alerts config which is already deleted is ignored
+func (r *Client) RemoveAlertsConfigAlertStatus(ctx context.Context, namespace string, alertsConfigName string, alertName string) error {
+	log := log.Logger(ctx, "controllers", "common", "RemoveAlertsConfigAlertStatus")
+	log = log.WithValues("alertsConfigCR", alertsConfigName, "namespace", namespace, "alert", alertName)
+	alertsConfig := &alertmanagerv1alpha1.AlertsConfig{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: alertsConfigName}}
+	patch, _ := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"alertsStatus": map[string]interface{}{alertName: nil},
+		},
+	})
+	if err := r.Status().Patch(ctx, alertsConfig, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			log.Error(err, "unable to patch the status for alerts config object")
+		}
+		return client.IgnoreNotFound(err)
+	}
+	return nil
+}
@@ -19,15 +19,15 @@ package common
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
-	"github.com/keikoproj/alert-manager/internal/template"
-	"github.com/keikoproj/alert-manager/internal/utils"
 	"github.com/keikoproj/alert-manager/pkg/log"
+	"github.com/keikoproj/alert-manager/pkg/tracing"
 	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"reflect"
@@ -57,6 +57,13 @@ func (StatusUpdatePredicate) Update(e event.UpdateEvent) bool {
 		return false
 	}
 
+	// Reconcile request and pause are honored even if the status got changed in the same update
+	for _, annotation := range []string{alertmanagerv1alpha1.ReconcileRequestAnnotation, alertmanagerv1alpha1.PausedAnnotation} {
+		if e.ObjectOld.GetAnnotations()[annotation] != e.ObjectNew.GetAnnotations()[annotation] {
+			return true
+		}
+	}
+
 	//Better way to do it is to get GVK from ObjectKind but Kind is dropped during decode.
 	//For more details, check the status of the issue here
 	//https://github.com/kubernetes/kubernetes/issues/80609
@@ -76,6 +83,20 @@ func (StatusUpdatePredicate) Update(e event.UpdateEvent) bool {
 		}
 	}
 
+	if oldClusterWFAlertObj, ok := e.ObjectOld.(*alertmanagerv1alpha1.ClusterWavefrontAlert); ok {
+		newClusterWFAlertObj := e.ObjectNew.(*alertmanagerv1alpha1.ClusterWavefrontAlert)
+		if !reflect.DeepEqual(oldClusterWFAlertObj.Status, newClusterWFAlertObj.Status) {
+			return false
+		}
+	}
+
+	if oldWebhookAlertObj, ok := e.ObjectOld.(*alertmanagerv1alpha1.WebhookAlert); ok {
+		newWebhookAlertObj := e.ObjectNew.(*alertmanagerv1alpha1.WebhookAlert)
+		if !reflect.DeepEqual(oldWebhookAlertObj.Status, newWebhookAlertObj.Status) {
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -83,6 +104,10 @@ func (StatusUpdatePredicate) Update(e event.UpdateEvent) bool {
 type Client struct {
 	client.Client
 	Recorder record.EventRecorder
+	//HistoryLimit is the number of changes kept in the alert status history. History is not kept if 0
+	HistoryLimit int
+	//HistoryExportLimit is the number of changes per alert kept in the history config maps. History is not exported if 0
+	HistoryExportLimit int
 }
 
 // UpdateMeta function updates the metadata (mostly finalizers in this case)
@@ -112,7 +137,7 @@ func (r *Client) UpdateStatus(ctx context.Context, obj client.Object, state aler
 
 	//if wait time is specified, requeue it after provided time
 	if len(requeueTime) == 0 {
-		requeueTime[0] = 0
+		requeueTime = []float64{0}
 	}
 
 	log.Info("Requeue time", "time", requeueTime[0])
@@ -135,7 +160,7 @@ func (r *Client) PatchStatus(ctx context.Context, obj client.Object, patch clien
 
 	//if wait time is specified, requeue it after provided time
 	if len(requeueTime) == 0 {
-		requeueTime[0] = 0
+		requeueTime = []float64{0}
 	}
 
 	log.Info("Requeue time", "time", requeueTime[0])
@@ -162,55 +187,10 @@ func (r *Client) ConvertAlertCR(ctx context.Context, wfAlert *alertmanagerv1alph
 }
 
 // GetProcessedWFAlert function converts wavefront alert spec to wavefront api request by processing template with the values provided in alerts config
-func GetProcessedWFAlert(ctx context.Context, wfAlert *alertmanagerv1alpha1.WavefrontAlert, params map[string]string, alert *wf.Alert) error {
-	log := log.Logger(ctx, "controllers", "common", "GetProcessedWFAlert")
-	log = log.WithValues("alertsConfig_cr", wfAlert.Name)
-
-	wfAlertBytes, err := json.Marshal(wfAlert.Spec)
-	if err != nil {
-		// update the status and retry it
-		return err
-	}
-
-	//standalone alert
-	if len(wfAlert.Spec.ExportedParams) == 0 {
-		errMsg := "cannot use standalone alert with alertsconfig. must have exportedParams in wavefrontalert cr"
-		err := errors.New(errMsg)
-		log.Error(err, errMsg)
-		return err
-	}
-
-	// merge wavefront alert default values and alert config map values
-	params = utils.MergeMaps(ctx, wfAlert.Spec.ExportedParamsDefaultValues, params)
-	if err := wavefront.ValidateTemplateParams(ctx, wfAlert.Spec.ExportedParams, params); err != nil {
-		return err
-	}
-
-	// execute Golang Template
-	wfAlertTemplate, err := template.ProcessTemplate(ctx, string(wfAlertBytes), params)
-	if err != nil {
-		//update the status and retry it
-		return err
-	}
-	log.Info("Template process is successful", "here", wfAlertTemplate)
-
-	// Unmarshal back to wavefront alert
-	if err := json.Unmarshal([]byte(wfAlertTemplate), &wfAlert.Spec); err != nil {
-		// update the wfAlert status and retry it
-		return err
-	}
-	// Convert to Alert
-	if err := wavefront.ConvertAlertCRToWavefrontRequest(ctx, wfAlert.Spec, alert); err != nil {
-		errMsg := "unable to convert the wavefront spec to Alert API request. will not be retried"
-		log.Error(err, errMsg)
-		return err
-	}
-
-	// Validate the alert- just make sure severity and other required fields are properly replaced/substituted
-	if err := wavefront.ValidateAlertInput(ctx, alert); err != nil {
-		return err
-	}
-	return nil
+func GetProcessedWFAlert(ctx context.Context, wfAlert *alertmanagerv1alpha1.WavefrontAlert, params map[string]string, alert *wf.Alert) (err error) {
+	ctx, span := tracing.Start(ctx, "render alert", attribute.String("template", wfAlert.Name))
+	defer func() { tracing.End(span, err) }()
+	return wavefront.RenderAlert(ctx, wfAlert, params, alert)
 }
 
 // PatchWfAlertAndAlertsConfigStatus function patches the individual alert status for both wavefront alert and alerts config
@@ -249,3 +229,28 @@ func (r *Client) PatchWfAlertAndAlertsConfigStatus(
 
 	return nil
 }
+
+// UpdateSummary keeps the count of the alerts updated and skipped in a reconcile
+type UpdateSummary struct {
+	Updated int
+	Skipped int
+}
+
+// Add function counts the alert as updated or skipped
+func (s *UpdateSummary) Add(updated bool) {
+	if updated {
+		s.Updated++
+		return
+	}
+	s.Skipped++
+}
+
+// RecordUpdateSummary function reports the updated and skipped alert counts as an event on the object
+func (r *Client) RecordUpdateSummary(ctx context.Context, obj runtime.Object, summary UpdateSummary) {
+	if summary.Updated+summary.Skipped == 0 {
+		return
+	}
+	log := log.Logger(ctx, "controllers.common", "common", "RecordUpdateSummary")
+	log.Info("alerts update summary", "updated", summary.Updated, "skipped", summary.Skipped)
+	r.Recorder.Event(obj, v1.EventTypeNormal, "UpdateSummary", fmt.Sprintf("updated %d alerts, skipped %d unchanged alerts", summary.Updated, summary.Skipped))
+}
@@ -113,7 +113,7 @@ var _ = BeforeSuite(func() {
 		}).AnyTimes()
 
 	mockWavefront.EXPECT().ReadAlert(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
-	mockWavefront.EXPECT().UpdateAlert(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockWavefront.EXPECT().UpdateAlert(gomock.Any(), gomock.Any()).Return([]string{"condition: old -> new"}, nil).AnyTimes()
 	mockWavefront.EXPECT().DeleteAlert(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 	// Create k8s client
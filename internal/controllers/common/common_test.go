@@ -19,6 +19,7 @@ package common_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	wf "github.com/WavefrontHQ/go-wavefront-management-api"
@@ -26,10 +27,13 @@ import (
 	"github.com/keikoproj/alert-manager/internal/controllers/common"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 )
 
 var _ = Describe("Common", func() {
@@ -47,8 +51,8 @@ var _ = Describe("Common", func() {
 		It("should work as expected", func() {
 			By("Creating a new wavefront alert")
 			ctx := context.Background()
-			var minutes int32 = 5
-			var resolveAfterMinutes int32 = 5
+			minutes := intstr.FromInt32(5)
+			resolveAfterMinutes := intstr.FromInt32(5)
 
 			alert := &alertmanagerv1alpha1.WavefrontAlert{
 				TypeMeta: metav1.TypeMeta{
@@ -171,8 +175,8 @@ var _ = Describe("Common", func() {
 					"appName",
 					"condition",
 				},
-				Minutes:           func() *int32 { i := int32(5); return &i }(),
-				ResolveAfter:      func() *int32 { i := int32(5); return &i }(),
+				Minutes:           func() *intstr.IntOrString { i := intstr.FromInt32(5); return &i }(),
+				ResolveAfter:      func() *intstr.IntOrString { i := intstr.FromInt32(5); return &i }(),
 				Severity:          "severe",
 				DisplayExpression: "{{.condition}}",
 			},
@@ -209,5 +213,380 @@ var _ = Describe("Common", func() {
 			err := common.GetProcessedWFAlert(ctx, wfAlert, params, alert)
 			Expect(err).To(HaveOccurred())
 		})
+
+		It("Test with templated numeric and list fields", func() {
+			ctx := context.Background()
+			minutes := intstr.FromString("{{ .minutes }}")
+			checkFrequency := intstr.FromString("{{ .frequency }}")
+			numericAlert := &alertmanagerv1alpha1.WavefrontAlert{
+				Spec: alertmanagerv1alpha1.WavefrontAlertSpec{
+					AlertType:           "CLASSIC",
+					AlertName:           "alert-template-{{.appName}}",
+					Condition:           "ts(status.health)",
+					ExportedParams:      []string{"appName", "minutes", "frequency", "tags"},
+					Minutes:             &minutes,
+					ResolveAfter:        func() *intstr.IntOrString { i := intstr.FromInt32(5); return &i }(),
+					AlertCheckFrequency: &checkFrequency,
+					Severity:            "severe",
+					DisplayExpression:   "ts(status.health)",
+					Tags:                []string{"{{ .appName }}", "{{ .tags }}"},
+				},
+			}
+			params := map[string]string{
+				"appName":   "test",
+				"minutes":   "15",
+				"frequency": "2",
+				"tags":      "http,latency",
+			}
+			alert := &wf.Alert{}
+			err := common.GetProcessedWFAlert(ctx, numericAlert, params, alert)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(alert.Minutes).To(Equal(15))
+			Expect(alert.CheckingFrequencyInMinutes).To(Equal(2))
+			Expect(alert.Tags).To(Equal([]string{"test", "http", "latency"}))
+		})
+	})
+	Context("IsNamespaceAllowed test cases", func() {
+		ns := &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "team-a",
+				Labels: map[string]string{"tier": "prod"},
+			},
+		}
+
+		It("should allow every namespace when there are no restrictions", func() {
+			allowed, err := common.IsNamespaceAllowed(&alertmanagerv1alpha1.ClusterWavefrontAlert{}, ns)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(allowed).To(BeTrue())
+		})
+
+		It("should check allowedNamespaces", func() {
+			clusterAlert := &alertmanagerv1alpha1.ClusterWavefrontAlert{
+				Spec: alertmanagerv1alpha1.ClusterWavefrontAlertSpec{AllowedNamespaces: []string{"team-b"}},
+			}
+			allowed, err := common.IsNamespaceAllowed(clusterAlert, ns)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(allowed).To(BeFalse())
+
+			clusterAlert.Spec.AllowedNamespaces = append(clusterAlert.Spec.AllowedNamespaces, "team-a")
+			allowed, err = common.IsNamespaceAllowed(clusterAlert, ns)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(allowed).To(BeTrue())
+		})
+
+		It("should check namespaceSelector", func() {
+			clusterAlert := &alertmanagerv1alpha1.ClusterWavefrontAlert{
+				Spec: alertmanagerv1alpha1.ClusterWavefrontAlertSpec{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "dev"}},
+				},
+			}
+			allowed, err := common.IsNamespaceAllowed(clusterAlert, ns)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(allowed).To(BeFalse())
+
+			clusterAlert.Spec.NamespaceSelector.MatchLabels["tier"] = "prod"
+			allowed, err = common.IsNamespaceAllowed(clusterAlert, ns)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(allowed).To(BeTrue())
+		})
+	})
+
+	Context("RemoveAlertsConfigAlertStatus test cases", func() {
+		It("should remove only the alert entry of the template", func() {
+			ctx := context.Background()
+			commonClient := common.Client{Client: k8sClient, Recorder: record.NewFakeRecorder(10)}
+
+			alertsConfig := &alertmanagerv1alpha1.AlertsConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-template-consumer", Namespace: alertNamespace},
+			}
+			Expect(k8sClient.Create(ctx, alertsConfig)).Should(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, alertsConfig)).Should(Succeed())
+			})
+			statusPatch := []byte(`{"status":{"retryCount":0,"alertsStatus":{"cluster-cpu":{"id":"1","alertName":"cpu","errorDescription":""},"memory":{"id":"2","alertName":"memory","errorDescription":""}}}}`)
+			Expect(k8sClient.Status().Patch(ctx, alertsConfig, client.RawPatch(types.MergePatchType, statusPatch))).To(Succeed())
+
+			Expect(commonClient.RemoveAlertsConfigAlertStatus(ctx, alertNamespace, alertsConfig.Name, "cluster-cpu")).To(Succeed())
+			updated := &alertmanagerv1alpha1.AlertsConfig{}
+			Eventually(func() map[string]alertmanagerv1alpha1.AlertStatus {
+				k8sClient.Get(ctx, types.NamespacedName{Name: alertsConfig.Name, Namespace: alertNamespace}, updated)
+				return updated.Status.AlertsStatus
+			}, timeout, interval).Should(And(HaveKey("memory"), Not(HaveKey("cluster-cpu"))))
+
+			By("Ignoring the alerts config which is already deleted")
+			Expect(commonClient.RemoveAlertsConfigAlertStatus(ctx, alertNamespace, "deleted-alerts-config", "cluster-cpu")).To(Succeed())
+		})
+	})
+
+	Context("TemplateAlertDeletionPolicy test cases", func() {
+		It("should resolve the policy from the alert entry, alerts config and template in order", func() {
+			ctx := context.Background()
+			commonClient := common.Client{Client: k8sClient, Recorder: record.NewFakeRecorder(10)}
+
+			alertsConfig := &alertmanagerv1alpha1.AlertsConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "template-deletion-policy", Namespace: alertNamespace},
+				Spec: alertmanagerv1alpha1.AlertsConfigSpec{
+					DeletionPolicy: alertmanagerv1alpha1.DeletionPolicySnooze,
+					Alerts: map[string]alertmanagerv1alpha1.Config{
+						"retained": {DeletionPolicy: alertmanagerv1alpha1.DeletionPolicyRetain},
+						"default":  {},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, alertsConfig)).Should(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, alertsConfig)).Should(Succeed())
+			})
+
+			policy, protected, err := commonClient.TemplateAlertDeletionPolicy(ctx, alertNamespace, alertsConfig.Name, "retained", alertmanagerv1alpha1.DeletionPolicyDelete)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(protected).To(BeFalse())
+			Expect(policy).To(Equal(alertmanagerv1alpha1.DeletionPolicyRetain))
+
+			policy, _, err = commonClient.TemplateAlertDeletionPolicy(ctx, alertNamespace, alertsConfig.Name, "default", alertmanagerv1alpha1.DeletionPolicyDelete)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policy).To(Equal(alertmanagerv1alpha1.DeletionPolicySnooze))
+
+			By("Falling back to the template policy if the alerts config is already deleted")
+			policy, protected, err = commonClient.TemplateAlertDeletionPolicy(ctx, alertNamespace, "deleted-alerts-config", "default", alertmanagerv1alpha1.DeletionPolicyRetain)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(protected).To(BeFalse())
+			Expect(policy).To(Equal(alertmanagerv1alpha1.DeletionPolicyRetain))
+
+			By("Protecting the alerts of the deletion protected alerts config")
+			alertsConfig.Annotations = map[string]string{alertmanagerv1alpha1.DeletionProtectionAnnotation: "true"}
+			Expect(k8sClient.Update(ctx, alertsConfig)).Should(Succeed())
+			Eventually(func() bool {
+				_, protected, _ := commonClient.TemplateAlertDeletionPolicy(ctx, alertNamespace, alertsConfig.Name, "default", "")
+				return protected
+			}, timeout, interval).Should(BeTrue())
+			alertsConfig.Annotations = nil
+			Expect(k8sClient.Update(ctx, alertsConfig)).Should(Succeed())
+		})
+	})
+
+	Context("IsDryRun test cases", func() {
+		It("Test with annotation", func() {
+			alert := &alertmanagerv1alpha1.WavefrontAlert{}
+			Expect(common.IsDryRun(alert, false)).To(BeFalse())
+
+			alert.Annotations = map[string]string{alertmanagerv1alpha1.DryRunAnnotation: "true"}
+			Expect(common.IsDryRun(alert, false)).To(BeTrue())
+
+			alert.Annotations[alertmanagerv1alpha1.DryRunAnnotation] = "false"
+			Expect(common.IsDryRun(alert, false)).To(BeFalse())
+		})
+
+		It("Test with global dry-run", func() {
+			alert := &alertmanagerv1alpha1.WavefrontAlert{}
+			Expect(common.IsDryRun(alert, true)).To(BeTrue())
+		})
+	})
+
+	Context("Reconcile request and pause test cases", func() {
+		It("Test with paused annotation", func() {
+			alert := &alertmanagerv1alpha1.WavefrontAlert{}
+			Expect(common.IsPaused(alert)).To(BeFalse())
+
+			alert.Annotations = map[string]string{alertmanagerv1alpha1.PausedAnnotation: "true"}
+			Expect(common.IsPaused(alert)).To(BeTrue())
+		})
+
+		It("Test with reconcile request annotation", func() {
+			alert := &alertmanagerv1alpha1.AlertsConfig{}
+			_, forced := common.ReconcileRequested(alert, "")
+			Expect(forced).To(BeFalse())
+
+			alert.Annotations = map[string]string{alertmanagerv1alpha1.ReconcileRequestAnnotation: "2025-01-01T00:00:00Z"}
+			request, forced := common.ReconcileRequested(alert, "")
+			Expect(forced).To(BeTrue())
+			Expect(request).To(Equal("2025-01-01T00:00:00Z"))
+
+			_, forced = common.ReconcileRequested(alert, "2025-01-01T00:00:00Z")
+			Expect(forced).To(BeFalse())
+		})
+
+		It("Test payload hashes are reset", func() {
+			alertsStatus := map[string]alertmanagerv1alpha1.AlertStatus{"a": {ID: "1", PayloadHash: "abc"}}
+			common.ResetPayloadHashes(alertsStatus)
+			Expect(alertsStatus["a"].PayloadHash).To(BeEmpty())
+			Expect(alertsStatus["a"].ID).To(Equal("1"))
+		})
+
+		It("Test annotation changes pass the status update predicate", func() {
+			oldAlert := &alertmanagerv1alpha1.WavefrontAlert{}
+			newAlert := oldAlert.DeepCopy()
+			newAlert.Status.State = alertmanagerv1alpha1.Ready
+			Expect(common.StatusUpdatePredicate{}.Update(event.UpdateEvent{ObjectOld: oldAlert, ObjectNew: newAlert})).To(BeFalse())
+
+			newAlert.Annotations = map[string]string{alertmanagerv1alpha1.ReconcileRequestAnnotation: "1"}
+			Expect(common.StatusUpdatePredicate{}.Update(event.UpdateEvent{ObjectOld: oldAlert, ObjectNew: newAlert})).To(BeTrue())
+
+			newAlert.Annotations = map[string]string{alertmanagerv1alpha1.PausedAnnotation: "true"}
+			Expect(common.StatusUpdatePredicate{}.Update(event.UpdateEvent{ObjectOld: oldAlert, ObjectNew: newAlert})).To(BeTrue())
+		})
+	})
+
+	Context("Rollout test cases", func() {
+		It("Test with no rollout strategy", func() {
+			rollout := common.NewRollout(2, []string{"c", "a", "b"})
+			Expect(common.NextRolloutBatch(rollout, nil)).To(Equal([]string{"a", "b", "c"}))
+		})
+
+		It("Test with canaries and batch size", func() {
+			strategy := &alertmanagerv1alpha1.RolloutStrategy{BatchSize: 2, Canaries: []string{"d", "missing"}}
+			rollout := common.NewRollout(2, []string{"a", "b", "c", "d"})
+
+			batch := common.NextRolloutBatch(rollout, strategy)
+			Expect(batch).To(Equal([]string{"d"}))
+			rollout.AlertsConfigs["d"] = alertmanagerv1alpha1.RolloutUpdated
+
+			batch = common.NextRolloutBatch(rollout, strategy)
+			Expect(batch).To(Equal([]string{"a", "b"}))
+			rollout.AlertsConfigs["a"] = alertmanagerv1alpha1.RolloutUpdated
+			rollout.AlertsConfigs["b"] = alertmanagerv1alpha1.RolloutFailed
+
+			Expect(common.NextRolloutBatch(rollout, strategy)).To(Equal([]string{"c"}))
+			Expect(common.CountRollout(rollout, alertmanagerv1alpha1.RolloutUpdated)).To(Equal(2))
+		})
+
+		It("Test with max failures", func() {
+			maxFailures := 1
+			strategy := &alertmanagerv1alpha1.RolloutStrategy{MaxFailures: &maxFailures}
+			rollout := common.NewRollout(2, []string{"a", "b", "c"})
+			rollout.AlertsConfigs["a"] = alertmanagerv1alpha1.RolloutFailed
+			Expect(common.ExceedsMaxFailures(rollout, strategy)).To(BeFalse())
+			Expect(common.ExceedsMaxFailures(rollout, nil)).To(BeFalse())
+
+			rollout.AlertsConfigs["b"] = alertmanagerv1alpha1.RolloutFailed
+			Expect(common.ExceedsMaxFailures(rollout, strategy)).To(BeTrue())
+		})
+
+		It("Test unfinished rollout", func() {
+			Expect(common.RolloutUnfinished(nil)).To(BeFalse())
+			rollout := common.NewRollout(2, []string{"a"})
+			Expect(common.RolloutUnfinished(rollout)).To(BeTrue())
+			rollout.Phase = alertmanagerv1alpha1.RolloutHalted
+			Expect(common.RolloutUnfinished(rollout)).To(BeFalse())
+		})
+	})
+
+	Context("Deletion policy test cases", func() {
+		It("Test deletion policy precedence", func() {
+			Expect(common.GetDeletionPolicy()).To(Equal(alertmanagerv1alpha1.DeletionPolicyDelete))
+			Expect(common.GetDeletionPolicy("", alertmanagerv1alpha1.DeletionPolicyRetain)).To(Equal(alertmanagerv1alpha1.DeletionPolicyRetain))
+			Expect(common.GetDeletionPolicy(alertmanagerv1alpha1.DeletionPolicySnooze, alertmanagerv1alpha1.DeletionPolicyRetain)).To(Equal(alertmanagerv1alpha1.DeletionPolicySnooze))
+		})
+
+		It("Test with deletion protection annotation", func() {
+			alertsConfig := &alertmanagerv1alpha1.AlertsConfig{}
+			Expect(common.IsDeletionProtected(alertsConfig)).To(BeFalse())
+
+			alertsConfig.Annotations = map[string]string{alertmanagerv1alpha1.DeletionProtectionAnnotation: "true"}
+			Expect(common.IsDeletionProtected(alertsConfig)).To(BeTrue())
+		})
+	})
+
+	Context("Dashboard test cases", func() {
+		It("Test dashboard links are sorted and added once", func() {
+			links := common.DashboardLinks(map[string]alertmanagerv1alpha1.DashboardStatus{
+				"latency": {Name: "latency", Link: "https://wavefront/dashboards/latency"},
+				"errors":  {Name: "errors", Link: "https://wavefront/dashboards/errors"},
+				"pending": {Name: "pending"},
+			})
+			Expect(links).To(Equal("Dashboard errors: https://wavefront/dashboards/errors\nDashboard latency: https://wavefront/dashboards/latency"))
+
+			alert := wf.Alert{AdditionalInfo: "runbook"}
+			common.AddDashboardLinks(&alert, links)
+			common.AddDashboardLinks(&alert, links)
+			Expect(alert.AdditionalInfo).To(Equal("runbook\n" + links))
+
+			alert = wf.Alert{}
+			common.AddDashboardLinks(&alert, "")
+			Expect(alert.AdditionalInfo).To(BeEmpty())
+		})
+
+		It("Test dashboard is retained as per the deletion policy", func() {
+			// wavefront client is not called
+			Expect(common.DeleteDashboard(context.Background(), nil, "id", alertmanagerv1alpha1.DeletionPolicyRetain)).To(Succeed())
+			Expect(common.DeleteDashboard(context.Background(), nil, "id", alertmanagerv1alpha1.DeletionPolicySnooze)).To(Succeed())
+			Expect(common.DeleteDashboard(context.Background(), nil, "", alertmanagerv1alpha1.DeletionPolicyDelete)).To(Succeed())
+		})
+	})
+
+	Context("Derived metric test cases", func() {
+		It("Test derived metric is retained as per the deletion policy", func() {
+			// wavefront client is not called
+			Expect(common.DeleteDerivedMetric(context.Background(), nil, "id", alertmanagerv1alpha1.DeletionPolicyRetain)).To(Succeed())
+			Expect(common.DeleteDerivedMetric(context.Background(), nil, "id", alertmanagerv1alpha1.DeletionPolicySnooze)).To(Succeed())
+			Expect(common.DeleteDerivedMetric(context.Background(), nil, "", alertmanagerv1alpha1.DeletionPolicyDelete)).To(Succeed())
+		})
+	})
+
+	Context("Sharding test cases", func() {
+		It("Test namespace is always in the same shard", func() {
+			shard := common.ShardForNamespace("team-a", 4)
+			Expect(shard).To(BeNumerically(">=", 0))
+			Expect(shard).To(BeNumerically("<", 4))
+			Expect(common.ShardForNamespace("team-a", 4)).To(Equal(shard))
+			Expect(common.ShardForNamespace("", 4)).To(Equal(0))
+			Expect(common.ShardForNamespace("team-a", 1)).To(Equal(0))
+		})
+
+		It("Test shard from namespace label", func() {
+			shard, ok := common.ShardFromLabel(" 2 ", 4)
+			Expect(ok).To(BeTrue())
+			Expect(shard).To(Equal(2))
+			_, ok = common.ShardFromLabel("4", 4)
+			Expect(ok).To(BeFalse())
+			_, ok = common.ShardFromLabel("team-a", 4)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("Test fair share of the shards", func() {
+			Expect(common.FairShare(8, 0)).To(Equal(8))
+			Expect(common.FairShare(8, 3)).To(Equal(3))
+			Expect(common.FairShare(8, 4)).To(Equal(2))
+			Expect(common.FairShare(2, 4)).To(Equal(1))
+		})
+	})
+
+	Context("Alert history test cases", func() {
+		It("Test history keeps only the latest changes", func() {
+			trigger := &alertmanagerv1alpha1.WavefrontAlert{ObjectMeta: metav1.ObjectMeta{Name: "test-alert", Generation: 2}}
+			change := common.NewAlertChange(alertmanagerv1alpha1.AlertUpdate, trigger, "WavefrontAlert", []string{"minutes: 5 -> 10"})
+			Expect(change.TriggeredBy).To(Equal("WavefrontAlert/test-alert"))
+			Expect(change.Generation).To(Equal(int64(2)))
+
+			var history []alertmanagerv1alpha1.AlertChange
+			for i := 0; i < 3; i++ {
+				history = common.AppendAlertChange(history, change, 2)
+			}
+			Expect(history).To(HaveLen(2))
+			Expect(common.AppendAlertChange(history, change, 0)).To(BeNil())
+		})
+
+		It("Test create operation", func() {
+			Expect(common.CreateOperation(alertmanagerv1alpha1.AlertStatus{})).To(Equal(alertmanagerv1alpha1.AlertCreate))
+			Expect(common.CreateOperation(alertmanagerv1alpha1.AlertStatus{PayloadHash: "abc"})).To(Equal(alertmanagerv1alpha1.AlertRecreate))
+		})
+
+		It("Test history config map name and key", func() {
+			Expect(common.HistoryConfigMapName("WavefrontAlert", "test-alert")).To(Equal("alert-history-wavefrontalert-test-alert"))
+			Expect(len(common.HistoryConfigMapName("AlertsConfig", strings.Repeat("a", 300)))).To(Equal(253))
+			Expect(common.HistoryConfigMapKey("team a/latency")).To(Equal("team_a_latency"))
+			Expect(common.HistoryConfigMapKey("")).To(Equal("_"))
+		})
+
+		It("Test history lines are trimmed", func() {
+			history := common.AppendHistoryLine("", "1", 2)
+			history = common.AppendHistoryLine(history, "2", 2)
+			history = common.AppendHistoryLine(history, "3", 2)
+			Expect(history).To(Equal("2\n3"))
+
+			data := map[string]string{"a": "1\n2\n3", "b": "4"}
+			common.TrimHistoryData(data, 6)
+			Expect(data).To(Equal(map[string]string{"a": "2\n3", "b": "4"}))
+		})
 	})
 })
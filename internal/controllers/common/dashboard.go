@@ -0,0 +1,130 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/keikoproj/alert-manager/internal/config"
+	"github.com/keikoproj/alert-manager/internal/utils"
+	"github.com/keikoproj/alert-manager/pkg/log"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DashboardLink function returns the link to the dashboard in wavefront
+func DashboardLink(dashboardID string) string {
+	return fmt.Sprintf("https://%s/dashboards/%s", config.Props.WavefrontAPIUrl(), dashboardID)
+}
+
+// DashboardLinks function returns the links to the dashboards in the status, one per line and sorted by the key, so they can be added to the alerts
+func DashboardLinks(dashboardsStatus map[string]alertmanagerv1alpha1.DashboardStatus) string {
+	keys := make([]string, 0, len(dashboardsStatus))
+	for key, dashboard := range dashboardsStatus {
+		if dashboard.Link != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		dashboard := dashboardsStatus[key]
+		lines = append(lines, fmt.Sprintf("Dashboard %s: %s", dashboard.Name, dashboard.Link))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// AddDashboardLinks function adds the dashboard links to the additional information of the alert unless they are already there
+func AddDashboardLinks(alert *wf.Alert, links string) {
+	if links == "" || strings.Contains(alert.AdditionalInfo, links) {
+		return
+	}
+	if alert.AdditionalInfo == "" {
+		alert.AdditionalInfo = links
+		return
+	}
+	alert.AdditionalInfo = alert.AdditionalInfo + "\n" + links
+}
+
+// ApplyDashboard function creates or updates the rendered dashboard in wavefront unless it is the same as the last applied one.
+// Dashboard created with the previous url is deleted once the dashboard with the new url got created. Returns the new status of the dashboard
+func ApplyDashboard(ctx context.Context, wavefrontClient wavefront.Interface, status alertmanagerv1alpha1.DashboardStatus, dashboard *wf.Dashboard, forced bool) (alertmanagerv1alpha1.DashboardStatus, error) {
+	log := log.Logger(ctx, "controllers.common", "dashboard", "ApplyDashboard")
+	log = log.WithValues("dashboardID", dashboard.ID)
+
+	data, err := json.Marshal(dashboard)
+	if err != nil {
+		return status, err
+	}
+	checksum := utils.CalculateChecksum(ctx, string(data))
+	if !forced && status.ID == dashboard.ID && status.LastChangeChecksum == checksum && status.State == alertmanagerv1alpha1.Ready {
+		log.V(1).Info("rendered dashboard is same as the last applied one. skipping")
+		return status, nil
+	}
+
+	if status.ID == dashboard.ID {
+		err = wavefrontClient.UpdateDashboard(ctx, dashboard)
+		if wf.NotFound(err) {
+			log.Info("dashboard doesn't exist in wavefront, so creating it again")
+			err = wavefrontClient.CreateDashboard(ctx, dashboard)
+		}
+	} else {
+		err = wavefrontClient.CreateDashboard(ctx, dashboard)
+		if err == nil && status.ID != "" {
+			log.Info("dashboard url got changed. deleting the dashboard with the previous url", "previousID", status.ID)
+			if err := wavefrontClient.DeleteDashboard(ctx, status.ID); err != nil {
+				// Dashboard with the new url is already there so it is not retried
+				log.Error(err, "unable to delete the dashboard with the previous url", "previousID", status.ID)
+			}
+		}
+	}
+	if err != nil {
+		status.State = alertmanagerv1alpha1.Error
+		status.ErrorDescription = err.Error()
+		return status, err
+	}
+	log.Info("dashboard successfully got applied")
+	status.ID = dashboard.ID
+	status.Name = dashboard.Name
+	status.Link = DashboardLink(dashboard.ID)
+	status.State = alertmanagerv1alpha1.Ready
+	status.ErrorDescription = ""
+	status.LastChangeChecksum = checksum
+	status.LastUpdatedTimestamp = metav1.Now()
+	return status, nil
+}
+
+// DeleteDashboard function deletes the wavefront dashboard unless the deletion policy retains it.
+// Snooze policy retains the dashboard since there is nothing to snooze
+func DeleteDashboard(ctx context.Context, wavefrontClient wavefront.Interface, dashboardID string, policy alertmanagerv1alpha1.DeletionPolicy) error {
+	log := log.Logger(ctx, "controllers.common", "dashboard", "DeleteDashboard")
+	log = log.WithValues("dashboardID", dashboardID, "deletionPolicy", policy)
+	if dashboardID == "" {
+		return nil
+	}
+	if policy != alertmanagerv1alpha1.DeletionPolicyDelete {
+		log.Info("retaining the dashboard in wavefront")
+		return nil
+	}
+	return wavefrontClient.DeleteDashboard(ctx, dashboardID)
+}
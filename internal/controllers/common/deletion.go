@@ -0,0 +1,109 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"strconv"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/keikoproj/alert-manager/pkg/log"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IsDeletionProtected checks if the object has the deletion protection annotation
+func IsDeletionProtected(obj metav1.Object) bool {
+	protected, _ := strconv.ParseBool(obj.GetAnnotations()[alertmanagerv1alpha1.DeletionProtectionAnnotation])
+	return protected
+}
+
+// RecordDeletionProtected function records the deletion being blocked as an event on the object
+func (r *Client) RecordDeletionProtected(ctx context.Context, obj runtime.Object) {
+	log := log.Logger(ctx, "controllers.common", "deletion", "RecordDeletionProtected")
+	log.Info("deletion is blocked by the deletion protection annotation")
+	r.Recorder.Event(obj, v1.EventTypeWarning, "DeletionProtected", "deletion is blocked. remove the "+alertmanagerv1alpha1.DeletionProtectionAnnotation+" annotation to delete")
+}
+
+// GetDeletionPolicy function returns the first deletion policy provided in the order of precedence. Defaults to Delete
+func GetDeletionPolicy(policies ...alertmanagerv1alpha1.DeletionPolicy) alertmanagerv1alpha1.DeletionPolicy {
+	for _, policy := range policies {
+		if policy != "" {
+			return policy
+		}
+	}
+	return alertmanagerv1alpha1.DeletionPolicyDelete
+}
+
+// TemplateAlertDeletionPolicy function returns the deletion policy of the alert rendered by the alerts config when its template is deleted.
+// Alert entry policy overwrites the alerts config one which overwrites the template one, same as when the alerts config is deleted.
+// Alert is protected if the alerts config has the deletion protection annotation. Alerts config which is already deleted is ignored
+func (r *Client) TemplateAlertDeletionPolicy(ctx context.Context, namespace string, alertsConfigName string, templateName string, templatePolicy alertmanagerv1alpha1.DeletionPolicy) (alertmanagerv1alpha1.DeletionPolicy, bool, error) {
+	var alertsConfig alertmanagerv1alpha1.AlertsConfig
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: alertsConfigName}, &alertsConfig); err != nil {
+		return GetDeletionPolicy(templatePolicy), false, client.IgnoreNotFound(err)
+	}
+	if IsDeletionProtected(&alertsConfig) {
+		r.RecordDeletionProtected(ctx, &alertsConfig)
+		return "", true, nil
+	}
+	return GetDeletionPolicy(alertsConfig.Spec.Alerts[templateName].DeletionPolicy, alertsConfig.Spec.DeletionPolicy, templatePolicy), false, nil
+}
+
+// ApplyDeletionPolicy function deletes, snoozes or retains the wavefront alert as per the deletion policy.
+// Ownership tags are removed from the retained and snoozed alerts so the orphan alert collector leaves them alone
+func ApplyDeletionPolicy(ctx context.Context, wavefrontClient wavefront.Interface, alertID string, policy alertmanagerv1alpha1.DeletionPolicy) error {
+	log := log.Logger(ctx, "controllers.common", "deletion", "ApplyDeletionPolicy")
+	log = log.WithValues("alertID", alertID, "deletionPolicy", policy)
+	if alertID == "" {
+		return nil
+	}
+	switch policy {
+	case alertmanagerv1alpha1.DeletionPolicyRetain:
+		log.Info("retaining the alert in wavefront")
+		return disownAlert(ctx, wavefrontClient, alertID)
+	case alertmanagerv1alpha1.DeletionPolicySnooze:
+		log.Info("snoozing the alert in wavefront")
+		if err := disownAlert(ctx, wavefrontClient, alertID); err != nil {
+			return err
+		}
+		return wavefrontClient.SnoozeAlert(ctx, alertID)
+	default:
+		return wavefrontClient.DeleteAlert(ctx, alertID)
+	}
+}
+
+// disownAlert function removes the ownership tags from the wavefront alert
+func disownAlert(ctx context.Context, wavefrontClient wavefront.Interface, alertID string) error {
+	alert, err := wavefrontClient.ReadAlert(ctx, alertID)
+	if wf.NotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !wavefront.RemoveOwnershipTags(alert) {
+		return nil
+	}
+	_, err = wavefrontClient.UpdateAlert(ctx, alert)
+	return err
+}
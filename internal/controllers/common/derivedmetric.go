@@ -0,0 +1,90 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/keikoproj/alert-manager/internal/utils"
+	"github.com/keikoproj/alert-manager/pkg/log"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApplyDerivedMetric function creates or updates the rendered derived metric in wavefront unless it is the same as the last applied one.
+// Derived metric is created again if it doesn't exist in wavefront anymore. Returns the new status of the derived metric
+func ApplyDerivedMetric(ctx context.Context, wavefrontClient wavefront.Interface, status alertmanagerv1alpha1.DerivedMetricStatus, metric *wf.DerivedMetric, forced bool) (alertmanagerv1alpha1.DerivedMetricStatus, error) {
+	log := log.Logger(ctx, "controllers.common", "derivedmetric", "ApplyDerivedMetric")
+	log = log.WithValues("derivedMetricID", status.ID)
+
+	// checksum is calculated before the id is set so it only covers the rendered spec
+	data, err := json.Marshal(metric)
+	if err != nil {
+		return status, err
+	}
+	checksum := utils.CalculateChecksum(ctx, string(data))
+	if !forced && status.ID != "" && status.LastChangeChecksum == checksum && status.State == alertmanagerv1alpha1.Ready {
+		log.V(1).Info("rendered derived metric is same as the last applied one. skipping")
+		return status, nil
+	}
+
+	if status.ID != "" {
+		id := status.ID
+		metric.ID = &id
+		err = wavefrontClient.UpdateDerivedMetric(ctx, metric)
+		if wf.NotFound(err) {
+			log.Info("derived metric doesn't exist in wavefront, so creating it again")
+			metric.ID = nil
+			err = wavefrontClient.CreateDerivedMetric(ctx, metric)
+		}
+	} else {
+		err = wavefrontClient.CreateDerivedMetric(ctx, metric)
+	}
+	if err != nil {
+		status.State = alertmanagerv1alpha1.Error
+		status.ErrorDescription = err.Error()
+		return status, err
+	}
+	if metric.ID != nil {
+		status.ID = *metric.ID
+	}
+	log.Info("derived metric successfully got applied", "derivedMetricID", status.ID)
+	status.Name = metric.Name
+	status.State = alertmanagerv1alpha1.Ready
+	status.ErrorDescription = ""
+	status.LastChangeChecksum = checksum
+	status.LastUpdatedTimestamp = metav1.Now()
+	return status, nil
+}
+
+// DeleteDerivedMetric function deletes the wavefront derived metric unless the deletion policy retains it.
+// Snooze policy retains the derived metric since there is nothing to snooze
+func DeleteDerivedMetric(ctx context.Context, wavefrontClient wavefront.Interface, derivedMetricID string, policy alertmanagerv1alpha1.DeletionPolicy) error {
+	log := log.Logger(ctx, "controllers.common", "derivedmetric", "DeleteDerivedMetric")
+	log = log.WithValues("derivedMetricID", derivedMetricID, "deletionPolicy", policy)
+	if derivedMetricID == "" {
+		return nil
+	}
+	if policy != alertmanagerv1alpha1.DeletionPolicyDelete {
+		log.Info("retaining the derived metric in wavefront")
+		return nil
+	}
+	return wavefrontClient.DeleteDerivedMetric(ctx, derivedMetricID)
+}
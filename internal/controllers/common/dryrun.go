@@ -0,0 +1,95 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/keikoproj/alert-manager/pkg/log"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// IsDryRun checks if the changes for the object must only be previewed.
+// Either the manager runs in dry-run mode or the object has the dry-run annotation
+func IsDryRun(obj metav1.Object, globalDryRun bool) bool {
+	if globalDryRun {
+		return true
+	}
+	dryRun, _ := strconv.ParseBool(obj.GetAnnotations()[alertmanagerv1alpha1.DryRunAnnotation])
+	return dryRun
+}
+
+// PreviewAlert function builds the dry-run preview for the rendered alert without changing anything in wavefront.
+// If the alert already exists, live alert is read to compute the diff
+func PreviewAlert(ctx context.Context, wavefrontClient wavefront.Interface, alert *wf.Alert) (*alertmanagerv1alpha1.DryRunStatus, error) {
+	preview := &alertmanagerv1alpha1.DryRunStatus{
+		Operation:   alertmanagerv1alpha1.DryRunCreate,
+		PayloadHash: wavefront.PayloadHash(ctx, alert),
+		Timestamp:   metav1.Now(),
+	}
+	if alert.ID == nil || *alert.ID == "" {
+		return preview, nil
+	}
+	live, err := wavefrontClient.ReadAlert(ctx, *alert.ID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the live alert %s: %w", *alert.ID, err)
+	}
+	preview.Operation = alertmanagerv1alpha1.DryRunUpdate
+	preview.Diff = wavefront.DiffAlerts(live, alert)
+	return preview, nil
+}
+
+// PreviewDelete function builds the dry-run preview for deleting an alert
+func PreviewDelete() *alertmanagerv1alpha1.DryRunStatus {
+	return &alertmanagerv1alpha1.DryRunStatus{
+		Operation: alertmanagerv1alpha1.DryRunDelete,
+		Timestamp: metav1.Now(),
+	}
+}
+
+// RecordDryRun function records the preview as an event on the object
+func (r *Client) RecordDryRun(ctx context.Context, obj runtime.Object, alertName string, preview *alertmanagerv1alpha1.DryRunStatus) {
+	log := log.Logger(ctx, "controllers.common", "dryrun", "RecordDryRun")
+	log.Info("dry-run: skipping the wavefront call", "alertName", alertName, "operation", preview.Operation, "payloadHash", preview.PayloadHash, "diff", preview.Diff)
+	msg := fmt.Sprintf("dry-run: would %s alert %s", strings.ToLower(string(preview.Operation)), alertName)
+	if preview.PayloadHash != "" {
+		msg = fmt.Sprintf("%s with payload hash %s", msg, preview.PayloadHash)
+	}
+	if len(preview.Diff) > 0 {
+		msg = fmt.Sprintf("%s. diff: %s", msg, strings.Join(preview.Diff, "; "))
+	}
+	r.Recorder.Event(obj, v1.EventTypeNormal, string(alertmanagerv1alpha1.DryRun), msg)
+}
+
+// CheckPreviewedPayload function warns if the alert being applied is not the one previewed in dry-run mode.
+// For ex: template got changed after the dry-run annotation was removed
+func (r *Client) CheckPreviewedPayload(ctx context.Context, obj runtime.Object, alertStatus alertmanagerv1alpha1.AlertStatus, alert *wf.Alert) {
+	if alertStatus.DryRun == nil || alertStatus.DryRun.PayloadHash == "" {
+		return
+	}
+	if hash := wavefront.PayloadHash(ctx, alert); hash != alertStatus.DryRun.PayloadHash {
+		r.Recorder.Event(obj, v1.EventTypeWarning, "DryRunMismatch", fmt.Sprintf("applied payload hash %s for alert %s is different from the dry-run preview %s", hash, alert.Name, alertStatus.DryRun.PayloadHash))
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/keikoproj/alert-manager/internal/config"
+	"github.com/keikoproj/alert-manager/pkg/log"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// AlertHistoryLabel is the label on the config maps with the exported alert change history
+	AlertHistoryLabel = "alertmanager.keikoproj.io/alert-history"
+	// maxHistoryConfigMapSize keeps the history config map well below the 1MiB limit
+	maxHistoryConfigMapSize = 512 * 1024
+	maxConfigMapNameLength  = 253
+)
+
+var invalidConfigMapKeyChars = regexp.MustCompile(`[^-._a-zA-Z0-9]+`)
+
+// NewAlertChange function returns the change applied now because of the trigger resource
+func NewAlertChange(operation alertmanagerv1alpha1.AlertOperation, trigger metav1.Object, kind string, diff []string) alertmanagerv1alpha1.AlertChange {
+	return alertmanagerv1alpha1.AlertChange{
+		Timestamp:   metav1.Now(),
+		Operation:   operation,
+		TriggeredBy: kind + "/" + trigger.GetName(),
+		Generation:  trigger.GetGeneration(),
+		Diff:        diff,
+	}
+}
+
+// CreateOperation function returns Recreate if the alert was already created before, for ex: alert got deleted from wavefront by hand
+func CreateOperation(previous alertmanagerv1alpha1.AlertStatus) alertmanagerv1alpha1.AlertOperation {
+	if len(previous.History) > 0 || previous.PayloadHash != "" {
+		return alertmanagerv1alpha1.AlertRecreate
+	}
+	return alertmanagerv1alpha1.AlertCreate
+}
+
+// RecordAlertChange function adds the change to the alert status history and exports it to the history config map if enabled.
+// Alert status is nil if the alert is not in the status anymore, for ex: deleted alert
+func (r *Client) RecordAlertChange(ctx context.Context, owner wavefront.Owner, alertStatus *alertmanagerv1alpha1.AlertStatus, change alertmanagerv1alpha1.AlertChange) {
+	log := log.Logger(ctx, "controllers.common", "history", "RecordAlertChange")
+	if alertStatus != nil {
+		alertStatus.History = AppendAlertChange(alertStatus.History, change, r.HistoryLimit)
+	}
+	if r.HistoryExportLimit <= 0 {
+		return
+	}
+	// Failing to export the history doesn't fail the reconcile
+	if err := r.exportAlertChange(ctx, owner, change); err != nil {
+		log.Error(err, "unable to export the alert change history", "owner", owner)
+	}
+}
+
+// AppendAlertChange function adds the change to the history and keeps only the latest limit changes
+func AppendAlertChange(history []alertmanagerv1alpha1.AlertChange, change alertmanagerv1alpha1.AlertChange, limit int) []alertmanagerv1alpha1.AlertChange {
+	if limit <= 0 {
+		return nil
+	}
+	history = append(history, change)
+	if len(history) > limit {
+		history = append([]alertmanagerv1alpha1.AlertChange{}, history[len(history)-limit:]...)
+	}
+	return history
+}
+
+// exportAlertChange function appends the change to the owner's history config map. There is one key per alert instance
+// with one JSON encoded change per line
+func (r *Client) exportAlertChange(ctx context.Context, owner wavefront.Owner, change alertmanagerv1alpha1.AlertChange) error {
+	line, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+	key := types.NamespacedName{Namespace: owner.Namespace, Name: HistoryConfigMapName(owner.Kind, owner.Name)}
+	if key.Namespace == "" {
+		// cluster scoped resources
+		key.Namespace = config.Namespace()
+	}
+	dataKey := HistoryConfigMapKey(owner.Instance)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var cm v1.ConfigMap
+		err := r.Get(ctx, key, &cm)
+		if apierrors.IsNotFound(err) {
+			// Not owned by the resource so the history is kept after the resource is deleted
+			cm = v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+					Labels:    map[string]string{AlertHistoryLabel: "true"},
+				},
+				Data: map[string]string{dataKey: string(line)},
+			}
+			return r.Create(ctx, &cm)
+		}
+		if err != nil {
+			return err
+		}
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+		cm.Data[dataKey] = AppendHistoryLine(cm.Data[dataKey], string(line), r.HistoryExportLimit)
+		TrimHistoryData(cm.Data, maxHistoryConfigMapSize)
+		return r.Update(ctx, &cm)
+	})
+}
+
+// HistoryConfigMapName function returns the name of the config map with the exported history of the resource
+func HistoryConfigMapName(kind string, name string) string {
+	cmName := "alert-history-" + strings.ToLower(kind) + "-" + name
+	if len(cmName) > maxConfigMapNameLength {
+		cmName = strings.TrimRight(cmName[:maxConfigMapNameLength], "-.")
+	}
+	return cmName
+}
+
+// HistoryConfigMapKey function converts the alert instance name to a valid config map key
+func HistoryConfigMapKey(instance string) string {
+	key := invalidConfigMapKeyChars.ReplaceAllString(instance, "_")
+	if key == "" {
+		return "_"
+	}
+	return key
+}
+
+// AppendHistoryLine function adds the line to the history lines and keeps only the latest limit lines
+func AppendHistoryLine(history string, line string, limit int) string {
+	var lines []string
+	if history != "" {
+		lines = strings.Split(history, "\n")
+	}
+	lines = append(lines, line)
+	if len(lines) > limit {
+		lines = lines[len(lines)-limit:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// TrimHistoryData function drops the oldest lines of the largest keys until the config map data fits in the max size
+func TrimHistoryData(data map[string]string, maxSize int) {
+	size := func() int {
+		total := 0
+		for k, v := range data {
+			total += len(k) + len(v)
+		}
+		return total
+	}
+	for size() > maxSize {
+		largest := ""
+		for k, v := range data {
+			if largest == "" || len(v) > len(data[largest]) {
+				largest = k
+			}
+		}
+		if _, rest, ok := strings.Cut(data[largest], "\n"); ok {
+			data[largest] = rest
+		} else {
+			delete(data, largest)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"github.com/keikoproj/alert-manager/internal/config"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AlertOwner function returns the owner used in the ownership tags of the alert created by the resource.
+// Instance is the key of the alert in the resource status
+func AlertOwner(obj metav1.Object, kind string, instance string) wavefront.Owner {
+	return wavefront.Owner{
+		ClusterID: config.Props.ClusterID(),
+		Namespace: obj.GetNamespace(),
+		Kind:      kind,
+		Name:      obj.GetName(),
+		Instance:  instance,
+	}
+}
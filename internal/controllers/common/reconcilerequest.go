@@ -0,0 +1,65 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"strconv"
+
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/keikoproj/alert-manager/pkg/log"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IsPaused checks if the object has the paused annotation. Nothing is changed in wavefront for a paused object
+func IsPaused(obj metav1.Object) bool {
+	paused, _ := strconv.ParseBool(obj.GetAnnotations()[alertmanagerv1alpha1.PausedAnnotation])
+	return paused
+}
+
+// ReconcileRequested returns the reconcile request annotation value and true if it is different from the last handled one.
+// Alerts must be pushed to wavefront again in that case, bypassing the checksum and observed generation checks
+func ReconcileRequested(obj metav1.Object, lastHandled string) (string, bool) {
+	request := obj.GetAnnotations()[alertmanagerv1alpha1.ReconcileRequestAnnotation]
+	return request, request != "" && request != lastHandled
+}
+
+// ResetPayloadHashes function clears the payload hash of the alerts so they are updated in wavefront
+// even if the rendered payload is same as the last applied one
+func ResetPayloadHashes(alertsStatus map[string]alertmanagerv1alpha1.AlertStatus) {
+	for key, alertStatus := range alertsStatus {
+		alertStatus.PayloadHash = ""
+		alertsStatus[key] = alertStatus
+	}
+}
+
+// Pause function reports the paused state of the object. Status is only updated when the object gets paused
+// and the state before the pause is not kept. Reconcilers handle the Paused state as a change once the annotation is removed
+func (r *Client) Pause(ctx context.Context, obj client.Object, state *alertmanagerv1alpha1.State) (ctrl.Result, error) {
+	log := log.Logger(ctx, "controllers.common", "reconcilerequest", "Pause")
+	if *state == alertmanagerv1alpha1.Paused {
+		log.V(1).Info("resource is paused. skipping")
+		return ctrl.Result{}, nil
+	}
+	log.Info("resource got paused. wavefront is not called until the annotation is removed", "annotation", alertmanagerv1alpha1.PausedAnnotation)
+	r.Recorder.Event(obj, v1.EventTypeNormal, string(alertmanagerv1alpha1.Paused), "Reconcile is paused by the "+alertmanagerv1alpha1.PausedAnnotation+" annotation")
+	*state = alertmanagerv1alpha1.Paused
+	return r.UpdateStatus(ctx, obj, alertmanagerv1alpha1.Paused)
+}
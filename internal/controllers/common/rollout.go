@@ -0,0 +1,87 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"sort"
+
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+)
+
+// NewRollout function starts the rollout of the template generation with all the alerts configs pending
+func NewRollout(generation int64, alertsConfigs []string) *alertmanagerv1alpha1.RolloutStatus {
+	rollout := &alertmanagerv1alpha1.RolloutStatus{
+		Generation:    generation,
+		Phase:         alertmanagerv1alpha1.RolloutInProgress,
+		AlertsConfigs: make(map[string]alertmanagerv1alpha1.RolloutInstanceState, len(alertsConfigs)),
+	}
+	for _, name := range alertsConfigs {
+		rollout.AlertsConfigs[name] = alertmanagerv1alpha1.RolloutPending
+	}
+	return rollout
+}
+
+// NextRolloutBatch function returns the next batch of pending alerts configs.
+// Pending canaries are returned first in a batch of their own and the rest are returned in sorted order
+// so the batches are the same after a controller restart
+func NextRolloutBatch(rollout *alertmanagerv1alpha1.RolloutStatus, strategy *alertmanagerv1alpha1.RolloutStrategy) []string {
+	var batch []string
+	if strategy != nil {
+		for _, name := range strategy.Canaries {
+			if rollout.AlertsConfigs[name] == alertmanagerv1alpha1.RolloutPending {
+				batch = append(batch, name)
+			}
+		}
+		if len(batch) > 0 {
+			return batch
+		}
+	}
+	for name, state := range rollout.AlertsConfigs {
+		if state == alertmanagerv1alpha1.RolloutPending {
+			batch = append(batch, name)
+		}
+	}
+	sort.Strings(batch)
+	if strategy != nil && strategy.BatchSize > 0 && len(batch) > strategy.BatchSize {
+		batch = batch[:strategy.BatchSize]
+	}
+	return batch
+}
+
+// CountRollout function returns the number of alerts configs in the given rollout state
+func CountRollout(rollout *alertmanagerv1alpha1.RolloutStatus, state alertmanagerv1alpha1.RolloutInstanceState) int {
+	count := 0
+	for _, s := range rollout.AlertsConfigs {
+		if s == state {
+			count++
+		}
+	}
+	return count
+}
+
+// ExceedsMaxFailures function checks if the rollout must be halted as per the rollout strategy
+func ExceedsMaxFailures(rollout *alertmanagerv1alpha1.RolloutStatus, strategy *alertmanagerv1alpha1.RolloutStrategy) bool {
+	if strategy == nil || strategy.MaxFailures == nil {
+		return false
+	}
+	return CountRollout(rollout, alertmanagerv1alpha1.RolloutFailed) > *strategy.MaxFailures
+}
+
+// RolloutUnfinished function checks if the rollout is in progress or paused between the batches
+func RolloutUnfinished(rollout *alertmanagerv1alpha1.RolloutStatus) bool {
+	return rollout != nil && (rollout.Phase == alertmanagerv1alpha1.RolloutInProgress || rollout.Phase == alertmanagerv1alpha1.RolloutPaused)
+}
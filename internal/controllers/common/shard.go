@@ -0,0 +1,51 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// ShardForNamespace function returns the shard of the namespace by hashing its name.
+// Cluster scoped resources (empty namespace) always belong to shard 0
+func ShardForNamespace(namespace string, shardCount int) int {
+	if namespace == "" || shardCount <= 1 {
+		return 0
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(namespace))
+	return int(h.Sum32() % uint32(shardCount))
+}
+
+// ShardFromLabel function parses the shard number from the namespace label value
+func ShardFromLabel(value string, shardCount int) (int, bool) {
+	shard, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || shard < 0 || shard >= shardCount {
+		return 0, false
+	}
+	return shard, true
+}
+
+// FairShare function returns the max number of shards a replica may own so the shards are spread evenly between the replicas
+func FairShare(shardCount int, members int) int {
+	if members < 1 {
+		members = 1
+	}
+	return (shardCount + members - 1) / members
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	"github.com/google/uuid"
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/keikoproj/alert-manager/pkg/log"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	"github.com/prometheus/client_golang/prometheus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	firingStateLabels = []string{"namespace", "kind", "name", "alert", "severity", "state"}
+
+	alertFiringState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "alert_manager_alert_firing_state",
+		Help: "1 for the current live state of the managed wavefront alert",
+	}, firingStateLabels)
+	alertFiringStateSince = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "alert_manager_alert_firing_state_since_seconds",
+		Help: "Unix time the managed wavefront alert was first seen in its current live state",
+	}, firingStateLabels)
+)
+
+func init() {
+	metrics.Registry.MustRegister(alertFiringState, alertFiringStateSince)
+}
+
+// firingStatePrecedence is the order the wavefront alert statuses are checked in since an alert can have more than one.
+// For ex: a snoozed alert can be firing too
+var firingStatePrecedence = []alertmanagerv1alpha1.FiringState{
+	alertmanagerv1alpha1.Snoozed,
+	alertmanagerv1alpha1.InMaintenance,
+	alertmanagerv1alpha1.Firing,
+	alertmanagerv1alpha1.NoData,
+	alertmanagerv1alpha1.Checking,
+}
+
+// FiringStatePoller periodically records the live state of the wavefront alerts, for ex: FIRING or SNOOZED, in the alert status
+// of the WavefrontAlert, ClusterWavefrontAlert and AlertsConfig resources and exports it as metrics.
+// Alerts are fetched with a single paged search by the cluster ownership tag instead of reading them one by one,
+// so alerts created before the cluster id got configured are not polled until they get updated
+type FiringStatePoller struct {
+	client.Client
+	WavefrontClient wavefront.Interface
+	//ClusterID is the id of the cluster in the ownership tags
+	ClusterID string
+	//Interval between two polls
+	Interval time.Duration
+	//Namespaces limits the poll to the resources in the watched namespaces. nil if all the namespaces are watched
+	Namespaces *NamespaceFilter
+
+	// exported are the metric labels set in the previous poll so the ones not seen anymore can be deleted
+	exported map[firingStateOwner][]string
+}
+
+// firingStateOwner identifies an alert status entry
+type firingStateOwner struct {
+	kind      string
+	namespace string
+	name      string
+	key       string
+}
+
+// errFiringStateClusterID is returned when the cluster id is not configured. Managed alerts are found by the cluster tag
+var errFiringStateClusterID = errors.New("firing state poller requires cluster.id in the config map")
+
+// Start function runs the poller until the context is done. Implements manager.Runnable
+func (p *FiringStatePoller) Start(ctx context.Context) error {
+	log := log.Logger(ctx, "controllers", "firing_state_poller", "Start")
+	if p.ClusterID == "" {
+		return errFiringStateClusterID
+	}
+	log.Info("Starting firing state poller", "clusterID", p.ClusterID, "interval", p.Interval)
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := p.Poll(context.WithValue(ctx, requestId, uuid.New())); err != nil {
+				log.Error(err, "firing state poll failed")
+			}
+		}
+	}
+}
+
+// NeedLeaderElection function makes sure only the leader polls wavefront and patches the status. Implements manager.LeaderElectionRunnable
+func (p *FiringStatePoller) NeedLeaderElection() bool {
+	return true
+}
+
+// Poll function fetches the live state of the alerts once, patches the alert status entries whose state changed and updates the metrics
+func (p *FiringStatePoller) Poll(ctx context.Context) error {
+	log := log.Logger(ctx, "controllers", "firing_state_poller", "Poll")
+	log = log.WithValues("clusterID", p.ClusterID)
+	if p.ClusterID == "" {
+		return errFiringStateClusterID
+	}
+	alerts, err := p.WavefrontClient.FindAlertsByTag(ctx, wavefront.ClusterTag(p.ClusterID))
+	if err != nil {
+		return err
+	}
+	liveAlerts := make(map[string]*wf.Alert, len(alerts))
+	for _, alert := range alerts {
+		if alert.ID != nil {
+			liveAlerts[*alert.ID] = alert
+		}
+	}
+
+	var wfAlertList alertmanagerv1alpha1.WavefrontAlertList
+	if err := p.List(ctx, &wfAlertList); err != nil {
+		return err
+	}
+	var clusterAlertList alertmanagerv1alpha1.ClusterWavefrontAlertList
+	if err := p.List(ctx, &clusterAlertList); err != nil {
+		return err
+	}
+	var alertsConfigList alertmanagerv1alpha1.AlertsConfigList
+	if err := p.List(ctx, &alertsConfigList); err != nil {
+		return err
+	}
+
+	exported := make(map[firingStateOwner][]string)
+	now := metav1.Now()
+	for i := range wfAlertList.Items {
+		wfAlert := &wfAlertList.Items[i]
+		if !p.Namespaces.Allows(ctx, wfAlert.Namespace) {
+			continue
+		}
+		// Alerts rendered from a template are exported by the alerts config
+		changes := p.pollStatus(ctx, wfAlert, alertmanagerv1alpha1.WavefrontAlertKind, wfAlert.Status.AlertsStatus, liveAlerts, now, exported, func(a alertmanagerv1alpha1.AlertStatus) bool {
+			return a.AssociatedAlertsConfig.CR == ""
+		})
+		p.patchFiringState(ctx, wfAlert, map[string]interface{}{"alertsStatus": changes})
+	}
+	for i := range clusterAlertList.Items {
+		clusterAlert := &clusterAlertList.Items[i]
+		namespaces := make(map[string]interface{})
+		for namespace, nsStatus := range clusterAlert.Status.Namespaces {
+			if !p.Namespaces.Allows(ctx, namespace) {
+				continue
+			}
+			// Alerts rendered from a cluster template are always exported by the alerts config
+			changes := p.pollStatus(ctx, clusterAlert, alertmanagerv1alpha1.ClusterWavefrontAlertKind, nsStatus.AlertsStatus, liveAlerts, now, exported, func(alertmanagerv1alpha1.AlertStatus) bool {
+				return false
+			})
+			if len(changes) > 0 {
+				namespaces[namespace] = map[string]interface{}{"alertsStatus": changes}
+			}
+		}
+		p.patchFiringState(ctx, clusterAlert, map[string]interface{}{"namespaces": namespaces})
+	}
+	for i := range alertsConfigList.Items {
+		alertsConfig := &alertsConfigList.Items[i]
+		if !p.Namespaces.Allows(ctx, alertsConfig.Namespace) {
+			continue
+		}
+		changes := p.pollStatus(ctx, alertsConfig, alertmanagerv1alpha1.AlertsConfigKind, alertsConfig.Status.AlertsStatus, liveAlerts, now, exported, func(alertmanagerv1alpha1.AlertStatus) bool {
+			return true
+		})
+		p.patchFiringState(ctx, alertsConfig, map[string]interface{}{"alertsStatus": changes})
+	}
+
+	for owner, labels := range p.exported {
+		if current, ok := exported[owner]; !ok || !slices.Equal(current, labels) {
+			alertFiringState.DeleteLabelValues(labels...)
+			alertFiringStateSince.DeleteLabelValues(labels...)
+		}
+	}
+	p.exported = exported
+	return nil
+}
+
+// pollStatus function returns the changes of the status entries whose live state changed keyed by the entry and sets the metrics for the entries to be exported
+func (p *FiringStatePoller) pollStatus(
+	ctx context.Context,
+	obj client.Object,
+	kind string,
+	alertsStatus map[string]alertmanagerv1alpha1.AlertStatus,
+	liveAlerts map[string]*wf.Alert,
+	now metav1.Time,
+	exported map[firingStateOwner][]string,
+	export func(alertmanagerv1alpha1.AlertStatus) bool,
+) map[string]interface{} {
+	log := log.Logger(ctx, "controllers", "firing_state_poller", "pollStatus")
+	log = log.WithValues("kind", kind, "namespace", obj.GetNamespace(), "name", obj.GetName())
+
+	changes := make(map[string]interface{})
+	for key, alertStatus := range alertsStatus {
+		live, ok := liveAlerts[alertStatus.ID]
+		if alertStatus.ID == "" || !ok {
+			// Not created yet or not tagged with the cluster id
+			continue
+		}
+		state := FiringStateOf(live)
+		since := alertStatus.FiringStateSince
+		if state != alertStatus.FiringState {
+			since = nil
+			if state != "" {
+				since = &now
+			}
+			changes[key] = map[string]interface{}{
+				"firingState":      nullIfEmpty(string(state)),
+				"firingStateSince": since,
+			}
+			log.V(1).Info("alert firing state changed", "alertID", alertStatus.ID, "from", alertStatus.FiringState, "to", state)
+		}
+		if state == "" || !export(alertStatus) {
+			continue
+		}
+		labels := []string{obj.GetNamespace(), kind, obj.GetName(), alertStatus.Name, severityOf(live), string(state)}
+		exported[firingStateOwner{kind: kind, namespace: obj.GetNamespace(), name: obj.GetName(), key: key}] = labels
+		alertFiringState.WithLabelValues(labels...).Set(1)
+		if since != nil {
+			alertFiringStateSince.WithLabelValues(labels...).Set(float64(since.Unix()))
+		}
+	}
+	return changes
+}
+
+// patchFiringState function patches the changed firing states in the status unless there is nothing to patch.
+// Status is not updated with the rest of the alert status so a reconcile in progress doesn't conflict with the poller.
+// Resource version makes sure an entry removed by a reconcile since the list is not created again without its id
+func (p *FiringStatePoller) patchFiringState(ctx context.Context, obj client.Object, status map[string]interface{}) {
+	log := log.Logger(ctx, "controllers", "firing_state_poller", "patchFiringState")
+	log = log.WithValues("namespace", obj.GetNamespace(), "name", obj.GetName())
+	empty := true
+	for _, changes := range status {
+		if len(changes.(map[string]interface{})) > 0 {
+			empty = false
+		}
+	}
+	if empty {
+		return
+	}
+
+	patch, _ := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"resourceVersion": obj.GetResourceVersion()},
+		"status":   status,
+	})
+	err := p.Status().Patch(ctx, obj, client.RawPatch(types.MergePatchType, patch))
+	switch {
+	case apierrors.IsConflict(err):
+		// Resource got changed since the list. It will be retried in the next poll
+		log.V(1).Info("resource got changed since the poll started. skipping")
+	case err != nil:
+		// It will be retried in the next poll
+		log.Error(err, "unable to patch the alert firing state")
+	}
+}
+
+// FiringStateOf function returns the live state of the wavefront alert or empty if none of the statuses is a known state
+func FiringStateOf(alert *wf.Alert) alertmanagerv1alpha1.FiringState {
+	for _, state := range firingStatePrecedence {
+		for _, status := range alert.Status {
+			if status == string(state) {
+				return state
+			}
+		}
+	}
+	return ""
+}
+
+// severityOf function returns the severity of the alert. Threshold alerts have the severity per condition so the first one is used
+func severityOf(alert *wf.Alert) string {
+	if alert.Severity != "" || len(alert.SeverityList) == 0 {
+		return alert.Severity
+	}
+	return alert.SeverityList[0]
+}
+
+// nullIfEmpty function returns nil for an empty string so the merge patch removes the field
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers_test
+
+import (
+	"context"
+	"time"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	"github.com/golang/mock/gomock"
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/keikoproj/alert-manager/internal/controllers"
+	mock_wavefront "github.com/keikoproj/alert-manager/internal/controllers/mocks"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// FiringStatePoller tests validate that the live state of the alerts is recorded in the status only when it changes
+var _ = Describe("FiringStatePoller", Label("controller", "firingstatepoller"), func() {
+	const (
+		clusterID = "test-cluster"
+		namespace = "default"
+	)
+
+	newAlert := func(id string, status ...string) *wf.Alert {
+		return &wf.Alert{ID: &id, Name: id, Severity: "WARN", Status: status}
+	}
+
+	DescribeTable("FiringStateOf",
+		func(status []string, expected alertmanagerv1alpha1.FiringState) {
+			Expect(controllers.FiringStateOf(newAlert("id", status...))).To(Equal(expected))
+		},
+		Entry("firing", []string{"FIRING"}, alertmanagerv1alpha1.Firing),
+		Entry("snoozed takes precedence over firing", []string{"FIRING", "SNOOZED"}, alertmanagerv1alpha1.Snoozed),
+		Entry("maintenance takes precedence over no data", []string{"NO_DATA", "IN_MAINTENANCE"}, alertmanagerv1alpha1.InMaintenance),
+		Entry("unknown status", []string{"INVALID"}, alertmanagerv1alpha1.FiringState("")),
+		Entry("no status", nil, alertmanagerv1alpha1.FiringState("")),
+	)
+
+	It("Should record the firing state and keep the time it entered the state", func() {
+		ctx := context.Background()
+		alertsConfig := &alertmanagerv1alpha1.AlertsConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "firing-state-poller-test", Namespace: namespace},
+		}
+		Expect(k8sClient.Create(ctx, alertsConfig)).To(Succeed())
+		defer func() {
+			Expect(k8sClient.Delete(ctx, alertsConfig)).To(Succeed())
+		}()
+		alertsConfig.Status.AlertsStatus = map[string]alertmanagerv1alpha1.AlertStatus{
+			"firing-template":   {ID: "firing-id", Name: "firing"},
+			"untagged-template": {ID: "untagged-id", Name: "untagged"},
+		}
+		Expect(k8sClient.Status().Update(ctx, alertsConfig)).To(Succeed())
+
+		mockCtrl := gomock.NewController(GinkgoT())
+		defer mockCtrl.Finish()
+		wfClient := mock_wavefront.NewMockInterface(mockCtrl)
+		gomock.InOrder(
+			wfClient.EXPECT().FindAlertsByTag(gomock.Any(), wavefront.ClusterTag(clusterID)).Return([]*wf.Alert{
+				newAlert("firing-id", "FIRING"),
+			}, nil).Times(2),
+			wfClient.EXPECT().FindAlertsByTag(gomock.Any(), wavefront.ClusterTag(clusterID)).Return([]*wf.Alert{
+				newAlert("firing-id", "CHECKING"),
+			}, nil).Times(1),
+		)
+		wfClient.EXPECT().ReadAlert(gomock.Any(), gomock.Any()).Times(0)
+
+		poller := &controllers.FiringStatePoller{
+			Client:          k8sClient,
+			WavefrontClient: wfClient,
+			ClusterID:       clusterID,
+		}
+		key := types.NamespacedName{Name: alertsConfig.Name, Namespace: namespace}
+
+		Expect(poller.Poll(ctx)).To(Succeed())
+		Expect(k8sClient.Get(ctx, key, alertsConfig)).To(Succeed())
+		firing := alertsConfig.Status.AlertsStatus["firing-template"]
+		Expect(firing.FiringState).To(Equal(alertmanagerv1alpha1.Firing))
+		Expect(firing.FiringStateSince).NotTo(BeNil())
+		Expect(alertsConfig.Status.AlertsStatus["untagged-template"].FiringState).To(BeEmpty())
+
+		// Same state keeps the time it entered the state
+		Expect(poller.Poll(ctx)).To(Succeed())
+		Expect(k8sClient.Get(ctx, key, alertsConfig)).To(Succeed())
+		Expect(alertsConfig.Status.AlertsStatus["firing-template"].FiringStateSince).To(Equal(firing.FiringStateSince))
+
+		Expect(poller.Poll(ctx)).To(Succeed())
+		Expect(k8sClient.Get(ctx, key, alertsConfig)).To(Succeed())
+		Expect(alertsConfig.Status.AlertsStatus["firing-template"].FiringState).To(Equal(alertmanagerv1alpha1.Checking))
+		Expect(alertsConfig.Status.AlertsStatus["firing-template"].ID).To(Equal("firing-id"))
+	})
+
+	It("Should record the firing state of the alerts rendered from a cluster template", func() {
+		ctx := context.Background()
+		clusterAlert := &alertmanagerv1alpha1.ClusterWavefrontAlert{
+			ObjectMeta: metav1.ObjectMeta{Name: "firing-state-poller-cluster-test"},
+			Spec: alertmanagerv1alpha1.ClusterWavefrontAlertSpec{
+				WavefrontAlertSpec: alertmanagerv1alpha1.WavefrontAlertSpec{
+					AlertName:         "cpu",
+					Condition:         "ts(cpu) > 80",
+					DisplayExpression: "ts(cpu)",
+					Severity:          "warn",
+					ExportedParams:    []string{"app"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, clusterAlert)).To(Succeed())
+		defer func() {
+			Expect(k8sClient.Delete(ctx, clusterAlert)).To(Succeed())
+		}()
+		clusterAlert.Status.Namespaces = map[string]alertmanagerv1alpha1.NamespaceAlertsStatus{
+			namespace: {AlertsStatus: map[string]alertmanagerv1alpha1.AlertStatus{
+				"consumer": {ID: "cluster-firing-id", Name: "cpu"},
+			}},
+		}
+		Expect(k8sClient.Status().Update(ctx, clusterAlert)).To(Succeed())
+
+		mockCtrl := gomock.NewController(GinkgoT())
+		defer mockCtrl.Finish()
+		wfClient := mock_wavefront.NewMockInterface(mockCtrl)
+		wfClient.EXPECT().FindAlertsByTag(gomock.Any(), wavefront.ClusterTag(clusterID)).Return([]*wf.Alert{
+			newAlert("cluster-firing-id", "FIRING"),
+		}, nil).Times(1)
+
+		poller := &controllers.FiringStatePoller{
+			Client:          k8sClient,
+			WavefrontClient: wfClient,
+			ClusterID:       clusterID,
+		}
+		Expect(poller.Poll(ctx)).To(Succeed())
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: clusterAlert.Name}, clusterAlert)).To(Succeed())
+		firing := clusterAlert.Status.Namespaces[namespace].AlertsStatus["consumer"]
+		Expect(firing.FiringState).To(Equal(alertmanagerv1alpha1.Firing))
+		Expect(firing.FiringStateSince).NotTo(BeNil())
+		Expect(firing.ID).To(Equal("cluster-firing-id"))
+	})
+
+	It("Should refuse to poll if the cluster id is not configured", func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		defer mockCtrl.Finish()
+		wfClient := mock_wavefront.NewMockInterface(mockCtrl)
+		wfClient.EXPECT().FindAlertsByTag(gomock.Any(), gomock.Any()).Times(0)
+
+		poller := &controllers.FiringStatePoller{Client: k8sClient, WavefrontClient: wfClient, Interval: time.Minute}
+		Expect(poller.Poll(context.Background())).NotTo(Succeed())
+		Expect(poller.Start(context.Background())).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/keikoproj/alert-manager/internal/utils"
+	"github.com/keikoproj/alert-manager/pkg/log"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// NamespaceFilter limits the controllers to the watched namespaces so multiple alert-manager instances can run in the same cluster.
+// Manager cache is also limited to the namespaces in the list but namespaces selected by labels can change at any time so they are checked on every reconcile
+type NamespaceFilter struct {
+	client.Client
+	//Namespaces is the list of watched namespaces. All namespaces if empty
+	Namespaces []string
+	//Selector selects the watched namespaces by their labels. All namespaces if nil
+	Selector labels.Selector
+}
+
+// Setup function requeues the resources in a namespace when its labels change. Controller is not changed if there is no selector
+func (f *NamespaceFilter) Setup(b *builder.Builder, list client.ObjectList) *builder.Builder {
+	if f == nil || f.Selector == nil {
+		return b
+	}
+	return b.Watches(&v1.Namespace{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return f.requestsInNamespace(ctx, list, obj.GetName())
+	}), builder.WithPredicates(predicate.LabelChangedPredicate{}))
+}
+
+// Allows function checks if the namespace is watched. Cluster scoped resources (empty namespace) are always allowed.
+// It always returns true if the filter is not configured
+func (f *NamespaceFilter) Allows(ctx context.Context, namespace string) bool {
+	if f == nil || namespace == "" {
+		return true
+	}
+	if len(f.Namespaces) > 0 && !utils.ContainsString(f.Namespaces, namespace) {
+		return false
+	}
+	if f.Selector == nil {
+		return true
+	}
+	var ns v1.Namespace
+	if err := f.Get(ctx, types.NamespacedName{Name: namespace}, &ns); err != nil {
+		log.Logger(ctx, "controllers", "namespace_filter", "Allows").Error(err, "unable to get the namespace", "namespace", namespace)
+		return false
+	}
+	return f.Selector.Matches(labels.Set(ns.Labels))
+}
+
+// requestsInNamespace function returns the requests for all the resources of the list type in the namespace
+func (f *NamespaceFilter) requestsInNamespace(ctx context.Context, list client.ObjectList, namespace string) []reconcile.Request {
+	log := log.Logger(ctx, "controllers", "namespace_filter", "requestsInNamespace")
+	list = list.DeepCopyObject().(client.ObjectList)
+	if err := f.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		log.Error(err, "unable to list the resources", "namespace", namespace)
+		return nil
+	}
+	objs, err := meta.ExtractList(list)
+	if err != nil {
+		log.Error(err, "unable to list the resources", "namespace", namespace)
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(objs))
+	for _, o := range objs {
+		if obj, ok := o.(client.Object); ok {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}})
+		}
+	}
+	return requests
+}
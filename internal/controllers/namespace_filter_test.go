@@ -0,0 +1,53 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers_test
+
+import (
+	"context"
+
+	"github.com/keikoproj/alert-manager/internal/controllers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// NamespaceFilter tests validate that only the watched namespaces are reconciled
+var _ = Describe("NamespaceFilter", Label("controller", "namespacefilter"), func() {
+	ctx := context.Background()
+
+	It("Should allow all the namespaces if not configured", func() {
+		var filter *controllers.NamespaceFilter
+		Expect(filter.Allows(ctx, "default")).To(BeTrue())
+	})
+
+	It("Should allow only the namespaces in the list", func() {
+		filter := &controllers.NamespaceFilter{Client: k8sClient, Namespaces: []string{"default"}}
+		Expect(filter.Allows(ctx, "default")).To(BeTrue())
+		Expect(filter.Allows(ctx, "kube-system")).To(BeFalse())
+		// cluster scoped resources
+		Expect(filter.Allows(ctx, "")).To(BeTrue())
+	})
+
+	It("Should allow only the namespaces matching the selector", func() {
+		selector, err := labels.Parse("kubernetes.io/metadata.name=default")
+		Expect(err).To(BeNil())
+		filter := &controllers.NamespaceFilter{Client: k8sClient, Selector: selector}
+		Expect(filter.Allows(ctx, "default")).To(BeTrue())
+		Expect(filter.Allows(ctx, "kube-system")).To(BeFalse())
+		Expect(filter.Allows(ctx, "does-not-exist")).To(BeFalse())
+	})
+})
@@ -0,0 +1,176 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/keikoproj/alert-manager/pkg/audit"
+	"github.com/keikoproj/alert-manager/pkg/log"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OrphanCollector periodically deletes the wavefront alerts created from this cluster when the resource
+// or the status entry which created them doesn't exist anymore. For ex: resource got force deleted or its finalizer got removed by hand.
+// Alerts are identified by the ownership tags so alerts created before the cluster id got configured are never touched
+type OrphanCollector struct {
+	client.Client
+	WavefrontClient wavefront.Interface
+	//ClusterID is the id of the cluster in the ownership tags
+	ClusterID string
+	//Interval between two runs
+	Interval time.Duration
+	//DryRun only reports the orphan alerts without deleting them
+	DryRun bool
+	//MaxDeletes is the max number of alerts deleted in a run. Nothing is deleted if more orphan alerts are found
+	//since it is more likely a misconfiguration than that many resources being force deleted
+	MaxDeletes int
+	//Namespaces limits the collection to the alerts owned by the resources in the watched namespaces. nil if all the namespaces are watched
+	Namespaces *NamespaceFilter
+
+	// candidates are the orphan alerts found in the previous run. An alert is deleted only if it is found orphan
+	// in two consecutive runs so an alert created just before its status got updated is not deleted
+	candidates map[string]bool
+}
+
+// Start function runs the collector until the context is done. Implements manager.Runnable
+func (c *OrphanCollector) Start(ctx context.Context) error {
+	log := log.Logger(ctx, "controllers", "orphan_collector", "Start")
+	log.Info("Starting orphan alert collector", "clusterID", c.ClusterID, "interval", c.Interval, "dryRun", c.DryRun)
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := c.Collect(context.WithValue(ctx, requestId, uuid.New())); err != nil {
+				log.Error(err, "orphan alert collection failed")
+			}
+		}
+	}
+}
+
+// NeedLeaderElection function makes sure only the leader deletes the alerts. Implements manager.LeaderElectionRunnable
+func (c *OrphanCollector) NeedLeaderElection() bool {
+	return true
+}
+
+// Collect function runs the collection once and returns the ids of the orphan alerts found
+func (c *OrphanCollector) Collect(ctx context.Context) ([]string, error) {
+	log := log.Logger(ctx, "controllers", "orphan_collector", "Collect")
+	log = log.WithValues("clusterID", c.ClusterID)
+	ctx = audit.WithActor(ctx, audit.Actor{Kind: "OrphanCollector", Name: "orphan-collector"})
+	if c.ClusterID == "" {
+		log.Info("cluster id is not configured. skipping")
+		return nil, nil
+	}
+	alerts, err := c.WavefrontClient.FindAlertsByTag(ctx, wavefront.ClusterTag(c.ClusterID))
+	if err != nil {
+		return nil, err
+	}
+
+	var orphans []string
+	for _, alert := range alerts {
+		if alert.ID == nil {
+			continue
+		}
+		owner, ok := wavefront.ParseOwner(alert.Tags)
+		if !ok || owner.ClusterID != c.ClusterID {
+			// Not sure who owns it
+			continue
+		}
+		if !c.Namespaces.Allows(ctx, owner.Namespace) {
+			// Owner is not in the cache of this instance. The instance watching the namespace collects it
+			continue
+		}
+		orphan, err := c.isOrphan(ctx, owner, *alert.ID)
+		if err != nil {
+			// Don't delete anything if we are not sure about the owners
+			return nil, err
+		}
+		if orphan {
+			log.Info("found orphan alert", "alertID", *alert.ID, "alertName", alert.Name, "owner", owner)
+			orphans = append(orphans, *alert.ID)
+		}
+	}
+
+	candidates := make(map[string]bool, len(orphans))
+	var confirmed []string
+	for _, id := range orphans {
+		candidates[id] = true
+		if c.candidates[id] {
+			confirmed = append(confirmed, id)
+		}
+	}
+	c.candidates = candidates
+
+	if c.DryRun {
+		log.Info("dry-run: orphan alerts are not deleted", "alertIDs", orphans)
+		return orphans, nil
+	}
+	if len(confirmed) > c.MaxDeletes {
+		return orphans, fmt.Errorf("found %d orphan alerts which is more than max %d deletes per run. not deleting any of them", len(confirmed), c.MaxDeletes)
+	}
+	for _, id := range confirmed {
+		if err := c.WavefrontClient.DeleteAlert(ctx, id); err != nil {
+			// It will be retried in the next run
+			log.Error(err, "unable to delete the orphan alert", "alertID", id)
+			continue
+		}
+		delete(c.candidates, id)
+		log.Info("deleted the orphan alert", "alertID", id)
+	}
+	return orphans, nil
+}
+
+// isOrphan function checks if the owner of the alert or the status entry for the alert doesn't exist anymore
+func (c *OrphanCollector) isOrphan(ctx context.Context, owner wavefront.Owner, alertID string) (bool, error) {
+	namespacedName := types.NamespacedName{Namespace: owner.Namespace, Name: owner.Name}
+	switch owner.Kind {
+	case alertmanagerv1alpha1.WavefrontAlertKind:
+		var wfAlert alertmanagerv1alpha1.WavefrontAlert
+		if err := c.Get(ctx, namespacedName, &wfAlert); err != nil {
+			return apierrors.IsNotFound(err), client.IgnoreNotFound(err)
+		}
+		return !hasAlertStatusID(wfAlert.Status.AlertsStatus, alertID), nil
+	case alertmanagerv1alpha1.AlertsConfigKind:
+		var alertsConfig alertmanagerv1alpha1.AlertsConfig
+		if err := c.Get(ctx, namespacedName, &alertsConfig); err != nil {
+			return apierrors.IsNotFound(err), client.IgnoreNotFound(err)
+		}
+		return alertsConfig.Status.AlertsStatus[owner.Instance].ID != alertID, nil
+	}
+	return false, nil
+}
+
+// hasAlertStatusID checks if any of the alerts in the status has the id
+func hasAlertStatusID(alertsStatus map[string]alertmanagerv1alpha1.AlertStatus, alertID string) bool {
+	for _, a := range alertsStatus {
+		if a.ID == alertID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers_test
+
+import (
+	"context"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	"github.com/golang/mock/gomock"
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/keikoproj/alert-manager/internal/controllers"
+	mock_wavefront "github.com/keikoproj/alert-manager/internal/controllers/mocks"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OrphanCollector tests validate that only the alerts without an owner are deleted
+var _ = Describe("OrphanCollector", Label("controller", "orphancollector"), func() {
+	const (
+		clusterID = "test-cluster"
+		namespace = "default"
+	)
+
+	newAlert := func(id string, owner wavefront.Owner) *wf.Alert {
+		return &wf.Alert{ID: &id, Name: id, Tags: owner.Tags()}
+	}
+
+	It("Should delete the alerts found orphan in two consecutive runs", func() {
+		ctx := context.Background()
+		alertsConfig := &alertmanagerv1alpha1.AlertsConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "orphan-collector-test", Namespace: namespace},
+		}
+		Expect(k8sClient.Create(ctx, alertsConfig)).To(Succeed())
+		defer func() {
+			Expect(k8sClient.Delete(ctx, alertsConfig)).To(Succeed())
+		}()
+		alertsConfig.Status.AlertsStatus = map[string]alertmanagerv1alpha1.AlertStatus{
+			"owned-template": {ID: "owned-id", Name: "owned"},
+		}
+		Expect(k8sClient.Status().Update(ctx, alertsConfig)).To(Succeed())
+
+		owner := wavefront.Owner{ClusterID: clusterID, Namespace: namespace, Kind: alertmanagerv1alpha1.AlertsConfigKind, Name: alertsConfig.Name}
+		ownedOwner, staleOwner, deletedOwner := owner, owner, owner
+		ownedOwner.Instance = "owned-template"
+		staleOwner.Instance = "removed-template"
+		deletedOwner.Name = "deleted-alerts-config"
+		deletedOwner.Instance = "owned-template"
+		otherCluster := ownedOwner
+		otherCluster.ClusterID = "other-cluster"
+
+		mockCtrl := gomock.NewController(GinkgoT())
+		defer mockCtrl.Finish()
+		wfClient := mock_wavefront.NewMockInterface(mockCtrl)
+		wfClient.EXPECT().FindAlertsByTag(gomock.Any(), wavefront.ClusterTag(clusterID)).Return([]*wf.Alert{
+			newAlert("owned-id", ownedOwner),
+			newAlert("stale-id", staleOwner),
+			newAlert("deleted-id", deletedOwner),
+			newAlert("other-cluster-id", otherCluster),
+		}, nil).Times(2)
+		wfClient.EXPECT().DeleteAlert(gomock.Any(), "stale-id").Return(nil).Times(1)
+		wfClient.EXPECT().DeleteAlert(gomock.Any(), "deleted-id").Return(nil).Times(1)
+
+		collector := &controllers.OrphanCollector{
+			Client:          k8sClient,
+			WavefrontClient: wfClient,
+			ClusterID:       clusterID,
+			MaxDeletes:      5,
+		}
+		// First run only marks the candidates
+		orphans, err := collector.Collect(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(orphans).To(ConsistOf("stale-id", "deleted-id"))
+
+		orphans, err = collector.Collect(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(orphans).To(ConsistOf("stale-id", "deleted-id"))
+	})
+
+	It("Should not delete anything above the max deletes", func() {
+		ctx := context.Background()
+		owner := wavefront.Owner{ClusterID: clusterID, Namespace: namespace, Kind: alertmanagerv1alpha1.WavefrontAlertKind, Instance: "alert"}
+		first, second := owner, owner
+		first.Name = "deleted-wavefront-alert-1"
+		second.Name = "deleted-wavefront-alert-2"
+
+		mockCtrl := gomock.NewController(GinkgoT())
+		defer mockCtrl.Finish()
+		wfClient := mock_wavefront.NewMockInterface(mockCtrl)
+		wfClient.EXPECT().FindAlertsByTag(gomock.Any(), gomock.Any()).Return([]*wf.Alert{
+			newAlert("first-id", first),
+			newAlert("second-id", second),
+		}, nil).Times(2)
+		wfClient.EXPECT().DeleteAlert(gomock.Any(), gomock.Any()).Times(0)
+
+		collector := &controllers.OrphanCollector{
+			Client:          k8sClient,
+			WavefrontClient: wfClient,
+			ClusterID:       clusterID,
+			MaxDeletes:      1,
+		}
+		_, err := collector.Collect(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = collector.Collect(ctx)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Should skip the alerts owned by the resources in the namespaces not watched by the instance", func() {
+		ctx := context.Background()
+		owner := wavefront.Owner{ClusterID: clusterID, Namespace: "not-watched", Kind: alertmanagerv1alpha1.WavefrontAlertKind, Name: "other-instance-alert", Instance: "alert"}
+
+		mockCtrl := gomock.NewController(GinkgoT())
+		defer mockCtrl.Finish()
+		wfClient := mock_wavefront.NewMockInterface(mockCtrl)
+		wfClient.EXPECT().FindAlertsByTag(gomock.Any(), gomock.Any()).Return([]*wf.Alert{
+			newAlert("other-instance-id", owner),
+		}, nil).Times(2)
+		wfClient.EXPECT().DeleteAlert(gomock.Any(), gomock.Any()).Times(0)
+
+		collector := &controllers.OrphanCollector{
+			Client:          k8sClient,
+			WavefrontClient: wfClient,
+			ClusterID:       clusterID,
+			MaxDeletes:      5,
+			Namespaces:      &controllers.NamespaceFilter{Client: k8sClient, Namespaces: []string{namespace}},
+		}
+		for i := 0; i < 2; i++ {
+			orphans, err := collector.Collect(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(orphans).To(BeEmpty())
+		}
+	})
+})
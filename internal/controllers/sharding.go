@@ -0,0 +1,470 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	controllercommon "github.com/keikoproj/alert-manager/internal/controllers/common"
+	"github.com/keikoproj/alert-manager/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// ShardLeaseTypeLabel is the label on the leases used by the sharding. Value is either shard or member
+	ShardLeaseTypeLabel = "alertmanager.keikoproj.io/lease-type"
+	// ShardLabel is the label on the shard leases with the shard number
+	ShardLabel = "alertmanager.keikoproj.io/shard"
+
+	shardLeaseType  = "shard"
+	memberLeaseType = "member"
+	// member leases not renewed for this many lease durations are deleted
+	staleMemberLeaseFactor = 10
+)
+
+var (
+	shardOwned = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "alert_manager_shard_owned",
+		Help: "1 if the shard is owned by this replica, 0 otherwise",
+	}, []string{"shard"})
+	shardMembers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "alert_manager_shard_members",
+		Help: "Number of live replicas sharing the shards as seen by this replica",
+	})
+	shardRebalances = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "alert_manager_shard_rebalances_total",
+		Help: "Number of times the shards owned by this replica changed",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(shardOwned, shardMembers, shardRebalances)
+}
+
+// ShardManager splits the namespaces between the replicas so every replica reconciles only the namespaces of the shards it owns.
+// Every shard has a lease and every replica has a member lease. Replicas own at most their fair share of the shards
+// based on the live members, so the shards are rebalanced when a replica joins or leaves
+type ShardManager struct {
+	client.Client
+	//Namespace of the leases
+	Namespace string
+	//LeasePrefix is the prefix of the lease names
+	LeasePrefix string
+	//Identity of this replica
+	Identity string
+	//ShardCount is the number of shards
+	ShardCount int
+	//NamespaceLabel is the namespace label with the shard number. Namespaces are hashed if it is empty or the label is missing
+	NamespaceLabel string
+	//LeaseDuration is how long a lease is valid without renewal
+	LeaseDuration time.Duration
+	//RenewInterval between two lease renewals. Must be less than the lease duration
+	RenewInterval time.Duration
+
+	mu      sync.RWMutex
+	owned   map[int]bool
+	resyncs []shardResync
+}
+
+// shardResync has what is needed to requeue the resources of a controller when a shard is acquired
+type shardResync struct {
+	list client.ObjectList
+	ch   chan event.GenericEvent
+}
+
+// Setup function makes the controller run on all the replicas and requeues the resources of the acquired shards
+// and of the namespaces whose shard label changed. Controller is not changed if sharding is not enabled
+func (s *ShardManager) Setup(b *builder.Builder, list client.ObjectList) *builder.Builder {
+	if s == nil {
+		return b
+	}
+	ch := make(chan event.GenericEvent, 1024)
+	s.mu.Lock()
+	s.resyncs = append(s.resyncs, shardResync{list: list, ch: ch})
+	s.mu.Unlock()
+	b = b.
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		WatchesRawSource(source.Channel(ch, &handler.EnqueueRequestForObject{}))
+	if s.NamespaceLabel == "" {
+		return b
+	}
+	// Namespace moves to another shard. The new owner picks it up and the old one skips it from now on
+	return b.Watches(&v1.Namespace{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return s.requestsInNamespace(ctx, list, obj.GetName())
+	}), builder.WithPredicates(predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.ObjectOld.GetLabels()[s.NamespaceLabel] != e.ObjectNew.GetLabels()[s.NamespaceLabel]
+		},
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}))
+}
+
+// Owns function checks if this replica owns the shard of the namespace. It always returns true if sharding is not enabled
+func (s *ShardManager) Owns(ctx context.Context, namespace string) bool {
+	if s == nil {
+		return true
+	}
+	shard := s.shardFor(ctx, namespace)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.owned[shard]
+}
+
+// OwnedShards function returns the sorted shards owned by this replica
+func (s *ShardManager) OwnedShards() []int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	shards := make([]int, 0, len(s.owned))
+	for shard := range s.owned {
+		shards = append(shards, shard)
+	}
+	sort.Ints(shards)
+	return shards
+}
+
+// shardFor function returns the shard of the namespace
+func (s *ShardManager) shardFor(ctx context.Context, namespace string) int {
+	if namespace != "" && s.NamespaceLabel != "" {
+		var ns v1.Namespace
+		if err := s.Get(ctx, types.NamespacedName{Name: namespace}, &ns); err == nil {
+			if shard, ok := controllercommon.ShardFromLabel(ns.Labels[s.NamespaceLabel], s.ShardCount); ok {
+				return shard
+			}
+		}
+	}
+	return controllercommon.ShardForNamespace(namespace, s.ShardCount)
+}
+
+// Start function renews the leases until the context is done. Implements manager.Runnable
+func (s *ShardManager) Start(ctx context.Context) error {
+	log := log.Logger(ctx, "controllers", "sharding", "Start")
+	log.Info("Starting shard manager", "identity", s.Identity, "shards", s.ShardCount)
+	ticker := time.NewTicker(s.RenewInterval)
+	defer ticker.Stop()
+	for {
+		if err := s.Sync(context.WithValue(ctx, requestId, uuid.New())); err != nil {
+			log.Error(err, "unable to sync the shard leases")
+		}
+		select {
+		case <-ctx.Done():
+			// Let the other replicas take over the shards without waiting for the leases to expire
+			releaseCtx, cancel := context.WithTimeout(context.Background(), s.RenewInterval)
+			defer cancel()
+			s.release(releaseCtx)
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection function makes the shard manager run on all the replicas. Implements manager.LeaderElectionRunnable
+func (s *ShardManager) NeedLeaderElection() bool {
+	return false
+}
+
+// Sync function renews the member lease, keeps or releases the owned shards as per the fair share and acquires the free shards
+func (s *ShardManager) Sync(ctx context.Context) error {
+	log := log.Logger(ctx, "controllers", "sharding", "Sync")
+	now := time.Now()
+	if err := s.renewMemberLease(ctx, now); err != nil {
+		// Other replicas take over once the leases expire so stop reconciling right away
+		s.setOwned(ctx, nil)
+		return err
+	}
+
+	var leases coordinationv1.LeaseList
+	if err := s.List(ctx, &leases, client.InNamespace(s.Namespace), client.HasLabels{ShardLeaseTypeLabel}); err != nil {
+		s.setOwned(ctx, nil)
+		return err
+	}
+	members := 0
+	shardLeases := make(map[int]*coordinationv1.Lease, s.ShardCount)
+	for i := range leases.Items {
+		lease := &leases.Items[i]
+		switch lease.Labels[ShardLeaseTypeLabel] {
+		case memberLeaseType:
+			if s.isValid(lease, now) {
+				members++
+			} else if s.isStale(lease, now) {
+				// Any replica can clean it up. Conflicts are ignored
+				_ = s.Delete(ctx, lease, client.Preconditions{ResourceVersion: &lease.ResourceVersion})
+			}
+		case shardLeaseType:
+			if shard, err := strconv.Atoi(lease.Labels[ShardLabel]); err == nil && shard < s.ShardCount {
+				shardLeases[shard] = lease
+			}
+		}
+	}
+	fairShare := controllercommon.FairShare(s.ShardCount, members)
+	shardMembers.Set(float64(members))
+
+	owned := make(map[int]bool, fairShare)
+	// Renew the owned shards first so the shards don't move around unless needed
+	for shard := 0; shard < s.ShardCount; shard++ {
+		lease, ok := shardLeases[shard]
+		if !ok || !s.isHolder(lease) || !s.isValid(lease, now) {
+			continue
+		}
+		if len(owned) >= fairShare {
+			log.Info("releasing the shard to rebalance", "shard", shard, "fairShare", fairShare)
+			lease.Spec.HolderIdentity = nil
+			if err := s.Update(ctx, lease); err != nil {
+				log.Error(err, "unable to release the shard", "shard", shard)
+			}
+			continue
+		}
+		lease.Spec.RenewTime = &metav1.MicroTime{Time: now}
+		if err := s.Update(ctx, lease); err != nil {
+			log.Error(err, "unable to renew the shard lease", "shard", shard)
+			continue
+		}
+		owned[shard] = true
+	}
+	for shard := 0; shard < s.ShardCount && len(owned) < fairShare; shard++ {
+		if owned[shard] {
+			continue
+		}
+		lease, ok := shardLeases[shard]
+		if ok && lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity != "" && s.isValid(lease, now) {
+			// owned by another replica
+			continue
+		}
+		if err := s.acquireShardLease(ctx, shard, lease, now); err != nil {
+			if !apierrors.IsConflict(err) && !apierrors.IsAlreadyExists(err) {
+				log.Error(err, "unable to acquire the shard lease", "shard", shard)
+			}
+			continue
+		}
+		log.Info("acquired the shard", "shard", shard)
+		owned[shard] = true
+	}
+	s.setOwned(ctx, owned)
+	return nil
+}
+
+// renewMemberLease function creates or renews the lease of this replica
+func (s *ShardManager) renewMemberLease(ctx context.Context, now time.Time) error {
+	var lease coordinationv1.Lease
+	err := s.Get(ctx, types.NamespacedName{Namespace: s.Namespace, Name: s.memberLeaseName()}, &lease)
+	if apierrors.IsNotFound(err) {
+		lease = coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      s.memberLeaseName(),
+				Namespace: s.Namespace,
+				Labels:    map[string]string{ShardLeaseTypeLabel: memberLeaseType},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To(s.Identity),
+				LeaseDurationSeconds: ptr.To(int32(s.LeaseDuration.Seconds())),
+				AcquireTime:          &metav1.MicroTime{Time: now},
+				RenewTime:            &metav1.MicroTime{Time: now},
+			},
+		}
+		return s.Create(ctx, &lease)
+	}
+	if err != nil {
+		return err
+	}
+	lease.Spec.RenewTime = &metav1.MicroTime{Time: now}
+	return s.Update(ctx, &lease)
+}
+
+// acquireShardLease function creates the shard lease or takes over the free one
+func (s *ShardManager) acquireShardLease(ctx context.Context, shard int, lease *coordinationv1.Lease, now time.Time) error {
+	if lease == nil {
+		return s.Create(ctx, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-shard-%d", s.LeasePrefix, shard),
+				Namespace: s.Namespace,
+				Labels: map[string]string{
+					ShardLeaseTypeLabel: shardLeaseType,
+					ShardLabel:          strconv.Itoa(shard),
+				},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To(s.Identity),
+				LeaseDurationSeconds: ptr.To(int32(s.LeaseDuration.Seconds())),
+				AcquireTime:          &metav1.MicroTime{Time: now},
+				RenewTime:            &metav1.MicroTime{Time: now},
+			},
+		})
+	}
+	lease.Spec.HolderIdentity = ptr.To(s.Identity)
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(s.LeaseDuration.Seconds()))
+	lease.Spec.AcquireTime = &metav1.MicroTime{Time: now}
+	lease.Spec.RenewTime = &metav1.MicroTime{Time: now}
+	lease.Spec.LeaseTransitions = ptr.To(ptr.Deref(lease.Spec.LeaseTransitions, 0) + 1)
+	// Update fails with conflict if another replica took it first
+	return s.Update(ctx, lease)
+}
+
+// release function gives up the owned shards and removes the member lease
+func (s *ShardManager) release(ctx context.Context) {
+	log := log.Logger(ctx, "controllers", "sharding", "release")
+	for _, shard := range s.OwnedShards() {
+		var lease coordinationv1.Lease
+		if err := s.Get(ctx, types.NamespacedName{Namespace: s.Namespace, Name: fmt.Sprintf("%s-shard-%d", s.LeasePrefix, shard)}, &lease); err != nil {
+			continue
+		}
+		if !s.isHolder(&lease) {
+			continue
+		}
+		lease.Spec.HolderIdentity = nil
+		if err := s.Update(ctx, &lease); err != nil {
+			log.Error(err, "unable to release the shard", "shard", shard)
+		}
+	}
+	s.setOwned(ctx, nil)
+	memberLease := &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Namespace: s.Namespace, Name: s.memberLeaseName()}}
+	if err := client.IgnoreNotFound(s.Delete(ctx, memberLease)); err != nil {
+		log.Error(err, "unable to delete the member lease")
+	}
+}
+
+// setOwned function updates the owned shards and metrics, and requeues the resources of the newly acquired shards
+func (s *ShardManager) setOwned(ctx context.Context, owned map[int]bool) {
+	s.mu.Lock()
+	acquired := make(map[int]bool)
+	for shard := range owned {
+		if !s.owned[shard] {
+			acquired[shard] = true
+		}
+	}
+	changed := len(acquired) > 0 || len(owned) != len(s.owned)
+	s.owned = owned
+	resyncs := s.resyncs
+	s.mu.Unlock()
+
+	for shard := 0; shard < s.ShardCount; shard++ {
+		value := 0.0
+		if owned[shard] {
+			value = 1
+		}
+		shardOwned.WithLabelValues(strconv.Itoa(shard)).Set(value)
+	}
+	if !changed {
+		return
+	}
+	shardRebalances.Inc()
+	if len(acquired) > 0 {
+		// Don't block the lease renewal while the controllers are catching up
+		go s.resync(ctx, acquired, resyncs)
+	}
+}
+
+// resync function requeues the resources in the namespaces of the acquired shards
+func (s *ShardManager) resync(ctx context.Context, acquired map[int]bool, resyncs []shardResync) {
+	log := log.Logger(ctx, "controllers", "sharding", "resync")
+	for _, r := range resyncs {
+		list := r.list.DeepCopyObject().(client.ObjectList)
+		if err := s.List(ctx, list); err != nil {
+			// Periodic resync of the manager picks them up eventually
+			log.Error(err, "unable to list the resources of the acquired shards")
+			continue
+		}
+		objs, err := meta.ExtractList(list)
+		if err != nil {
+			log.Error(err, "unable to list the resources of the acquired shards")
+			continue
+		}
+		for _, o := range objs {
+			obj, ok := o.(client.Object)
+			// Cluster scoped resources have alerts in the namespaces of every shard
+			if !ok || (obj.GetNamespace() != "" && !acquired[s.shardFor(ctx, obj.GetNamespace())]) {
+				continue
+			}
+			select {
+			case r.ch <- event.GenericEvent{Object: obj}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// requestsInNamespace function returns the requests for the resources of the list type in the namespace.
+// Cluster scoped resources are always returned since they have alerts in every namespace
+func (s *ShardManager) requestsInNamespace(ctx context.Context, list client.ObjectList, namespace string) []reconcile.Request {
+	log := log.Logger(ctx, "controllers", "sharding", "requestsInNamespace")
+	list = list.DeepCopyObject().(client.ObjectList)
+	if err := s.List(ctx, list); err != nil {
+		log.Error(err, "unable to list the resources", "namespace", namespace)
+		return nil
+	}
+	objs, err := meta.ExtractList(list)
+	if err != nil {
+		log.Error(err, "unable to list the resources", "namespace", namespace)
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, o := range objs {
+		obj, ok := o.(client.Object)
+		if !ok || (obj.GetNamespace() != "" && obj.GetNamespace() != namespace) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}})
+	}
+	return requests
+}
+
+func (s *ShardManager) memberLeaseName() string {
+	return fmt.Sprintf("%s-member-%s", s.LeasePrefix, s.Identity)
+}
+
+func (s *ShardManager) isHolder(lease *coordinationv1.Lease) bool {
+	return lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity == s.Identity
+}
+
+// isValid checks if the lease got renewed within its duration
+func (s *ShardManager) isValid(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return false
+	}
+	return lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second).After(now)
+}
+
+// isStale checks if the member lease is not renewed for long enough to be deleted
+func (s *ShardManager) isStale(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.RenewTime == nil {
+		return true
+	}
+	return lease.Spec.RenewTime.Add(staleMemberLeaseFactor * s.LeaseDuration).Before(now)
+}
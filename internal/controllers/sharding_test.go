@@ -0,0 +1,75 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/keikoproj/alert-manager/internal/controllers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ShardManager tests validate that the shards are rebalanced when the replicas join and leave
+var _ = Describe("ShardManager", Label("controller", "sharding"), func() {
+	const namespace = "default"
+
+	newShardManager := func(identity string) *controllers.ShardManager {
+		return &controllers.ShardManager{
+			Client:        k8sClient,
+			Namespace:     namespace,
+			LeasePrefix:   "sharding-test",
+			Identity:      identity,
+			ShardCount:    4,
+			LeaseDuration: 15 * time.Second,
+			RenewInterval: 5 * time.Second,
+		}
+	}
+
+	AfterEach(func() {
+		Expect(k8sClient.DeleteAllOf(context.Background(), &coordinationv1.Lease{}, client.InNamespace(namespace),
+			client.HasLabels{controllers.ShardLeaseTypeLabel})).To(Succeed())
+	})
+
+	It("Should rebalance the shards between the replicas", func() {
+		ctx := context.Background()
+		first := newShardManager("first")
+		Expect(first.Sync(ctx)).To(Succeed())
+		Expect(first.OwnedShards()).To(Equal([]int{0, 1, 2, 3}))
+		Expect(first.Owns(ctx, "team-a")).To(BeTrue())
+
+		// second replica joins. Shards are still owned by the first one
+		second := newShardManager("second")
+		Expect(second.Sync(ctx)).To(Succeed())
+		Expect(second.OwnedShards()).To(BeEmpty())
+
+		// first replica gives up the shards above its fair share
+		Expect(first.Sync(ctx)).To(Succeed())
+		Expect(first.OwnedShards()).To(Equal([]int{0, 1}))
+		Expect(second.Sync(ctx)).To(Succeed())
+		Expect(second.OwnedShards()).To(Equal([]int{2, 3}))
+		Expect(first.Owns(ctx, "team-a")).NotTo(Equal(second.Owns(ctx, "team-a")))
+	})
+
+	It("Should own all the namespaces if sharding is not enabled", func() {
+		var shards *controllers.ShardManager
+		Expect(shards.Owns(context.Background(), "team-a")).To(BeTrue())
+	})
+})
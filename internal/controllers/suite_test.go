@@ -28,6 +28,7 @@ import (
 	"github.com/keikoproj/alert-manager/internal/controllers"
 	"github.com/keikoproj/alert-manager/internal/controllers/common"
 	mock_wavefront "github.com/keikoproj/alert-manager/internal/controllers/mocks"
+	mock_webhook "github.com/keikoproj/alert-manager/internal/controllers/mocks/webhook"
 	"github.com/keikoproj/alert-manager/pkg/k8s"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -52,6 +53,7 @@ var cfg *rest.Config
 var k8sClient client.Client
 var testEnv *envtest.Environment
 var mockWavefront *mock_wavefront.MockInterface
+var mockWebhook *mock_webhook.MockInterface
 var mgrCtx context.Context
 
 // https://github.com/kubernetes-sigs/controller-runtime/issues/1571
@@ -115,6 +117,7 @@ var _ = BeforeSuite(func() {
 	defer mockCtrl.Finish()
 
 	mockWavefront = mock_wavefront.NewMockInterface(mockCtrl)
+	mockWebhook = mock_webhook.NewMockInterface(mockCtrl)
 
 	// Create a kubernetes clientset for the k8s.Client
 	clientset, err := kubernetes.NewForConfig(cfg)
@@ -151,6 +154,63 @@ var _ = BeforeSuite(func() {
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	// Set up ClusterWavefrontAlertReconciler with mocked dependencies
+	err = (&controllers.ClusterWavefrontAlertReconciler{
+		Client:          k8sManager.GetClient(),
+		Log:             ctrl.Log.WithName("test-clusterwavefrontalert-controller"),
+		Scheme:          k8sManager.GetScheme(),
+		CommonClient:    &commonClient,
+		WavefrontClient: mockWavefront,
+		Recorder:        k8sCl.SetUpEventHandler(context.Background()),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	// Set up WavefrontDashboardReconciler with mocked dependencies
+	err = (&controllers.WavefrontDashboardReconciler{
+		Client:          k8sManager.GetClient(),
+		Log:             ctrl.Log.WithName("test-wavefrontdashboard-controller"),
+		Scheme:          k8sManager.GetScheme(),
+		CommonClient:    &commonClient,
+		WavefrontClient: mockWavefront,
+		Recorder:        k8sCl.SetUpEventHandler(context.Background()),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	// Set up WavefrontDerivedMetricReconciler with mocked dependencies
+	err = (&controllers.WavefrontDerivedMetricReconciler{
+		Client:          k8sManager.GetClient(),
+		Log:             ctrl.Log.WithName("test-wavefrontderivedmetric-controller"),
+		Scheme:          k8sManager.GetScheme(),
+		CommonClient:    &commonClient,
+		WavefrontClient: mockWavefront,
+		Recorder:        k8sCl.SetUpEventHandler(context.Background()),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	// Set up WebhookAlertReconciler with mocked dependencies
+	err = (&controllers.WebhookAlertReconciler{
+		Client:        k8sManager.GetClient(),
+		Log:           ctrl.Log.WithName("test-webhookalert-controller"),
+		Scheme:        k8sManager.GetScheme(),
+		CommonClient:  &commonClient,
+		K8sClient:     &k8sCl,
+		WebhookClient: mockWebhook,
+		Recorder:      k8sCl.SetUpEventHandler(context.Background()),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	// Set up WorkloadReconcilers
+	for _, kind := range []string{controllers.DeploymentKind, controllers.StatefulSetKind, controllers.NamespaceKind} {
+		err = (&controllers.WorkloadReconciler{
+			Client:   k8sManager.GetClient(),
+			Log:      ctrl.Log.WithName("test-workload-controller"),
+			Scheme:   k8sManager.GetScheme(),
+			Recorder: k8sCl.SetUpEventHandler(context.Background()),
+			Kind:     kind,
+		}).SetupWithManager(k8sManager)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
 	// Start the controller manager in a separate goroutine
 	go func() {
 		mgrCtx, cancelFunc = context.WithCancel(context.Background())
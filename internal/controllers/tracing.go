@@ -0,0 +1,44 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/keikoproj/alert-manager/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// startReconcileSpan function starts the span of the reconcile. Request id of the reconcile is added to all the spans started from it
+func startReconcileSpan(ctx context.Context, kind string, req ctrl.Request) (context.Context, trace.Span) {
+	// controllers set the request id either with WithRequestID or with the plain request_id key
+	id, ok := GetRequestID(ctx)
+	if !ok {
+		id, ok = ctx.Value(requestId).(uuid.UUID)
+	}
+	if ok {
+		ctx = tracing.WithRequestID(ctx, id.String())
+	}
+	attrs := []attribute.KeyValue{attribute.String("kind", kind), attribute.String("name", req.Name)}
+	if req.Namespace != "" {
+		attrs = append(attrs, attribute.String("namespace", req.Namespace))
+	}
+	return tracing.Start(ctx, kind+".Reconcile", attrs...)
+}
@@ -21,15 +21,20 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
 	"github.com/keikoproj/alert-manager/internal/config"
 	"github.com/keikoproj/alert-manager/internal/utils"
+	"github.com/keikoproj/alert-manager/pkg/audit"
 	"github.com/keikoproj/alert-manager/pkg/log"
+	"github.com/keikoproj/alert-manager/pkg/tracing"
 	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	"go.opentelemetry.io/otel/attribute"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -59,9 +64,16 @@ type WavefrontAlertReconciler struct {
 	Recorder        record.EventRecorder
 	CommonClient    *controllercommon.Client
 	WavefrontClient wavefront.Interface
+	//DryRun previews the changes for all the wavefront alerts without calling wavefront create, update or delete APIs
+	DryRun bool
+	//Shards limits the reconciles to the namespaces of the owned shards. nil if sharding is not enabled
+	Shards *ShardManager
+	//Namespaces limits the reconciles to the watched namespaces. nil if all the namespaces are watched
+	Namespaces *NamespaceFilter
 }
 
 //+kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch
 //+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
 //+kubebuilder:rbac:groups=alertmanager.keikoproj.io,resources=wavefrontalerts,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=alertmanager.keikoproj.io,resources=wavefrontalerts/status,verbs=get;update;patch
@@ -85,14 +97,35 @@ func (r *WavefrontAlertReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	}()
 
 	ctx = context.WithValue(ctx, requestId, uuid.New())
+	ctx, span := startReconcileSpan(ctx, alertmanagerv1alpha1.WavefrontAlertKind, req)
+	defer span.End()
 	log := log.Logger(ctx, "controllers", "wavefrontalert_controller", "Reconcile")
 	log = log.WithValues("wavefrontalert_cr", req.NamespacedName)
 	log.Info("Start of the request")
 
+	if !r.Namespaces.Allows(ctx, req.Namespace) {
+		log.V(1).Info("namespace is not watched by this instance. skipping")
+		return ctrl.Result{}, nil
+	}
+	if !r.Shards.Owns(ctx, req.Namespace) {
+		log.V(1).Info("namespace belongs to a shard owned by another replica. skipping")
+		return ctrl.Result{}, nil
+	}
+
 	var wfAlert alertmanagerv1alpha1.WavefrontAlert
-	if err := r.Get(ctx, req.NamespacedName, &wfAlert); err != nil {
+	// reconciled resource is the template of the alerts rolled out below
+	_, templateSpan := tracing.Start(ctx, "fetch template", attribute.String("template", req.Name), attribute.String("kind", alertmanagerv1alpha1.WavefrontAlertKind))
+	err := r.Get(ctx, req.NamespacedName, &wfAlert)
+	tracing.End(templateSpan, err)
+	if err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	// wavefront changes are audited as done by the resource
+	ctx = audit.WithActor(ctx, audit.ActorFor(&wfAlert, alertmanagerv1alpha1.WavefrontAlertKind))
+	// Paused resource is left alone, even if it is being deleted, until the annotation is removed
+	if controllercommon.IsPaused(&wfAlert) {
+		return r.CommonClient.Pause(ctx, &wfAlert, &wfAlert.Status.State)
+	}
 	//var status alertmanagerv1alpha1.WavefrontAlertStatus
 	//Main responsibilities of the Wavefront Alert Controller
 	// Check if it is delete request
@@ -128,7 +161,26 @@ func (r *WavefrontAlertReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		exportedParamslength = len(wfAlert.Spec.ExportedParams)
 	}
 
-	if wfAlert.Status.ObservedGeneration == wfAlert.ObjectMeta.Generation && wfAlert.Status.State != alertmanagerv1alpha1.Error {
+	// Alerts are pushed again if requested or once the resource is resumed even if there is no change in the spec
+	request, forced := controllercommon.ReconcileRequested(&wfAlert, wfAlert.Status.LastHandledReconcileRequest)
+	resumed := wfAlert.Status.State == alertmanagerv1alpha1.Paused
+	if resumed {
+		log.Info("resource got resumed")
+		// State is set again by the alerts which are not skipped
+		wfAlert.Status.State = alertmanagerv1alpha1.Ready
+	}
+	if forced {
+		log.Info("reconcile requested. pushing the alerts again", "request", request)
+		wfAlert.Status.LastHandledReconcileRequest = request
+		// Template change is rolled out again to all the alerts configs
+		wfAlert.Status.Rollout = nil
+		controllercommon.ResetPayloadHashes(wfAlert.Status.AlertsStatus)
+	}
+	rolloutUnfinished := controllercommon.RolloutUnfinished(wfAlert.Status.Rollout)
+
+	// Previewed change must be applied once dry-run is turned off even if there is no change in the spec
+	if wfAlert.Status.ObservedGeneration == wfAlert.ObjectMeta.Generation && wfAlert.Status.State != alertmanagerv1alpha1.Error && wfAlert.Status.State != alertmanagerv1alpha1.DryRun &&
+		!forced && !resumed && !rolloutUnfinished {
 		proceed = false
 	}
 
@@ -151,42 +203,32 @@ func (r *WavefrontAlertReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	// If there is a change in wavefront alert spec (ObservedGeneration) and NO CHANGE in exported Params,
 	// we need to loop through all the alertsconfig CR under status
 	// and fill up with the substituted params value then apply the change in wavefront for individual alert
-	if exportedParamslength > 0 && proceed && wfAlert.Status.ObservedGeneration != wfAlert.ObjectMeta.Generation {
+	// Requested, resumed and unfinished rollouts are rolled out again for the same generation
+	if exportedParamslength > 0 && proceed && (wfAlert.Status.ObservedGeneration != wfAlert.ObjectMeta.Generation || forced || resumed || rolloutUnfinished) {
 		// wavefrontalerts spec change
 		log.Info("wavefrontalerts spec was changed, processing to update individual alert that associated with it")
 		wfAlert.Status.LastChangeChecksum = lastChangeChecksum
-		for _, c := range wfAlert.Status.AlertsStatus {
-			// Get alertsconfig CR
-			alertConfigNamespacedName := types.NamespacedName{Namespace: req.Namespace, Name: c.AssociatedAlertsConfig.CR}
-			var alertsConfig alertmanagerv1alpha1.AlertsConfig
-			if err := r.Get(ctx, alertConfigNamespacedName, &alertsConfig); err != nil {
-				return r.UpdateIndividualWavefrontAlertStatusError(ctx, &wfAlert, alertmanagerv1alpha1.Error, err, errRequeueTime)
-			}
-			err := r.UpdateIndividualWavefrontAlert(ctx, req, c, alertsConfig, wfAlert)
-			if err != nil {
-				state := alertmanagerv1alpha1.Error
-				if strings.Contains(err.Error(), "Exceeded limit setting") {
-					state = alertmanagerv1alpha1.ClientExceededLimit
+		if controllercommon.IsDryRun(&wfAlert, r.DryRun) {
+			for key, c := range wfAlert.Status.AlertsStatus {
+				// Get alertsconfig CR
+				alertConfigNamespacedName := types.NamespacedName{Namespace: req.Namespace, Name: c.AssociatedAlertsConfig.CR}
+				var alertsConfig alertmanagerv1alpha1.AlertsConfig
+				if err := r.Get(ctx, alertConfigNamespacedName, &alertsConfig); err != nil {
+					return r.UpdateIndividualWavefrontAlertStatusError(ctx, &wfAlert, alertmanagerv1alpha1.Error, err, errRequeueTime)
 				}
-				// Update the state to be error for each alert
-				c.State = state
-				if err := r.CommonClient.PatchWfAlertAndAlertsConfigStatus(ctx, c.State, &wfAlert, &alertsConfig, c, errRequeueTime); err != nil {
-					log.Error(err, "unable to patch wfalert and alertsconfig status objects")
-					return r.UpdateIndividualWavefrontAlertStatusError(ctx, &wfAlert, state, err, errRequeueTime)
+				if _, err := updateIndividualAlert(ctx, r.WavefrontClient, &c, alertsConfig, wfAlert.DeepCopy(), true); err != nil {
+					return r.UpdateIndividualWavefrontAlertStatusError(ctx, &wfAlert, alertmanagerv1alpha1.Error, err, errRequeueTime)
 				}
-				return r.UpdateIndividualWavefrontAlertStatusError(ctx, &wfAlert, state, err, errRequeueTime)
-			}
-			// Update the state to be ready for each alert
-			c.State = alertmanagerv1alpha1.Ready
-			if err := r.CommonClient.PatchWfAlertAndAlertsConfigStatus(ctx, c.State, &wfAlert, &alertsConfig, c); err != nil {
-				log.Error(err, "unable to patch wfalert and alertsconfig status objects")
-				return r.UpdateIndividualWavefrontAlertStatusError(ctx, &wfAlert, alertmanagerv1alpha1.Error, err, errRequeueTime)
+				wfAlert.Status.AlertsStatus[key] = c
+				r.CommonClient.RecordDryRun(ctx, &wfAlert, c.Name, c.DryRun)
 			}
+			// Observed generation is not updated so the previewed change gets applied once dry-run is turned off
+			wfAlert.Status.State = alertmanagerv1alpha1.DryRun
+			return r.CommonClient.UpdateStatus(ctx, &wfAlert, alertmanagerv1alpha1.DryRun)
 		}
-		wfAlert.Status.ObservedGeneration = wfAlert.ObjectMeta.Generation
-		// We are going to stop here because we already updated individual alerts that associate with
+		// We are going to stop here because we update individual alerts that associate with
 		// this wavefront alert template
-		return r.CommonClient.UpdateStatus(ctx, &wfAlert, alertmanagerv1alpha1.Ready, errRequeueTime)
+		return r.rolloutTemplateChange(ctx, req, &wfAlert)
 	}
 
 	wfAlert.Status.ObservedGeneration = wfAlert.ObjectMeta.Generation
@@ -215,19 +257,26 @@ func (r *WavefrontAlertReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return r.UpdateIndividualWavefrontAlertStatusError(ctx, &wfAlert, alertmanagerv1alpha1.Error, err, errRequeueTime)
 	}
 
+	if controllercommon.IsDryRun(&wfAlert, r.DryRun) {
+		return r.previewAlert(ctx, &wfAlert, alert)
+	}
+
 	// so simple validation is done so lets Handle reconcile
 	// Keep it simple - If already exist- call updateAlert with existing alertID
 	// If alert doesn't exist- create Alert
 	// TODO: In future, check if we need to do GET API call to get the existing alert and
 	//  compare it with the request to see if changes are really needed
 
-	if len(wfAlert.Status.AlertsStatus) == 0 {
+	if !hasAlertID(wfAlert.Status.AlertsStatus) {
 		// New alert
 		// First time use case
 		// Lets create an alert
 		var alert wf.Alert
 		r.convertAlertCR(ctx, &wfAlert, &alert)
 		log.V(1).Info("alert values", "alertObj", alert)
+		r.CommonClient.CheckPreviewedPayload(ctx, &wfAlert, wfAlert.Status.AlertsStatus[alert.Name], &alert)
+		// create response overwrites the alert so calculate the checksum before
+		payloadHash := wavefront.PayloadHash(ctx, &alert)
 		if err := r.WavefrontClient.CreateAlert(ctx, &alert); err != nil {
 			state := alertmanagerv1alpha1.Error
 			if strings.Contains(err.Error(), "Exceeded limit setting") {
@@ -244,7 +293,13 @@ func (r *WavefrontAlertReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			Name:               alert.Name,
 			Link:               fmt.Sprintf("https://%s/alerts/%s", config.Props.WavefrontAPIUrl(), *alert.ID),
 			LastChangeChecksum: lastChangeChecksum,
+			State:              alertmanagerv1alpha1.Ready,
+			PayloadHash:        payloadHash,
 		}
+		previous := wfAlert.Status.AlertsStatus[alert.Name]
+		alertResponse.History = previous.History
+		r.CommonClient.RecordAlertChange(ctx, controllercommon.AlertOwner(&wfAlert, alertmanagerv1alpha1.WavefrontAlertKind, wfAlert.Name), &alertResponse,
+			controllercommon.NewAlertChange(controllercommon.CreateOperation(previous), &wfAlert, alertmanagerv1alpha1.WavefrontAlertKind, nil))
 		alertsStatus := make(map[string]alertmanagerv1alpha1.AlertStatus)
 		alertsStatus[alertResponse.Name] = alertResponse
 		wfAlert.Status.RetryCount = 0
@@ -256,6 +311,7 @@ func (r *WavefrontAlertReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	// existing alert - Perform the updateAlert one by one
 	// this is for standalone alerts not alertsconfig scenario
 	currStatus := wfAlert.Status.AlertsStatus
+	var summary controllercommon.UpdateSummary
 	for _, a := range wfAlert.Status.AlertsStatus {
 		// Create a local copy of ID to avoid memory aliasing in loop
 		id := a.ID
@@ -264,11 +320,20 @@ func (r *WavefrontAlertReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		}
 		r.convertAlertCR(ctx, &wfAlert, &alert)
 		state := alertmanagerv1alpha1.Ready
+		r.CommonClient.CheckPreviewedPayload(ctx, &wfAlert, a, &alert)
 		respAlert := a
-		// TODO: Only do the UpdateAlert if there is a difference between parent lastChangeChecksum and child lastChangeChecksum- This could be in a scenario
-		//  where it updated 99 out of 100 child alerts and 1 got failed and it got requeued. so instead of trying to update 100 again lets just do only 1 api
-		// call update api
-		if err := r.WavefrontClient.UpdateAlert(ctx, &alert); err != nil {
+		respAlert.DryRun = nil
+		// Only the children with a different payload are updated. This could be in a scenario
+		// where it updated 99 out of 100 child alerts and 1 got failed and it got requeued
+		payloadHash := wavefront.PayloadHash(ctx, &alert)
+		if a.PayloadHash == payloadHash && a.State == alertmanagerv1alpha1.Ready {
+			log.V(1).Info("rendered alert is same as the last applied one. skipping", "alertID", a.ID)
+			summary.Add(false)
+			currStatus[respAlert.Name] = respAlert
+			continue
+		}
+		diff, err := r.WavefrontClient.UpdateAlert(ctx, &alert)
+		if err != nil {
 			r.Recorder.Event(&wfAlert, v1.EventTypeWarning, err.Error(), "unable to update the alert")
 			state = alertmanagerv1alpha1.Error
 			if strings.Contains(err.Error(), "Exceeded limit setting") {
@@ -281,6 +346,13 @@ func (r *WavefrontAlertReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			// if even one of the child got failed, make parent status as error
 			wfAlert.Status.State = state
 			wfAlert.Status.RetryCount = wfAlert.Status.RetryCount + 1
+		} else {
+			respAlert.PayloadHash = payloadHash
+			summary.Add(len(diff) > 0)
+			if len(diff) > 0 {
+				r.CommonClient.RecordAlertChange(ctx, controllercommon.AlertOwner(&wfAlert, alertmanagerv1alpha1.WavefrontAlertKind, wfAlert.Name), &respAlert,
+					controllercommon.NewAlertChange(alertmanagerv1alpha1.AlertUpdate, &wfAlert, alertmanagerv1alpha1.WavefrontAlertKind, diff))
+			}
 		}
 		log.Info("alert ids before and after", "before", a.ID, "after", alert.ID)
 		respAlert.State = state
@@ -293,11 +365,146 @@ func (r *WavefrontAlertReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		wfAlert.Status.RetryCount = 0
 		wfAlert.Status.ErrorDescription = ""
 	}
+	r.CommonClient.RecordUpdateSummary(ctx, &wfAlert, summary)
 	wfAlert.Status.AlertsStatus = currStatus
 	wfAlert.Status.ObservedGeneration = wfAlert.ObjectMeta.Generation
 	return r.CommonClient.UpdateStatus(ctx, &wfAlert, wfAlert.Status.State, errRequeueTime)
 }
 
+// rolloutTemplateChange function updates the alerts configs using the template in batches as per the rollout strategy.
+// Progress is kept in the status so the rollout is resumed from where it was left after a pause or a controller restart
+func (r *WavefrontAlertReconciler) rolloutTemplateChange(ctx context.Context, req ctrl.Request, wfAlert *alertmanagerv1alpha1.WavefrontAlert) (ctrl.Result, error) {
+	log := log.Logger(ctx, "controllers", "wavefrontalert_controller", "rolloutTemplateChange")
+	log = log.WithValues("wavefrontalert_cr", wfAlert.Name, "namespace", wfAlert.Namespace)
+	strategy := wfAlert.Spec.RolloutStrategy
+	rollout := wfAlert.Status.Rollout
+	if rollout == nil || rollout.Generation != wfAlert.ObjectMeta.Generation {
+		// New template change. Any rollout in progress for the previous generation is superseded
+		alertsConfigs := make([]string, 0, len(wfAlert.Status.AlertsStatus))
+		for key := range wfAlert.Status.AlertsStatus {
+			alertsConfigs = append(alertsConfigs, key)
+		}
+		rollout = controllercommon.NewRollout(wfAlert.ObjectMeta.Generation, alertsConfigs)
+		log.Info("starting the rollout of the template change", "generation", rollout.Generation, "alertsConfigs", len(alertsConfigs))
+	}
+
+	switch rollout.Phase {
+	case alertmanagerv1alpha1.RolloutHalted:
+		log.Info("rollout is halted. template must be changed to start a new rollout", "reason", rollout.Message)
+		return ctrl.Result{}, nil
+	case alertmanagerv1alpha1.RolloutPaused:
+		if wait := time.Until(rollout.NextBatchTime.Time); wait > 0 {
+			log.Info("rollout is paused", "nextBatchTime", rollout.NextBatchTime)
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+	}
+	rollout.Phase = alertmanagerv1alpha1.RolloutInProgress
+	rollout.NextBatchTime = nil
+
+	var summary controllercommon.UpdateSummary
+	for batch := controllercommon.NextRolloutBatch(rollout, strategy); len(batch) > 0; batch = controllercommon.NextRolloutBatch(rollout, strategy) {
+		log.Info("rolling out the template change", "batch", batch)
+		for _, key := range batch {
+			updated, err := r.rolloutAlertsConfig(ctx, req, wfAlert, key)
+			if err != nil {
+				log.Error(err, "unable to roll out the template change", "alertsConfig", key)
+				rollout.AlertsConfigs[key] = alertmanagerv1alpha1.RolloutFailed
+				continue
+			}
+			summary.Add(updated)
+			rollout.AlertsConfigs[key] = alertmanagerv1alpha1.RolloutUpdated
+		}
+
+		if controllercommon.ExceedsMaxFailures(rollout, strategy) {
+			rollout.Phase = alertmanagerv1alpha1.RolloutHalted
+			rollout.Message = fmt.Sprintf("%d alerts configs failed which is more than maxFailures %d", controllercommon.CountRollout(rollout, alertmanagerv1alpha1.RolloutFailed), *strategy.MaxFailures)
+			r.Recorder.Event(wfAlert, v1.EventTypeWarning, "RolloutHalted", rollout.Message)
+			r.CommonClient.RecordUpdateSummary(ctx, wfAlert, summary)
+			wfAlert.Status.Rollout = rollout
+			wfAlert.Status.State = alertmanagerv1alpha1.Error
+			wfAlert.Status.ErrorDescription = rollout.Message
+			// There is no use of requeue until the template is fixed
+			return ctrl.Result{}, r.Status().Update(ctx, wfAlert)
+		}
+
+		if strategy != nil && strategy.PauseSeconds > 0 && controllercommon.CountRollout(rollout, alertmanagerv1alpha1.RolloutPending) > 0 {
+			pause := time.Duration(strategy.PauseSeconds) * time.Second
+			nextBatchTime := metav1.NewTime(time.Now().Add(pause))
+			rollout.Phase = alertmanagerv1alpha1.RolloutPaused
+			rollout.NextBatchTime = &nextBatchTime
+			r.CommonClient.RecordUpdateSummary(ctx, wfAlert, summary)
+			wfAlert.Status.Rollout = rollout
+			wfAlert.Status.State = alertmanagerv1alpha1.Updating
+			if err := r.Status().Update(ctx, wfAlert); err != nil {
+				log.Error(err, "unable to save the rollout progress")
+			}
+			return ctrl.Result{RequeueAfter: pause}, nil
+		}
+	}
+	r.CommonClient.RecordUpdateSummary(ctx, wfAlert, summary)
+
+	if failed := controllercommon.CountRollout(rollout, alertmanagerv1alpha1.RolloutFailed); failed > 0 {
+		// Failed alerts configs are retried in the next reconcile
+		for key, state := range rollout.AlertsConfigs {
+			if state == alertmanagerv1alpha1.RolloutFailed {
+				rollout.AlertsConfigs[key] = alertmanagerv1alpha1.RolloutPending
+			}
+		}
+		wfAlert.Status.Rollout = rollout
+		wfAlert.Status.RetryCount = wfAlert.Status.RetryCount + 1
+		wfAlert.Status.ErrorDescription = fmt.Sprintf("unable to roll out the template change to %d alerts configs", failed)
+		return r.UpdateIndividualWavefrontAlertStatusError(ctx, wfAlert, alertmanagerv1alpha1.Error, errors.New(wfAlert.Status.ErrorDescription), errRequeueTime)
+	}
+
+	rollout.Phase = alertmanagerv1alpha1.RolloutCompleted
+	wfAlert.Status.Rollout = rollout
+	wfAlert.Status.RetryCount = 0
+	wfAlert.Status.ErrorDescription = ""
+	wfAlert.Status.State = alertmanagerv1alpha1.Ready
+	wfAlert.Status.ObservedGeneration = wfAlert.ObjectMeta.Generation
+	return r.CommonClient.UpdateStatus(ctx, wfAlert, alertmanagerv1alpha1.Ready, errRequeueTime)
+}
+
+// rolloutAlertsConfig function updates the alert of an alerts config with the template change and patches
+// both wavefront alert and alerts config status with the result
+func (r *WavefrontAlertReconciler) rolloutAlertsConfig(ctx context.Context, req ctrl.Request, wfAlert *alertmanagerv1alpha1.WavefrontAlert, key string) (bool, error) {
+	c := wfAlert.Status.AlertsStatus[key]
+	// Get alertsconfig CR
+	alertConfigNamespacedName := types.NamespacedName{Namespace: req.Namespace, Name: c.AssociatedAlertsConfig.CR}
+	var alertsConfig alertmanagerv1alpha1.AlertsConfig
+	if err := r.Get(ctx, alertConfigNamespacedName, &alertsConfig); err != nil {
+		return false, err
+	}
+	if controllercommon.IsPaused(&alertsConfig) {
+		// alerts config renders all its alerts again once it is resumed
+		log.Logger(ctx, "controllers", "wavefrontalert_controller", "rolloutAlertsConfig").Info("alerts config is paused. skipping", "alertsConfig", alertsConfig.Name)
+		return false, nil
+	}
+	c.DryRun = nil
+	// Spec is rendered in place, so every alerts config gets its own copy of the template
+	updated, err := r.UpdateIndividualWavefrontAlert(ctx, req, &c, alertsConfig, *wfAlert.DeepCopy())
+	if err != nil {
+		state := alertmanagerv1alpha1.Error
+		if strings.Contains(err.Error(), "Exceeded limit setting") {
+			state = alertmanagerv1alpha1.ClientExceededLimit
+		}
+		// Update the state to be error for the alert
+		c.State = state
+		c.ErrorDescription = err.Error()
+		if patchErr := r.CommonClient.PatchWfAlertAndAlertsConfigStatus(ctx, c.State, wfAlert, &alertsConfig, c, errRequeueTime); patchErr != nil {
+			log.Logger(ctx, "controllers", "wavefrontalert_controller", "rolloutAlertsConfig").Error(patchErr, "unable to patch wfalert and alertsconfig status objects")
+		}
+		return false, err
+	}
+	// Update the state to be ready for the alert
+	c.State = alertmanagerv1alpha1.Ready
+	c.ErrorDescription = ""
+	if err := r.CommonClient.PatchWfAlertAndAlertsConfigStatus(ctx, c.State, wfAlert, &alertsConfig, c); err != nil {
+		return updated, err
+	}
+	return updated, nil
+}
+
 // PatchIndividualAlertsStatusError function is a utility function to patch the error status
 // We use status patch instead of status update to avoid any overwrite between two threads when alertsConfig CR has multiple alert configs
 func (r *WavefrontAlertReconciler) PatchIndividualAlertsStatusError(ctx context.Context, wfAlert *alertmanagerv1alpha1.WavefrontAlert, alertName string, state alertmanagerv1alpha1.State, err error, requeueTime ...float64) (ctrl.Result, error) {
@@ -320,21 +527,69 @@ func (r *WavefrontAlertReconciler) PatchIndividualAlertsStatusError(ctx context.
 func (r *WavefrontAlertReconciler) HandleDelete(ctx context.Context, wfAlert *alertmanagerv1alpha1.WavefrontAlert) error {
 	log := log.Logger(ctx, "controllers", "wavefrontalert_controller", "HandleDelete")
 	log = log.WithValues("wavefrontalert_cr", wfAlert.Name, "namespace", wfAlert.Namespace)
+	if controllercommon.IsDeletionProtected(wfAlert) {
+		// Finalizer is kept. Removing the annotation triggers the reconcile again
+		r.CommonClient.RecordDeletionProtected(ctx, wfAlert)
+		return nil
+	}
+	if controllercommon.IsDryRun(wfAlert, r.DryRun) {
+		// Finalizer is kept so the alerts get deleted once dry-run is turned off
+		for name, alert := range wfAlert.Status.AlertsStatus {
+			if alert.ID == "" {
+				continue
+			}
+			alert.DryRun = controllercommon.PreviewDelete()
+			wfAlert.Status.AlertsStatus[name] = alert
+			r.CommonClient.RecordDryRun(ctx, wfAlert, alert.Name, alert.DryRun)
+		}
+		wfAlert.Status.State = alertmanagerv1alpha1.DryRun
+		_, err := r.CommonClient.UpdateStatus(ctx, wfAlert, alertmanagerv1alpha1.DryRun)
+		return err
+	}
 	// Lets check the status of the CR and
 	// retrieve all the alerts associated with this CR and delete it
 	//Check if any alerts were created with this config
-	if len(wfAlert.Status.AlertsStatus) > 0 {
-		//Call wavefront api and delete the alerts one by one
-		for _, alert := range wfAlert.Status.AlertsStatus {
-			if alert.ID != "" {
-				if err := r.WavefrontClient.DeleteAlert(ctx, alert.ID); err != nil {
-					log.Error(err, "skipping alert deletion", "alertID", alert.ID)
-					// Just skip it for now
-					// this is too opinionated but we don't want to stop the delete execution for other alerts as well
-					// if there is any valid reasons not to skip it, we can look into it in future
-				}
+	failed := 0
+	//Call wavefront api and delete the alerts one by one
+	for name, alert := range wfAlert.Status.AlertsStatus {
+		policy := controllercommon.GetDeletionPolicy(wfAlert.Spec.DeletionPolicy)
+		var err error
+		if alert.AssociatedAlertsConfig.CR != "" {
+			// alert created by an alerts config from this template follows the alerts config deletion policy
+			var protected bool
+			policy, protected, err = r.CommonClient.TemplateAlertDeletionPolicy(ctx, wfAlert.Namespace, alert.AssociatedAlertsConfig.CR, wfAlert.Name, wfAlert.Spec.DeletionPolicy)
+			if err == nil && protected {
+				err = fmt.Errorf("alerts config %s is deletion protected", alert.AssociatedAlertsConfig.CR)
 			}
 		}
+		if err == nil {
+			err = controllercommon.ApplyDeletionPolicy(ctx, r.WavefrontClient, alert.ID, policy)
+		}
+		if err != nil {
+			// Continue with the other alerts. Failed ones are kept in the status and retried
+			log.Error(err, "unable to delete the alert", "alertID", alert.ID)
+			alert.State = alertmanagerv1alpha1.Error
+			alert.ErrorDescription = err.Error()
+			wfAlert.Status.AlertsStatus[name] = alert
+			failed++
+			continue
+		}
+		delete(wfAlert.Status.AlertsStatus, name)
+		owner := controllercommon.AlertOwner(wfAlert, alertmanagerv1alpha1.WavefrontAlertKind, wfAlert.Name)
+		if alert.AssociatedAlertsConfig.CR != "" {
+			// alert created by an alerts config from this template
+			owner = controllercommon.AlertOwner(&metav1.ObjectMeta{Namespace: wfAlert.Namespace, Name: alert.AssociatedAlertsConfig.CR}, alertmanagerv1alpha1.AlertsConfigKind, wfAlert.Name)
+		}
+		r.CommonClient.RecordAlertChange(ctx, owner, nil,
+			controllercommon.NewAlertChange(alertmanagerv1alpha1.AlertDelete, wfAlert, alertmanagerv1alpha1.WavefrontAlertKind, nil))
+	}
+	if failed > 0 {
+		err := fmt.Errorf("unable to delete %d alerts from wavefront", failed)
+		wfAlert.Status.State = alertmanagerv1alpha1.Error
+		wfAlert.Status.ErrorDescription = err.Error()
+		wfAlert.Status.RetryCount = wfAlert.Status.RetryCount + 1
+		r.CommonClient.UpdateStatus(ctx, wfAlert, alertmanagerv1alpha1.Error)
+		return err
 	}
 
 	// Ok. Lets delete the finalizer so controller can delete the custom object
@@ -346,6 +601,51 @@ func (r *WavefrontAlertReconciler) HandleDelete(ctx context.Context, wfAlert *al
 	return nil
 }
 
+// previewAlert function records the dry-run preview of the standalone alert without calling wavefront create or update APIs
+func (r *WavefrontAlertReconciler) previewAlert(ctx context.Context, wfAlert *alertmanagerv1alpha1.WavefrontAlert, alert wf.Alert) (ctrl.Result, error) {
+	if !hasAlertID(wfAlert.Status.AlertsStatus) {
+		preview, err := controllercommon.PreviewAlert(ctx, r.WavefrontClient, &alert)
+		if err != nil {
+			return r.UpdateIndividualWavefrontAlertStatusError(ctx, wfAlert, alertmanagerv1alpha1.Error, err, errRequeueTime)
+		}
+		// Alert ID is empty so the alert gets created once dry-run is turned off
+		wfAlert.Status.AlertsStatus = map[string]alertmanagerv1alpha1.AlertStatus{
+			alert.Name: {
+				Name:   alert.Name,
+				State:  alertmanagerv1alpha1.DryRun,
+				DryRun: preview,
+			},
+		}
+		r.CommonClient.RecordDryRun(ctx, wfAlert, alert.Name, preview)
+	}
+	for name, a := range wfAlert.Status.AlertsStatus {
+		if a.ID == "" {
+			continue
+		}
+		id := a.ID
+		alert.ID = &id
+		preview, err := controllercommon.PreviewAlert(ctx, r.WavefrontClient, &alert)
+		if err != nil {
+			return r.UpdateIndividualWavefrontAlertStatusError(ctx, wfAlert, alertmanagerv1alpha1.Error, err, errRequeueTime)
+		}
+		a.DryRun = preview
+		wfAlert.Status.AlertsStatus[name] = a
+		r.CommonClient.RecordDryRun(ctx, wfAlert, a.Name, preview)
+	}
+	wfAlert.Status.State = alertmanagerv1alpha1.DryRun
+	return r.CommonClient.UpdateStatus(ctx, wfAlert, alertmanagerv1alpha1.DryRun)
+}
+
+// hasAlertID checks if any of the alerts got created in wavefront
+func hasAlertID(alertsStatus map[string]alertmanagerv1alpha1.AlertStatus) bool {
+	for _, a := range alertsStatus {
+		if a.ID != "" {
+			return true
+		}
+	}
+	return false
+}
+
 // convertAlertCR converts alert CR to wf.Alert
 func (r *WavefrontAlertReconciler) convertAlertCR(ctx context.Context, wfAlert *alertmanagerv1alpha1.WavefrontAlert, alert *wf.Alert) {
 	log := log.Logger(ctx, "controllers", "wavefrontalert_controller", "convertAlertCR")
@@ -363,15 +663,18 @@ func (r *WavefrontAlertReconciler) convertAlertCR(ctx context.Context, wfAlert *
 		if _, updateErr := r.CommonClient.UpdateStatus(ctx, wfAlert, alertmanagerv1alpha1.MalformedSpec); updateErr != nil {
 			log.Error(updateErr, "Failed to update WavefrontAlert status")
 		}
+		return
 	}
+	wavefront.AddOwnershipTags(alert, controllercommon.AlertOwner(wfAlert, alertmanagerv1alpha1.WavefrontAlertKind, wfAlert.Name))
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *WavefrontAlertReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&alertmanagerv1alpha1.WavefrontAlert{}).
-		WithEventFilter(controllercommon.StatusUpdatePredicate{}).
-		Complete(r)
+		WithEventFilter(controllercommon.StatusUpdatePredicate{})
+	b = r.Namespaces.Setup(b, &alertmanagerv1alpha1.WavefrontAlertList{})
+	return r.Shards.Setup(b, &alertmanagerv1alpha1.WavefrontAlertList{}).Complete(r)
 }
 
 func (r *WavefrontAlertReconciler) UpdateIndividualWavefrontAlertStatusError(
@@ -394,10 +697,31 @@ func (r *WavefrontAlertReconciler) UpdateIndividualWavefrontAlertStatusError(
 func (r *WavefrontAlertReconciler) UpdateIndividualWavefrontAlert(
 	ctx context.Context,
 	req ctrl.Request,
-	alertStatus alertmanagerv1alpha1.AlertStatus,
+	alertStatus *alertmanagerv1alpha1.AlertStatus,
 	alertsConfig alertmanagerv1alpha1.AlertsConfig,
 	wfAlert alertmanagerv1alpha1.WavefrontAlert,
-) error {
+) (bool, error) {
+	diff, err := updateIndividualAlert(ctx, r.WavefrontClient, alertStatus, alertsConfig, &wfAlert, false)
+	if err != nil || len(diff) == 0 {
+		return false, err
+	}
+	r.CommonClient.RecordAlertChange(ctx, controllercommon.AlertOwner(&alertsConfig, alertmanagerv1alpha1.AlertsConfigKind, alertStatus.AssociatedAlert.CR), alertStatus,
+		controllercommon.NewAlertChange(alertmanagerv1alpha1.AlertUpdate, &wfAlert, alertmanagerv1alpha1.WavefrontAlertKind, diff))
+	return true, nil
+}
+
+// updateIndividualAlert function re-renders the template with the alerts config params and updates the alert in wavefront.
+// Alert is not updated if the rendered payload is same as the last applied one or the live alert in wavefront.
+// Returns the difference if the alert got updated. PayloadHash (or the DryRun preview in dry-run) is set in the alert status.
+// This is shared by WavefrontAlert and ClusterWavefrontAlert reconcilers
+func updateIndividualAlert(
+	ctx context.Context,
+	wavefrontClient wavefront.Interface,
+	alertStatus *alertmanagerv1alpha1.AlertStatus,
+	alertsConfig alertmanagerv1alpha1.AlertsConfig,
+	wfAlert *alertmanagerv1alpha1.WavefrontAlert,
+	dryRun bool,
+) ([]string, error) {
 	log := log.Logger(ctx, "controllers", "wavefrontalert_controller", "UpdateIndividualWavefrontAlert")
 	// Get the corresponding alert in alertsConfig
 	config := alertsConfig.Spec.Alerts[alertStatus.AssociatedAlert.CR]
@@ -406,19 +730,37 @@ func (r *WavefrontAlertReconciler) UpdateIndividualWavefrontAlert(
 	//merge the alerts config global params and individual params
 	params := utils.MergeMaps(ctx, globalMap, config.Params)
 	// Create wavefront alert with proper substituted value of that exported param
-	if err := controllercommon.GetProcessedWFAlert(ctx, &wfAlert, params, &alert); err != nil {
-		return err
+	if err := controllercommon.GetProcessedWFAlert(ctx, wfAlert, params, &alert); err != nil {
+		return nil, fmt.Errorf("alerts config %s entry %s: %w", alertsConfig.Name, alertStatus.AssociatedAlert.CR, err)
 	}
+	wavefront.AddOwnershipTags(&alert, controllercommon.AlertOwner(&alertsConfig, alertmanagerv1alpha1.AlertsConfigKind, alertStatus.AssociatedAlert.CR))
+	controllercommon.AddDashboardLinks(&alert, controllercommon.DashboardLinks(alertsConfig.Status.DashboardsStatus))
 	// Update alert in wavefront
-	alert.ID = &alertStatus.ID
+	alertID := alertStatus.ID
+	alert.ID = &alertID
 	// Validate the alert request
 	if err := wavefront.ValidateAlertInput(ctx, &alert); err != nil {
-		return err
+		return nil, err
+	}
+	if dryRun {
+		preview, err := controllercommon.PreviewAlert(ctx, wavefrontClient, &alert)
+		if err != nil {
+			return nil, err
+		}
+		alertStatus.DryRun = preview
+		return nil, nil
 	}
 
-	if err := r.WavefrontClient.UpdateAlert(ctx, &alert); err != nil {
-		return err
+	payloadHash := wavefront.PayloadHash(ctx, &alert)
+	if alertStatus.PayloadHash == payloadHash && alertStatus.State == alertmanagerv1alpha1.Ready {
+		log.V(1).Info("rendered alert is same as the last applied one. skipping", "alertID", alertID)
+		return nil, nil
 	}
-	log.Info("alert successfully got updated", "alertID", alert.ID)
-	return nil
+	diff, err := wavefrontClient.UpdateAlert(ctx, &alert)
+	if err != nil {
+		return nil, err
+	}
+	alertStatus.PayloadHash = payloadHash
+	log.Info("alert successfully got updated", "alertID", alertID, "diff", diff)
+	return diff, nil
 }
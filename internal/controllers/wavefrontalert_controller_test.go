@@ -28,6 +28,7 @@ import (
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -67,7 +68,7 @@ var _ = Describe("WavefrontAlertController", Label("controller", "wavefrontalert
 			}, nil).AnyTimes()
 
 			// Mock the update and delete operations
-			mockWavefront.EXPECT().UpdateAlert(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			mockWavefront.EXPECT().UpdateAlert(gomock.Any(), gomock.Any()).Return([]string{"condition: old -> new"}, nil).AnyTimes()
 			mockWavefront.EXPECT().DeleteAlert(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 		})
 
@@ -78,8 +79,8 @@ var _ = Describe("WavefrontAlertController", Label("controller", "wavefrontalert
 				ctx := context.Background()
 
 				By("Creating a new WavefrontAlert with missing severity (a required field)")
-				var minutes int32 = 5
-				var resolveAfterMinutes int32 = 5
+				minutes := intstr.FromInt32(5)
+				resolveAfterMinutes := intstr.FromInt32(5)
 				alert := &v1alpha1.WavefrontAlert{
 					TypeMeta: metav1.TypeMeta{
 						APIVersion: "alertmanager.keikoproj.io/v1alpha1",
@@ -174,8 +175,8 @@ var _ = Describe("WavefrontAlertController", Label("controller", "wavefrontalert
 					ctx := context.Background()
 
 					By("Creating a new WavefrontAlert with all required fields including severity")
-					var minutes int32 = 5
-					var resolveAfterMinutes int32 = 5
+					minutes := intstr.FromInt32(5)
+					resolveAfterMinutes := intstr.FromInt32(5)
 					alert := &v1alpha1.WavefrontAlert{
 						TypeMeta: metav1.TypeMeta{
 							APIVersion: "alertmanager.keikoproj.io/v1alpha1",
@@ -237,8 +238,8 @@ var _ = Describe("WavefrontAlertController", Label("controller", "wavefrontalert
 
 					By("Creating several WavefrontAlerts with various validation issues")
 					// Missing severity
-					var minutes int32 = 5
-					var resolveAfterMinutes int32 = 5
+					minutes := intstr.FromInt32(5)
+					resolveAfterMinutes := intstr.FromInt32(5)
 					missingSeverityAlert := &v1alpha1.WavefrontAlert{
 						TypeMeta: metav1.TypeMeta{
 							APIVersion: "alertmanager.keikoproj.io/v1alpha1",
@@ -283,7 +284,7 @@ var _ = Describe("WavefrontAlertController", Label("controller", "wavefrontalert
 					}
 
 					// Invalid minutes
-					var zeroMinutes int32 = 0
+					zeroMinutes := intstr.FromInt32(0)
 					invalidMinutesAlert := &v1alpha1.WavefrontAlert{
 						TypeMeta: metav1.TypeMeta{
 							APIVersion: "alertmanager.keikoproj.io/v1alpha1",
@@ -306,7 +307,7 @@ var _ = Describe("WavefrontAlertController", Label("controller", "wavefrontalert
 					}
 
 					// Invalid resolve minutes
-					var zeroResolveMinutes int32 = 0
+					zeroResolveMinutes := intstr.FromInt32(0)
 					invalidResolveAlert := &v1alpha1.WavefrontAlert{
 						TypeMeta: metav1.TypeMeta{
 							APIVersion: "alertmanager.keikoproj.io/v1alpha1",
@@ -416,8 +417,8 @@ var _ = Describe("WavefrontAlertController", Label("controller", "wavefrontalert
 					ctx := context.Background()
 
 					By("Creating a WavefrontAlert to test deletion")
-					var minutes int32 = 5
-					var resolveAfterMinutes int32 = 5
+					minutes := intstr.FromInt32(5)
+					resolveAfterMinutes := intstr.FromInt32(5)
 					deleteTestAlert := &v1alpha1.WavefrontAlert{
 						TypeMeta: metav1.TypeMeta{
 							APIVersion: "alertmanager.keikoproj.io/v1alpha1",
@@ -460,5 +461,68 @@ var _ = Describe("WavefrontAlertController", Label("controller", "wavefrontalert
 				})
 			})
 		})
+
+		// Tests for previewing the changes without calling wavefront
+		Context("Dry-run", Label("dryrun"), func() {
+			It("Should preview the alert and create it once the dry-run annotation is removed", func() {
+				ctx := context.Background()
+
+				By("Creating a new WavefrontAlert with the dry-run annotation")
+				minutes := intstr.FromInt32(5)
+				resolveAfterMinutes := intstr.FromInt32(5)
+				alert := &v1alpha1.WavefrontAlert{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        alertName + "-dryrun",
+						Namespace:   alertNamespace,
+						Finalizers:  []string{"wavefrontalert.finalizers.alertmanager.keikoproj.io"},
+						Annotations: map[string]string{v1alpha1.DryRunAnnotation: "true"},
+					},
+					Spec: v1alpha1.WavefrontAlertSpec{
+						AlertType:         "CLASSIC",
+						AlertName:         alertName + "-dryrun",
+						Condition:         "ts(status.health)",
+						DisplayExpression: "ts(status.health)",
+						Minutes:           &minutes,
+						ResolveAfter:      &resolveAfterMinutes,
+						Tags:              []string{"foo", "bar"},
+						Severity:          "warn",
+					},
+				}
+				Expect(k8sClient.Create(ctx, alert)).Should(Succeed())
+				DeferCleanup(func() {
+					_ = k8sClient.Delete(ctx, alert)
+				})
+
+				By("Verifying the preview is recorded in the status without an alert ID")
+				alertLookupKey := types.NamespacedName{Name: alert.Name, Namespace: alertNamespace}
+				createdAlert := &v1alpha1.WavefrontAlert{}
+				Eventually(func() v1alpha1.State {
+					if err := k8sClient.Get(ctx, alertLookupKey, createdAlert); err != nil {
+						return ""
+					}
+					return createdAlert.Status.State
+				}, timeout, interval).Should(Equal(v1alpha1.DryRun))
+				alertStatus := createdAlert.Status.AlertsStatus[alert.Spec.AlertName]
+				Expect(alertStatus.ID).To(BeEmpty())
+				Expect(alertStatus.DryRun).NotTo(BeNil())
+				Expect(alertStatus.DryRun.Operation).To(Equal(v1alpha1.DryRunCreate))
+				Expect(alertStatus.DryRun.PayloadHash).NotTo(BeEmpty())
+
+				By("Removing the dry-run annotation")
+				delete(createdAlert.Annotations, v1alpha1.DryRunAnnotation)
+				Expect(k8sClient.Update(ctx, createdAlert)).Should(Succeed())
+
+				By("Verifying the alert gets created and the preview is cleared")
+				Eventually(func() v1alpha1.State {
+					if err := k8sClient.Get(ctx, alertLookupKey, createdAlert); err != nil {
+						return ""
+					}
+					return createdAlert.Status.State
+				}, timeout, interval).Should(Equal(v1alpha1.Ready))
+				alertStatus = createdAlert.Status.AlertsStatus[alert.Spec.AlertName]
+				Expect(alertStatus.ID).To(Equal("test-alert-id-123"))
+				Expect(alertStatus.DryRun).To(BeNil())
+			})
+		})
 	})
 })
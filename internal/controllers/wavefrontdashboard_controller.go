@@ -0,0 +1,210 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	"github.com/keikoproj/alert-manager/internal/utils"
+	"github.com/keikoproj/alert-manager/pkg/audit"
+	"github.com/keikoproj/alert-manager/pkg/log"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	controllercommon "github.com/keikoproj/alert-manager/internal/controllers/common"
+)
+
+const (
+	wavefrontDashboardFinalizerName = "wavefrontdashboard.finalizers.alertmanager.keikoproj.io"
+)
+
+// WavefrontDashboardReconciler reconciles a WavefrontDashboard object
+type WavefrontDashboardReconciler struct {
+	client.Client
+	Log             logr.Logger
+	Scheme          *runtime.Scheme
+	Recorder        record.EventRecorder
+	CommonClient    *controllercommon.Client
+	WavefrontClient wavefront.Interface
+	//DryRun skips the wavefront create, update and delete calls for all the dashboards
+	DryRun bool
+	//Shards limits the reconciles to the namespaces of the owned shards. nil if sharding is not enabled
+	Shards *ShardManager
+	//Namespaces limits the reconciles to the watched namespaces. nil if all the namespaces are watched
+	Namespaces *NamespaceFilter
+}
+
+//+kubebuilder:rbac:groups=alertmanager.keikoproj.io,resources=wavefrontdashboards,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=alertmanager.keikoproj.io,resources=wavefrontdashboards/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=alertmanager.keikoproj.io,resources=wavefrontdashboards/finalizers,verbs=update
+
+// Reconcile makes sure wavefront has the dashboard of a standalone WavefrontDashboard.
+// A dashboard with exportedParams is a template which is rendered by the alerts configs, so it is only marked as ready to be used
+func (r *WavefrontDashboardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	defer func() {
+		if err := recover(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	ctx = context.WithValue(ctx, requestId, uuid.New())
+	ctx, span := startReconcileSpan(ctx, alertmanagerv1alpha1.WavefrontDashboardKind, req)
+	defer span.End()
+	log := log.Logger(ctx, "controllers", "wavefrontdashboard_controller", "Reconcile")
+	log = log.WithValues("wavefrontdashboard_cr", req.NamespacedName)
+	log.Info("Start of the request")
+
+	if !r.Namespaces.Allows(ctx, req.Namespace) {
+		log.V(1).Info("namespace is not watched by this instance. skipping")
+		return ctrl.Result{}, nil
+	}
+	if !r.Shards.Owns(ctx, req.Namespace) {
+		log.V(1).Info("namespace belongs to a shard owned by another replica. skipping")
+		return ctrl.Result{}, nil
+	}
+
+	var wfDashboard alertmanagerv1alpha1.WavefrontDashboard
+	if err := r.Get(ctx, req.NamespacedName, &wfDashboard); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	// wavefront changes are audited as done by the resource
+	ctx = audit.WithActor(ctx, audit.ActorFor(&wfDashboard, alertmanagerv1alpha1.WavefrontDashboardKind))
+	// Paused resource is left alone, even if it is being deleted, until the annotation is removed
+	if controllercommon.IsPaused(&wfDashboard) {
+		return r.CommonClient.Pause(ctx, &wfDashboard, &wfDashboard.Status.State)
+	}
+
+	// Check if it is delete request
+	if !wfDashboard.ObjectMeta.DeletionTimestamp.IsZero() {
+		requeueFlag := false
+		if err := r.HandleDelete(ctx, &wfDashboard); err != nil {
+			log.Error(err, "unable to delete the dashboard")
+			requeueFlag = true
+		}
+		return ctrl.Result{Requeue: requeueFlag}, nil
+	}
+
+	//First time use case
+	if !utils.ContainsString(wfDashboard.ObjectMeta.Finalizers, wavefrontDashboardFinalizerName) {
+		log.Info("New wavefront dashboard resource. Adding the finalizer", "finalizer", wavefrontDashboardFinalizerName)
+		wfDashboard.ObjectMeta.Finalizers = append(wfDashboard.ObjectMeta.Finalizers, wavefrontDashboardFinalizerName)
+		r.CommonClient.UpdateMeta(ctx, &wfDashboard)
+		//That's fine- Let it come for requeue and we can create the dashboard
+		return ctrl.Result{}, nil
+	}
+
+	wfDashboard.Status.ObservedGeneration = wfDashboard.ObjectMeta.Generation
+	if len(wfDashboard.Spec.ExportedParams) > 0 {
+		// Alerts configs using the template are requeued by the alerts config controller watch
+		log.Info("dashboard is a template. it is rendered by the alerts configs")
+		wfDashboard.Status.RetryCount = 0
+		wfDashboard.Status.ErrorDescription = ""
+		return r.CommonClient.UpdateStatus(ctx, &wfDashboard, alertmanagerv1alpha1.ReadyToBeUsed)
+	}
+
+	var dashboard wf.Dashboard
+	if err := wavefront.RenderDashboard(ctx, &wfDashboard, nil, wavefront.DefaultDashboardURL(wfDashboard.Namespace, wfDashboard.Name), &dashboard); err != nil {
+		// There is no use of requeue in this case
+		r.Recorder.Event(&wfDashboard, v1.EventTypeWarning, string(alertmanagerv1alpha1.MalformedSpec), err.Error())
+		wfDashboard.Status.ErrorDescription = err.Error()
+		return r.CommonClient.UpdateStatus(ctx, &wfDashboard, alertmanagerv1alpha1.MalformedSpec)
+	}
+	if controllercommon.IsDryRun(&wfDashboard, r.DryRun) {
+		log.Info("dry-run: dashboard is not applied in wavefront", "dashboardID", dashboard.ID)
+		return r.CommonClient.UpdateStatus(ctx, &wfDashboard, alertmanagerv1alpha1.DryRun)
+	}
+
+	// Dashboard is pushed again if requested or once the resource is resumed even if there is no change
+	request, forced := controllercommon.ReconcileRequested(&wfDashboard, wfDashboard.Status.LastHandledReconcileRequest)
+	forced = forced || wfDashboard.Status.State == alertmanagerv1alpha1.Paused
+	status, err := controllercommon.ApplyDashboard(ctx, r.WavefrontClient, wfDashboard.Status.DashboardsStatus[wfDashboard.Name], &dashboard, forced)
+	wfDashboard.Status.DashboardsStatus = map[string]alertmanagerv1alpha1.DashboardStatus{wfDashboard.Name: status}
+	if err != nil {
+		return r.UpdateWavefrontDashboardStatusError(ctx, &wfDashboard, err)
+	}
+	if request != "" {
+		wfDashboard.Status.LastHandledReconcileRequest = request
+	}
+	wfDashboard.Status.RetryCount = 0
+	wfDashboard.Status.ErrorDescription = ""
+	return r.CommonClient.UpdateStatus(ctx, &wfDashboard, alertmanagerv1alpha1.Ready)
+}
+
+// HandleDelete function deletes the wavefront dashboard as per the deletion policy and removes the finalizer
+func (r *WavefrontDashboardReconciler) HandleDelete(ctx context.Context, wfDashboard *alertmanagerv1alpha1.WavefrontDashboard) error {
+	log := log.Logger(ctx, "controllers", "wavefrontdashboard_controller", "HandleDelete")
+	log = log.WithValues("wavefrontdashboard_cr", wfDashboard.Name, "namespace", wfDashboard.Namespace)
+	if controllercommon.IsDeletionProtected(wfDashboard) {
+		// Finalizer is kept. Removing the annotation triggers the reconcile again
+		r.CommonClient.RecordDeletionProtected(ctx, wfDashboard)
+		return nil
+	}
+	if controllercommon.IsDryRun(wfDashboard, r.DryRun) {
+		// Finalizer is kept so the dashboard gets deleted once dry-run is turned off
+		log.Info("dry-run: dashboard is not deleted from wavefront")
+		_, err := r.CommonClient.UpdateStatus(ctx, wfDashboard, alertmanagerv1alpha1.DryRun)
+		return err
+	}
+	policy := controllercommon.GetDeletionPolicy(wfDashboard.Spec.DeletionPolicy)
+	for name, dashboard := range wfDashboard.Status.DashboardsStatus {
+		if err := controllercommon.DeleteDashboard(ctx, r.WavefrontClient, dashboard.ID, policy); err != nil {
+			// Kept in the status so the deletion gets retried
+			dashboard.State = alertmanagerv1alpha1.Error
+			dashboard.ErrorDescription = err.Error()
+			wfDashboard.Status.DashboardsStatus[name] = dashboard
+			_, _ = r.UpdateWavefrontDashboardStatusError(ctx, wfDashboard, err)
+			return err
+		}
+	}
+
+	// Ok. Lets delete the finalizer so controller can delete the custom object
+	log.Info("Removing finalizer from WavefrontDashboard")
+	wfDashboard.ObjectMeta.Finalizers = utils.RemoveString(wfDashboard.ObjectMeta.Finalizers, wavefrontDashboardFinalizerName)
+	r.CommonClient.UpdateMeta(ctx, wfDashboard)
+	log.Info("Successfully deleted wfDashboard")
+	r.Recorder.Event(wfDashboard, v1.EventTypeNormal, "Deleted", "Successfully deleted WavefrontDashboard")
+	return nil
+}
+
+// UpdateWavefrontDashboardStatusError updates the status with the error and requeues the request
+func (r *WavefrontDashboardReconciler) UpdateWavefrontDashboardStatusError(ctx context.Context, wfDashboard *alertmanagerv1alpha1.WavefrontDashboard, err error) (ctrl.Result, error) {
+	log := log.Logger(ctx, "controllers", "wavefrontdashboard_controller", "UpdateWavefrontDashboardStatusError")
+	log.Error(err, "error occurred in wavefront dashboard", "wavefrontDashboard", wfDashboard.Name)
+	r.Recorder.Event(wfDashboard, v1.EventTypeWarning, string(alertmanagerv1alpha1.Error), fmt.Sprintf("error occurred in wavefront dashboard %s: %s", wfDashboard.Name, err.Error()))
+	wfDashboard.Status.ErrorDescription = err.Error()
+	wfDashboard.Status.RetryCount = wfDashboard.Status.RetryCount + 1
+	return r.CommonClient.UpdateStatus(ctx, wfDashboard, alertmanagerv1alpha1.Error, errRequeueTime)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *WavefrontDashboardReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&alertmanagerv1alpha1.WavefrontDashboard{}).
+		WithEventFilter(controllercommon.StatusUpdatePredicate{})
+	b = r.Namespaces.Setup(b, &alertmanagerv1alpha1.WavefrontDashboardList{})
+	return r.Shards.Setup(b, &alertmanagerv1alpha1.WavefrontDashboardList{}).Complete(r)
+}
@@ -0,0 +1,337 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers_test
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	"github.com/golang/mock/gomock"
+	"github.com/keikoproj/alert-manager/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// WavefrontDashboardController tests validate the controller's behavior when managing WavefrontDashboard CRs
+var _ = Describe("WavefrontDashboardController", Label("controller", "wavefrontdashboard"), func() {
+	const (
+		dashboardNamespace = "default"
+
+		timeout  = time.Second * 60
+		interval = time.Millisecond * 500
+	)
+
+	// wavefront calls recorded by the mocks. Dashboard ids are the urls so every test has its own ids
+	var (
+		mu         sync.Mutex
+		createdIDs []string
+		updatedIDs []string
+		deletedIDs []string
+		recorded   = func(calls *[]string) []string {
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]string(nil), *calls...)
+		}
+		record = func(calls *[]string, id string) {
+			mu.Lock()
+			defer mu.Unlock()
+			*calls = append(*calls, id)
+		}
+		idFor = func(name string) string {
+			return dashboardNamespace + "-" + name
+		}
+	)
+
+	BeforeEach(func() {
+		mockWavefront.EXPECT().CreateDashboard(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, dashboard *wf.Dashboard) error {
+			record(&createdIDs, dashboard.ID)
+			return nil
+		}).AnyTimes()
+		mockWavefront.EXPECT().UpdateDashboard(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, dashboard *wf.Dashboard) error {
+			record(&updatedIDs, dashboard.ID)
+			return nil
+		}).AnyTimes()
+		mockWavefront.EXPECT().DeleteDashboard(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id string) error {
+			record(&deletedIDs, id)
+			return nil
+		}).AnyTimes()
+	})
+
+	// newDashboard returns a standalone dashboard, or a template if exportedParams are given
+	newDashboard := func(name string, exportedParams ...string) *v1alpha1.WavefrontDashboard {
+		dashboard := &v1alpha1.WavefrontDashboard{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: dashboardNamespace},
+			Spec: v1alpha1.WavefrontDashboardSpec{
+				DashboardName: "checkout health",
+				Sections: []v1alpha1.DashboardSection{{
+					Name: "Health",
+					Rows: []v1alpha1.DashboardRow{{
+						Charts: []v1alpha1.DashboardChart{{
+							Name:    "errors",
+							Sources: []v1alpha1.DashboardSource{{Name: "errors", Query: "ts(http.errors)"}},
+						}},
+					}},
+				}},
+			},
+		}
+		if len(exportedParams) > 0 {
+			dashboard.Spec.DashboardName = "{{ .service }} health"
+			dashboard.Spec.ExportedParams = exportedParams
+			dashboard.Spec.ExportedParamsDefaultValues = map[string]string{"service": "checkout"}
+		}
+		return dashboard
+	}
+
+	// waitForState waits until the dashboard reaches the state and returns it
+	waitForState := func(ctx context.Context, name string, state v1alpha1.State) *v1alpha1.WavefrontDashboard {
+		lookupKey := types.NamespacedName{Name: name, Namespace: dashboardNamespace}
+		dashboard := &v1alpha1.WavefrontDashboard{}
+		Eventually(func() v1alpha1.State {
+			if err := k8sClient.Get(ctx, lookupKey, dashboard); err != nil {
+				return ""
+			}
+			return dashboard.Status.State
+		}, timeout, interval).Should(Equal(state))
+		return dashboard
+	}
+
+	// waitForDeletion waits until the dashboard resource is gone
+	waitForDeletion := func(ctx context.Context, name string) {
+		lookupKey := types.NamespacedName{Name: name, Namespace: dashboardNamespace}
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, lookupKey, &v1alpha1.WavefrontDashboard{}))
+		}, timeout, interval).Should(BeTrue())
+	}
+
+	// setAnnotation updates the annotation of the dashboard. Empty value removes the annotation
+	setAnnotation := func(ctx context.Context, name, annotation, value string) {
+		lookupKey := types.NamespacedName{Name: name, Namespace: dashboardNamespace}
+		Eventually(func() error {
+			dashboard := &v1alpha1.WavefrontDashboard{}
+			if err := k8sClient.Get(ctx, lookupKey, dashboard); err != nil {
+				return err
+			}
+			if value == "" {
+				delete(dashboard.Annotations, annotation)
+			} else {
+				if dashboard.Annotations == nil {
+					dashboard.Annotations = map[string]string{}
+				}
+				dashboard.Annotations[annotation] = value
+			}
+			return k8sClient.Update(ctx, dashboard)
+		}, timeout, interval).Should(Succeed())
+	}
+
+	Context("Standalone dashboard", func() {
+		It("Should create the dashboard", func() {
+			ctx := context.Background()
+			dashboard := newDashboard("standalone-dashboard")
+			Expect(k8sClient.Create(ctx, dashboard)).Should(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, dashboard)).Should(Succeed())
+			})
+
+			created := waitForState(ctx, dashboard.Name, v1alpha1.Ready)
+			// dashboard gets the default url of the resource
+			Expect(created.Status.DashboardsStatus[dashboard.Name].ID).To(Equal(idFor(dashboard.Name)))
+			Expect(created.Status.DashboardsStatus[dashboard.Name].Link).To(HaveSuffix("/dashboards/" + idFor(dashboard.Name)))
+		})
+
+		It("Should mark a template as ready to be used", func() {
+			ctx := context.Background()
+			dashboard := newDashboard("template-dashboard", "service")
+			Expect(k8sClient.Create(ctx, dashboard)).Should(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, dashboard)).Should(Succeed())
+			})
+
+			created := waitForState(ctx, dashboard.Name, v1alpha1.ReadyToBeUsed)
+			Expect(created.Status.DashboardsStatus).To(BeEmpty())
+			Expect(recorded(&createdIDs)).NotTo(ContainElement(idFor(dashboard.Name)))
+		})
+
+		It("Should push the dashboard again on a reconcile request", func() {
+			ctx := context.Background()
+			dashboard := newDashboard("resync-dashboard")
+			Expect(k8sClient.Create(ctx, dashboard)).Should(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, dashboard)).Should(Succeed())
+			})
+			waitForState(ctx, dashboard.Name, v1alpha1.Ready)
+			Expect(recorded(&updatedIDs)).NotTo(ContainElement(idFor(dashboard.Name)))
+
+			By("Requesting a reconcile")
+			setAnnotation(ctx, dashboard.Name, v1alpha1.ReconcileRequestAnnotation, "1")
+			lookupKey := types.NamespacedName{Name: dashboard.Name, Namespace: dashboardNamespace}
+			Eventually(func() string {
+				resynced := &v1alpha1.WavefrontDashboard{}
+				if err := k8sClient.Get(ctx, lookupKey, resynced); err != nil {
+					return ""
+				}
+				return resynced.Status.LastHandledReconcileRequest
+			}, timeout, interval).Should(Equal("1"))
+			Expect(recorded(&updatedIDs)).To(ContainElement(idFor(dashboard.Name)))
+		})
+	})
+
+	Context("Dry-run and pause", Label("dryrun", "pause"), func() {
+		It("Should not create the dashboard until the dry-run annotation is removed", func() {
+			ctx := context.Background()
+			dashboard := newDashboard("dryrun-dashboard")
+			dashboard.Annotations = map[string]string{v1alpha1.DryRunAnnotation: "true"}
+			Expect(k8sClient.Create(ctx, dashboard)).Should(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, dashboard)).Should(Succeed())
+			})
+
+			dryRun := waitForState(ctx, dashboard.Name, v1alpha1.DryRun)
+			Expect(dryRun.Status.DashboardsStatus).To(BeEmpty())
+			Expect(recorded(&createdIDs)).NotTo(ContainElement(idFor(dashboard.Name)))
+
+			By("Removing the dry-run annotation")
+			setAnnotation(ctx, dashboard.Name, v1alpha1.DryRunAnnotation, "")
+			ready := waitForState(ctx, dashboard.Name, v1alpha1.Ready)
+			Expect(ready.Status.DashboardsStatus[dashboard.Name].ID).To(Equal(idFor(dashboard.Name)))
+		})
+
+		It("Should not create the dashboard while paused", func() {
+			ctx := context.Background()
+			dashboard := newDashboard("paused-dashboard")
+			dashboard.Annotations = map[string]string{v1alpha1.PausedAnnotation: "true"}
+			Expect(k8sClient.Create(ctx, dashboard)).Should(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, dashboard)).Should(Succeed())
+			})
+
+			waitForState(ctx, dashboard.Name, v1alpha1.Paused)
+			Consistently(func() []string {
+				return recorded(&createdIDs)
+			}, 2*time.Second, interval).ShouldNot(ContainElement(idFor(dashboard.Name)))
+
+			By("Resuming the dashboard")
+			setAnnotation(ctx, dashboard.Name, v1alpha1.PausedAnnotation, "")
+			waitForState(ctx, dashboard.Name, v1alpha1.Ready)
+			Expect(recorded(&createdIDs)).To(ContainElement(idFor(dashboard.Name)))
+		})
+	})
+
+	Context("Deletion", Label("delete"), func() {
+		It("Should delete the dashboard from wavefront with the default deletion policy", func() {
+			ctx := context.Background()
+			dashboard := newDashboard("deleted-dashboard")
+			Expect(k8sClient.Create(ctx, dashboard)).Should(Succeed())
+			waitForState(ctx, dashboard.Name, v1alpha1.Ready)
+
+			Expect(k8sClient.Delete(ctx, dashboard)).Should(Succeed())
+			waitForDeletion(ctx, dashboard.Name)
+			Expect(recorded(&deletedIDs)).To(ContainElement(idFor(dashboard.Name)))
+		})
+
+		It("Should retain the dashboard in wavefront with the Retain deletion policy", func() {
+			ctx := context.Background()
+			dashboard := newDashboard("retained-dashboard")
+			dashboard.Spec.DeletionPolicy = v1alpha1.DeletionPolicyRetain
+			Expect(k8sClient.Create(ctx, dashboard)).Should(Succeed())
+			waitForState(ctx, dashboard.Name, v1alpha1.Ready)
+
+			Expect(k8sClient.Delete(ctx, dashboard)).Should(Succeed())
+			waitForDeletion(ctx, dashboard.Name)
+			Expect(recorded(&deletedIDs)).NotTo(ContainElement(idFor(dashboard.Name)))
+		})
+
+		It("Should block the deletion until the deletion protection annotation is removed", func() {
+			ctx := context.Background()
+			dashboard := newDashboard("protected-dashboard")
+			dashboard.Annotations = map[string]string{v1alpha1.DeletionProtectionAnnotation: "true"}
+			Expect(k8sClient.Create(ctx, dashboard)).Should(Succeed())
+			waitForState(ctx, dashboard.Name, v1alpha1.Ready)
+
+			Expect(k8sClient.Delete(ctx, dashboard)).Should(Succeed())
+			lookupKey := types.NamespacedName{Name: dashboard.Name, Namespace: dashboardNamespace}
+			Consistently(func() error {
+				return k8sClient.Get(ctx, lookupKey, &v1alpha1.WavefrontDashboard{})
+			}, 2*time.Second, interval).Should(Succeed())
+			Expect(recorded(&deletedIDs)).NotTo(ContainElement(idFor(dashboard.Name)))
+
+			By("Removing the deletion protection annotation")
+			setAnnotation(ctx, dashboard.Name, v1alpha1.DeletionProtectionAnnotation, "")
+			waitForDeletion(ctx, dashboard.Name)
+			Expect(recorded(&deletedIDs)).To(ContainElement(idFor(dashboard.Name)))
+		})
+	})
+
+	Context("Dashboard template rendered by an alerts config", Label("alertsconfig"), func() {
+		It("Should render the template with the alerts config params and delete it once it is removed from the alerts config", func() {
+			ctx := context.Background()
+			template := newDashboard("service-health", "service")
+			Expect(k8sClient.Create(ctx, template)).Should(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, template)).Should(Succeed())
+			})
+			waitForState(ctx, template.Name, v1alpha1.ReadyToBeUsed)
+
+			alertsConfig := &v1alpha1.AlertsConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboards-alerts-config", Namespace: dashboardNamespace},
+				Spec: v1alpha1.AlertsConfigSpec{
+					GlobalGVK: v1alpha1.GVK{Group: "alertmanager.keikoproj.io", Version: "v1alpha1", Kind: "WavefrontAlert"},
+					Dashboards: map[string]v1alpha1.DashboardConfig{
+						template.Name: {Params: v1alpha1.OrderedMap{"service": "payments"}},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, alertsConfig)).Should(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, alertsConfig)).Should(Succeed())
+			})
+
+			By("Verifying the rendered dashboard is recorded in the alerts config status")
+			// url defaults to <namespace>-<alerts config>-<template>
+			renderedID := idFor(alertsConfig.Name + "-" + template.Name)
+			lookupKey := types.NamespacedName{Name: alertsConfig.Name, Namespace: dashboardNamespace}
+			Eventually(func() v1alpha1.DashboardStatus {
+				rendered := &v1alpha1.AlertsConfig{}
+				if err := k8sClient.Get(ctx, lookupKey, rendered); err != nil {
+					return v1alpha1.DashboardStatus{}
+				}
+				return rendered.Status.DashboardsStatus[template.Name]
+			}, timeout, interval).Should(And(
+				HaveField("ID", renderedID),
+				HaveField("Name", "payments health"),
+				HaveField("State", v1alpha1.Ready),
+			))
+
+			By("Removing the dashboard from the alerts config")
+			Eventually(func() error {
+				rendered := &v1alpha1.AlertsConfig{}
+				if err := k8sClient.Get(ctx, lookupKey, rendered); err != nil {
+					return err
+				}
+				rendered.Spec.Dashboards = nil
+				return k8sClient.Update(ctx, rendered)
+			}, timeout, interval).Should(Succeed())
+			Eventually(func() []string {
+				return recorded(&deletedIDs)
+			}, timeout, interval).Should(ContainElement(renderedID))
+		})
+	})
+})
@@ -0,0 +1,210 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	"github.com/keikoproj/alert-manager/internal/utils"
+	"github.com/keikoproj/alert-manager/pkg/audit"
+	"github.com/keikoproj/alert-manager/pkg/log"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	controllercommon "github.com/keikoproj/alert-manager/internal/controllers/common"
+)
+
+const (
+	wavefrontDerivedMetricFinalizerName = "wavefrontderivedmetric.finalizers.alertmanager.keikoproj.io"
+)
+
+// WavefrontDerivedMetricReconciler reconciles a WavefrontDerivedMetric object
+type WavefrontDerivedMetricReconciler struct {
+	client.Client
+	Log             logr.Logger
+	Scheme          *runtime.Scheme
+	Recorder        record.EventRecorder
+	CommonClient    *controllercommon.Client
+	WavefrontClient wavefront.Interface
+	//DryRun skips the wavefront create, update and delete calls for all the derived metrics
+	DryRun bool
+	//Shards limits the reconciles to the namespaces of the owned shards. nil if sharding is not enabled
+	Shards *ShardManager
+	//Namespaces limits the reconciles to the watched namespaces. nil if all the namespaces are watched
+	Namespaces *NamespaceFilter
+}
+
+//+kubebuilder:rbac:groups=alertmanager.keikoproj.io,resources=wavefrontderivedmetrics,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=alertmanager.keikoproj.io,resources=wavefrontderivedmetrics/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=alertmanager.keikoproj.io,resources=wavefrontderivedmetrics/finalizers,verbs=update
+
+// Reconcile makes sure wavefront has the derived metric of a standalone WavefrontDerivedMetric.
+// A derived metric with exportedParams is a template which is rendered by the alerts configs, so it is only marked as ready to be used
+func (r *WavefrontDerivedMetricReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	defer func() {
+		if err := recover(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	ctx = context.WithValue(ctx, requestId, uuid.New())
+	ctx, span := startReconcileSpan(ctx, alertmanagerv1alpha1.WavefrontDerivedMetricKind, req)
+	defer span.End()
+	log := log.Logger(ctx, "controllers", "wavefrontderivedmetric_controller", "Reconcile")
+	log = log.WithValues("wavefrontderivedmetric_cr", req.NamespacedName)
+	log.Info("Start of the request")
+
+	if !r.Namespaces.Allows(ctx, req.Namespace) {
+		log.V(1).Info("namespace is not watched by this instance. skipping")
+		return ctrl.Result{}, nil
+	}
+	if !r.Shards.Owns(ctx, req.Namespace) {
+		log.V(1).Info("namespace belongs to a shard owned by another replica. skipping")
+		return ctrl.Result{}, nil
+	}
+
+	var wfDerivedMetric alertmanagerv1alpha1.WavefrontDerivedMetric
+	if err := r.Get(ctx, req.NamespacedName, &wfDerivedMetric); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	// wavefront changes are audited as done by the resource
+	ctx = audit.WithActor(ctx, audit.ActorFor(&wfDerivedMetric, alertmanagerv1alpha1.WavefrontDerivedMetricKind))
+	// Paused resource is left alone, even if it is being deleted, until the annotation is removed
+	if controllercommon.IsPaused(&wfDerivedMetric) {
+		return r.CommonClient.Pause(ctx, &wfDerivedMetric, &wfDerivedMetric.Status.State)
+	}
+
+	// Check if it is delete request
+	if !wfDerivedMetric.ObjectMeta.DeletionTimestamp.IsZero() {
+		requeueFlag := false
+		if err := r.HandleDelete(ctx, &wfDerivedMetric); err != nil {
+			log.Error(err, "unable to delete the derived metric")
+			requeueFlag = true
+		}
+		return ctrl.Result{Requeue: requeueFlag}, nil
+	}
+
+	//First time use case
+	if !utils.ContainsString(wfDerivedMetric.ObjectMeta.Finalizers, wavefrontDerivedMetricFinalizerName) {
+		log.Info("New wavefront derived metric resource. Adding the finalizer", "finalizer", wavefrontDerivedMetricFinalizerName)
+		wfDerivedMetric.ObjectMeta.Finalizers = append(wfDerivedMetric.ObjectMeta.Finalizers, wavefrontDerivedMetricFinalizerName)
+		r.CommonClient.UpdateMeta(ctx, &wfDerivedMetric)
+		//That's fine- Let it come for requeue and we can create the derived metric
+		return ctrl.Result{}, nil
+	}
+
+	wfDerivedMetric.Status.ObservedGeneration = wfDerivedMetric.ObjectMeta.Generation
+	if len(wfDerivedMetric.Spec.ExportedParams) > 0 {
+		// Alerts configs using the template are requeued by the alerts config controller watch
+		log.Info("derived metric is a template. it is rendered by the alerts configs")
+		wfDerivedMetric.Status.RetryCount = 0
+		wfDerivedMetric.Status.ErrorDescription = ""
+		return r.CommonClient.UpdateStatus(ctx, &wfDerivedMetric, alertmanagerv1alpha1.ReadyToBeUsed)
+	}
+
+	var metric wf.DerivedMetric
+	if err := wavefront.RenderDerivedMetric(ctx, &wfDerivedMetric, nil, &metric); err != nil {
+		// There is no use of requeue in this case
+		r.Recorder.Event(&wfDerivedMetric, v1.EventTypeWarning, string(alertmanagerv1alpha1.MalformedSpec), err.Error())
+		wfDerivedMetric.Status.ErrorDescription = err.Error()
+		return r.CommonClient.UpdateStatus(ctx, &wfDerivedMetric, alertmanagerv1alpha1.MalformedSpec)
+	}
+	if controllercommon.IsDryRun(&wfDerivedMetric, r.DryRun) {
+		log.Info("dry-run: derived metric is not applied in wavefront", "derivedMetricName", metric.Name)
+		return r.CommonClient.UpdateStatus(ctx, &wfDerivedMetric, alertmanagerv1alpha1.DryRun)
+	}
+
+	// Derived metric is pushed again if requested or once the resource is resumed even if there is no change
+	request, forced := controllercommon.ReconcileRequested(&wfDerivedMetric, wfDerivedMetric.Status.LastHandledReconcileRequest)
+	forced = forced || wfDerivedMetric.Status.State == alertmanagerv1alpha1.Paused
+	status, err := controllercommon.ApplyDerivedMetric(ctx, r.WavefrontClient, wfDerivedMetric.Status.DerivedMetricsStatus[wfDerivedMetric.Name], &metric, forced)
+	wfDerivedMetric.Status.DerivedMetricsStatus = map[string]alertmanagerv1alpha1.DerivedMetricStatus{wfDerivedMetric.Name: status}
+	if err != nil {
+		return r.UpdateWavefrontDerivedMetricStatusError(ctx, &wfDerivedMetric, err)
+	}
+	if request != "" {
+		wfDerivedMetric.Status.LastHandledReconcileRequest = request
+	}
+	wfDerivedMetric.Status.RetryCount = 0
+	wfDerivedMetric.Status.ErrorDescription = ""
+	return r.CommonClient.UpdateStatus(ctx, &wfDerivedMetric, alertmanagerv1alpha1.Ready)
+}
+
+// HandleDelete function deletes the wavefront derived metric as per the deletion policy and removes the finalizer
+func (r *WavefrontDerivedMetricReconciler) HandleDelete(ctx context.Context, wfDerivedMetric *alertmanagerv1alpha1.WavefrontDerivedMetric) error {
+	log := log.Logger(ctx, "controllers", "wavefrontderivedmetric_controller", "HandleDelete")
+	log = log.WithValues("wavefrontderivedmetric_cr", wfDerivedMetric.Name, "namespace", wfDerivedMetric.Namespace)
+	if controllercommon.IsDeletionProtected(wfDerivedMetric) {
+		// Finalizer is kept. Removing the annotation triggers the reconcile again
+		r.CommonClient.RecordDeletionProtected(ctx, wfDerivedMetric)
+		return nil
+	}
+	if controllercommon.IsDryRun(wfDerivedMetric, r.DryRun) {
+		// Finalizer is kept so the derived metric gets deleted once dry-run is turned off
+		log.Info("dry-run: derived metric is not deleted from wavefront")
+		_, err := r.CommonClient.UpdateStatus(ctx, wfDerivedMetric, alertmanagerv1alpha1.DryRun)
+		return err
+	}
+	policy := controllercommon.GetDeletionPolicy(wfDerivedMetric.Spec.DeletionPolicy)
+	for name, metric := range wfDerivedMetric.Status.DerivedMetricsStatus {
+		if err := controllercommon.DeleteDerivedMetric(ctx, r.WavefrontClient, metric.ID, policy); err != nil {
+			// Kept in the status so the deletion gets retried
+			metric.State = alertmanagerv1alpha1.Error
+			metric.ErrorDescription = err.Error()
+			wfDerivedMetric.Status.DerivedMetricsStatus[name] = metric
+			_, _ = r.UpdateWavefrontDerivedMetricStatusError(ctx, wfDerivedMetric, err)
+			return err
+		}
+	}
+
+	// Ok. Lets delete the finalizer so controller can delete the custom object
+	log.Info("Removing finalizer from WavefrontDerivedMetric")
+	wfDerivedMetric.ObjectMeta.Finalizers = utils.RemoveString(wfDerivedMetric.ObjectMeta.Finalizers, wavefrontDerivedMetricFinalizerName)
+	r.CommonClient.UpdateMeta(ctx, wfDerivedMetric)
+	log.Info("Successfully deleted wfDerivedMetric")
+	r.Recorder.Event(wfDerivedMetric, v1.EventTypeNormal, "Deleted", "Successfully deleted WavefrontDerivedMetric")
+	return nil
+}
+
+// UpdateWavefrontDerivedMetricStatusError updates the status with the error and requeues the request
+func (r *WavefrontDerivedMetricReconciler) UpdateWavefrontDerivedMetricStatusError(ctx context.Context, wfDerivedMetric *alertmanagerv1alpha1.WavefrontDerivedMetric, err error) (ctrl.Result, error) {
+	log := log.Logger(ctx, "controllers", "wavefrontderivedmetric_controller", "UpdateWavefrontDerivedMetricStatusError")
+	log.Error(err, "error occurred in wavefront derived metric", "wavefrontDerivedMetric", wfDerivedMetric.Name)
+	r.Recorder.Event(wfDerivedMetric, v1.EventTypeWarning, string(alertmanagerv1alpha1.Error), fmt.Sprintf("error occurred in wavefront derived metric %s: %s", wfDerivedMetric.Name, err.Error()))
+	wfDerivedMetric.Status.ErrorDescription = err.Error()
+	wfDerivedMetric.Status.RetryCount = wfDerivedMetric.Status.RetryCount + 1
+	return r.CommonClient.UpdateStatus(ctx, wfDerivedMetric, alertmanagerv1alpha1.Error, errRequeueTime)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *WavefrontDerivedMetricReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&alertmanagerv1alpha1.WavefrontDerivedMetric{}).
+		WithEventFilter(controllercommon.StatusUpdatePredicate{})
+	b = r.Namespaces.Setup(b, &alertmanagerv1alpha1.WavefrontDerivedMetricList{})
+	return r.Shards.Setup(b, &alertmanagerv1alpha1.WavefrontDerivedMetricList{}).Complete(r)
+}
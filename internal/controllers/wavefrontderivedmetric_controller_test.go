@@ -0,0 +1,333 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	"github.com/golang/mock/gomock"
+	"github.com/keikoproj/alert-manager/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// WavefrontDerivedMetricController tests validate the controller's behavior when managing WavefrontDerivedMetric CRs
+var _ = Describe("WavefrontDerivedMetricController", Label("controller", "wavefrontderivedmetric"), func() {
+	const (
+		derivedMetricNamespace = "default"
+
+		timeout  = time.Second * 60
+		interval = time.Millisecond * 500
+	)
+
+	// wavefront calls recorded by the mocks. Ids are derived from the derived metric names so every test has its own ids
+	var (
+		mu         sync.Mutex
+		createdIDs []string
+		updatedIDs []string
+		deletedIDs []string
+		recorded   = func(calls *[]string) []string {
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]string(nil), *calls...)
+		}
+		idFor = func(derivedMetricName string) string {
+			return "id-" + strings.ReplaceAll(derivedMetricName, " ", "-")
+		}
+	)
+
+	BeforeEach(func() {
+		mockWavefront.EXPECT().CreateDerivedMetric(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, metric *wf.DerivedMetric) error {
+			mu.Lock()
+			defer mu.Unlock()
+			id := idFor(metric.Name)
+			metric.ID = &id
+			createdIDs = append(createdIDs, id)
+			return nil
+		}).AnyTimes()
+		mockWavefront.EXPECT().UpdateDerivedMetric(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, metric *wf.DerivedMetric) error {
+			mu.Lock()
+			defer mu.Unlock()
+			updatedIDs = append(updatedIDs, *metric.ID)
+			return nil
+		}).AnyTimes()
+		mockWavefront.EXPECT().DeleteDerivedMetric(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			deletedIDs = append(deletedIDs, id)
+			return nil
+		}).AnyTimes()
+	})
+
+	// newDerivedMetric returns a standalone derived metric named after the resource, or a template if exportedParams are given
+	newDerivedMetric := func(name string, exportedParams ...string) *v1alpha1.WavefrontDerivedMetric {
+		derivedMetric := &v1alpha1.WavefrontDerivedMetric{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: derivedMetricNamespace},
+			Spec: v1alpha1.WavefrontDerivedMetricSpec{
+				DerivedMetricName: name,
+				Query:             `aliasMetric(sum(rate(ts(http.errors, service=checkout))), "derived.checkout.errors")`,
+				Minutes:           5,
+			},
+		}
+		if len(exportedParams) > 0 {
+			derivedMetric.Spec.DerivedMetricName = "{{ .service }} errors"
+			derivedMetric.Spec.Query = `aliasMetric(sum(rate(ts(http.errors, service={{ .service }}))), "derived.{{ .service }}.errors")`
+			derivedMetric.Spec.ExportedParams = exportedParams
+			derivedMetric.Spec.ExportedParamsDefaultValues = map[string]string{"service": "checkout"}
+		}
+		return derivedMetric
+	}
+
+	// waitForState waits until the derived metric reaches the state and returns it
+	waitForState := func(ctx context.Context, name string, state v1alpha1.State) *v1alpha1.WavefrontDerivedMetric {
+		lookupKey := types.NamespacedName{Name: name, Namespace: derivedMetricNamespace}
+		derivedMetric := &v1alpha1.WavefrontDerivedMetric{}
+		Eventually(func() v1alpha1.State {
+			if err := k8sClient.Get(ctx, lookupKey, derivedMetric); err != nil {
+				return ""
+			}
+			return derivedMetric.Status.State
+		}, timeout, interval).Should(Equal(state))
+		return derivedMetric
+	}
+
+	// waitForDeletion waits until the derived metric resource is gone
+	waitForDeletion := func(ctx context.Context, name string) {
+		lookupKey := types.NamespacedName{Name: name, Namespace: derivedMetricNamespace}
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, lookupKey, &v1alpha1.WavefrontDerivedMetric{}))
+		}, timeout, interval).Should(BeTrue())
+	}
+
+	// setAnnotation updates the annotation of the derived metric. Empty value removes the annotation
+	setAnnotation := func(ctx context.Context, name, annotation, value string) {
+		lookupKey := types.NamespacedName{Name: name, Namespace: derivedMetricNamespace}
+		Eventually(func() error {
+			derivedMetric := &v1alpha1.WavefrontDerivedMetric{}
+			if err := k8sClient.Get(ctx, lookupKey, derivedMetric); err != nil {
+				return err
+			}
+			if value == "" {
+				delete(derivedMetric.Annotations, annotation)
+			} else {
+				if derivedMetric.Annotations == nil {
+					derivedMetric.Annotations = map[string]string{}
+				}
+				derivedMetric.Annotations[annotation] = value
+			}
+			return k8sClient.Update(ctx, derivedMetric)
+		}, timeout, interval).Should(Succeed())
+	}
+
+	Context("Standalone derived metric", func() {
+		It("Should create the derived metric", func() {
+			ctx := context.Background()
+			derivedMetric := newDerivedMetric("standalone-derived-metric")
+			Expect(k8sClient.Create(ctx, derivedMetric)).Should(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, derivedMetric)).Should(Succeed())
+			})
+
+			created := waitForState(ctx, derivedMetric.Name, v1alpha1.Ready)
+			Expect(created.Status.DerivedMetricsStatus[derivedMetric.Name].ID).To(Equal(idFor(derivedMetric.Name)))
+			Expect(created.Status.DerivedMetricsStatus[derivedMetric.Name].Name).To(Equal(derivedMetric.Name))
+		})
+
+		It("Should mark a template as ready to be used", func() {
+			ctx := context.Background()
+			derivedMetric := newDerivedMetric("template-derived-metric", "service")
+			Expect(k8sClient.Create(ctx, derivedMetric)).Should(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, derivedMetric)).Should(Succeed())
+			})
+
+			created := waitForState(ctx, derivedMetric.Name, v1alpha1.ReadyToBeUsed)
+			Expect(created.Status.DerivedMetricsStatus).To(BeEmpty())
+			Expect(recorded(&createdIDs)).NotTo(ContainElement(idFor("checkout errors")))
+		})
+
+		It("Should push the derived metric again on a reconcile request", func() {
+			ctx := context.Background()
+			derivedMetric := newDerivedMetric("resync-derived-metric")
+			Expect(k8sClient.Create(ctx, derivedMetric)).Should(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, derivedMetric)).Should(Succeed())
+			})
+			waitForState(ctx, derivedMetric.Name, v1alpha1.Ready)
+			Expect(recorded(&updatedIDs)).NotTo(ContainElement(idFor(derivedMetric.Name)))
+
+			By("Requesting a reconcile")
+			setAnnotation(ctx, derivedMetric.Name, v1alpha1.ReconcileRequestAnnotation, "1")
+			lookupKey := types.NamespacedName{Name: derivedMetric.Name, Namespace: derivedMetricNamespace}
+			Eventually(func() string {
+				resynced := &v1alpha1.WavefrontDerivedMetric{}
+				if err := k8sClient.Get(ctx, lookupKey, resynced); err != nil {
+					return ""
+				}
+				return resynced.Status.LastHandledReconcileRequest
+			}, timeout, interval).Should(Equal("1"))
+			Expect(recorded(&updatedIDs)).To(ContainElement(idFor(derivedMetric.Name)))
+		})
+	})
+
+	Context("Dry-run and pause", Label("dryrun", "pause"), func() {
+		It("Should not create the derived metric until the dry-run annotation is removed", func() {
+			ctx := context.Background()
+			derivedMetric := newDerivedMetric("dryrun-derived-metric")
+			derivedMetric.Annotations = map[string]string{v1alpha1.DryRunAnnotation: "true"}
+			Expect(k8sClient.Create(ctx, derivedMetric)).Should(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, derivedMetric)).Should(Succeed())
+			})
+
+			dryRun := waitForState(ctx, derivedMetric.Name, v1alpha1.DryRun)
+			Expect(dryRun.Status.DerivedMetricsStatus).To(BeEmpty())
+			Expect(recorded(&createdIDs)).NotTo(ContainElement(idFor(derivedMetric.Name)))
+
+			By("Removing the dry-run annotation")
+			setAnnotation(ctx, derivedMetric.Name, v1alpha1.DryRunAnnotation, "")
+			ready := waitForState(ctx, derivedMetric.Name, v1alpha1.Ready)
+			Expect(ready.Status.DerivedMetricsStatus[derivedMetric.Name].ID).To(Equal(idFor(derivedMetric.Name)))
+		})
+
+		It("Should not create the derived metric while paused", func() {
+			ctx := context.Background()
+			derivedMetric := newDerivedMetric("paused-derived-metric")
+			derivedMetric.Annotations = map[string]string{v1alpha1.PausedAnnotation: "true"}
+			Expect(k8sClient.Create(ctx, derivedMetric)).Should(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, derivedMetric)).Should(Succeed())
+			})
+
+			waitForState(ctx, derivedMetric.Name, v1alpha1.Paused)
+			Consistently(func() []string {
+				return recorded(&createdIDs)
+			}, 2*time.Second, interval).ShouldNot(ContainElement(idFor(derivedMetric.Name)))
+
+			By("Resuming the derived metric")
+			setAnnotation(ctx, derivedMetric.Name, v1alpha1.PausedAnnotation, "")
+			waitForState(ctx, derivedMetric.Name, v1alpha1.Ready)
+			Expect(recorded(&createdIDs)).To(ContainElement(idFor(derivedMetric.Name)))
+		})
+	})
+
+	Context("Deletion", Label("delete"), func() {
+		It("Should delete the derived metric from wavefront with the default deletion policy", func() {
+			ctx := context.Background()
+			derivedMetric := newDerivedMetric("deleted-derived-metric")
+			Expect(k8sClient.Create(ctx, derivedMetric)).Should(Succeed())
+			waitForState(ctx, derivedMetric.Name, v1alpha1.Ready)
+
+			Expect(k8sClient.Delete(ctx, derivedMetric)).Should(Succeed())
+			waitForDeletion(ctx, derivedMetric.Name)
+			Expect(recorded(&deletedIDs)).To(ContainElement(idFor(derivedMetric.Name)))
+		})
+
+		It("Should retain the derived metric in wavefront with the Retain deletion policy", func() {
+			ctx := context.Background()
+			derivedMetric := newDerivedMetric("retained-derived-metric")
+			derivedMetric.Spec.DeletionPolicy = v1alpha1.DeletionPolicyRetain
+			Expect(k8sClient.Create(ctx, derivedMetric)).Should(Succeed())
+			waitForState(ctx, derivedMetric.Name, v1alpha1.Ready)
+
+			Expect(k8sClient.Delete(ctx, derivedMetric)).Should(Succeed())
+			waitForDeletion(ctx, derivedMetric.Name)
+			Expect(recorded(&deletedIDs)).NotTo(ContainElement(idFor(derivedMetric.Name)))
+		})
+
+		It("Should block the deletion until the deletion protection annotation is removed", func() {
+			ctx := context.Background()
+			derivedMetric := newDerivedMetric("protected-derived-metric")
+			derivedMetric.Annotations = map[string]string{v1alpha1.DeletionProtectionAnnotation: "true"}
+			Expect(k8sClient.Create(ctx, derivedMetric)).Should(Succeed())
+			waitForState(ctx, derivedMetric.Name, v1alpha1.Ready)
+
+			Expect(k8sClient.Delete(ctx, derivedMetric)).Should(Succeed())
+			lookupKey := types.NamespacedName{Name: derivedMetric.Name, Namespace: derivedMetricNamespace}
+			Consistently(func() error {
+				return k8sClient.Get(ctx, lookupKey, &v1alpha1.WavefrontDerivedMetric{})
+			}, 2*time.Second, interval).Should(Succeed())
+			Expect(recorded(&deletedIDs)).NotTo(ContainElement(idFor(derivedMetric.Name)))
+
+			By("Removing the deletion protection annotation")
+			setAnnotation(ctx, derivedMetric.Name, v1alpha1.DeletionProtectionAnnotation, "")
+			waitForDeletion(ctx, derivedMetric.Name)
+			Expect(recorded(&deletedIDs)).To(ContainElement(idFor(derivedMetric.Name)))
+		})
+	})
+
+	Context("Derived metric template rendered by an alerts config", Label("alertsconfig"), func() {
+		It("Should render the template with the alerts config params and delete it once it is removed from the alerts config", func() {
+			ctx := context.Background()
+			template := newDerivedMetric("service-errors", "service")
+			Expect(k8sClient.Create(ctx, template)).Should(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, template)).Should(Succeed())
+			})
+			waitForState(ctx, template.Name, v1alpha1.ReadyToBeUsed)
+
+			alertsConfig := &v1alpha1.AlertsConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "derived-metrics-alerts-config", Namespace: derivedMetricNamespace},
+				Spec: v1alpha1.AlertsConfigSpec{
+					GlobalGVK: v1alpha1.GVK{Group: "alertmanager.keikoproj.io", Version: "v1alpha1", Kind: "WavefrontAlert"},
+					DerivedMetrics: map[string]v1alpha1.DerivedMetricConfig{
+						template.Name: {Params: v1alpha1.OrderedMap{"service": "payments"}},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, alertsConfig)).Should(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, alertsConfig)).Should(Succeed())
+			})
+
+			By("Verifying the rendered derived metric is recorded in the alerts config status")
+			renderedID := idFor("payments errors")
+			lookupKey := types.NamespacedName{Name: alertsConfig.Name, Namespace: derivedMetricNamespace}
+			Eventually(func() v1alpha1.DerivedMetricStatus {
+				rendered := &v1alpha1.AlertsConfig{}
+				if err := k8sClient.Get(ctx, lookupKey, rendered); err != nil {
+					return v1alpha1.DerivedMetricStatus{}
+				}
+				return rendered.Status.DerivedMetricsStatus[template.Name]
+			}, timeout, interval).Should(And(
+				HaveField("ID", renderedID),
+				HaveField("Name", "payments errors"),
+				HaveField("State", v1alpha1.Ready),
+			))
+
+			By("Removing the derived metric from the alerts config")
+			Eventually(func() error {
+				rendered := &v1alpha1.AlertsConfig{}
+				if err := k8sClient.Get(ctx, lookupKey, rendered); err != nil {
+					return err
+				}
+				rendered.Spec.DerivedMetrics = nil
+				return k8sClient.Update(ctx, rendered)
+			}, timeout, interval).Should(Succeed())
+			Eventually(func() []string {
+				return recorded(&deletedIDs)
+			}, timeout, interval).Should(ContainElement(renderedID))
+		})
+	})
+})
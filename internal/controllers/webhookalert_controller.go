@@ -0,0 +1,268 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate mockgen -destination=mocks/webhook/mock_webhookiface.go -package=mock_webhook github.com/keikoproj/alert-manager/pkg/webhook Interface
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	"github.com/keikoproj/alert-manager/internal/template"
+	"github.com/keikoproj/alert-manager/internal/utils"
+	"github.com/keikoproj/alert-manager/pkg/audit"
+	"github.com/keikoproj/alert-manager/pkg/k8s"
+	"github.com/keikoproj/alert-manager/pkg/log"
+	"github.com/keikoproj/alert-manager/pkg/webhook"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	controllercommon "github.com/keikoproj/alert-manager/internal/controllers/common"
+)
+
+const (
+	webhookAlertFinalizerName = "webhookalert.finalizers.alertmanager.keikoproj.io"
+)
+
+// WebhookAlertReconciler reconciles a WebhookAlert object
+type WebhookAlertReconciler struct {
+	client.Client
+	Log           logr.Logger
+	Scheme        *runtime.Scheme
+	Recorder      record.EventRecorder
+	CommonClient  *controllercommon.Client
+	K8sClient     k8s.Interface
+	WebhookClient webhook.Interface
+	//Shards limits the reconciles to the namespaces of the owned shards. nil if sharding is not enabled
+	Shards *ShardManager
+	//Namespaces limits the reconciles to the watched namespaces. nil if all the namespaces are watched
+	Namespaces *NamespaceFilter
+}
+
+//+kubebuilder:rbac:groups=alertmanager.keikoproj.io,resources=webhookalerts,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=alertmanager.keikoproj.io,resources=webhookalerts/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=alertmanager.keikoproj.io,resources=webhookalerts/finalizers,verbs=update
+
+// Reconcile renders the WebhookAlert payload and makes sure the endpoint holds the same alert.
+// Alerts are created once and tracked with the id returned by the endpoint. Every later reconcile reads the
+// alert back from the endpoint to detect drift, recreates it if it went missing and updates it if it differs.
+func (r *WebhookAlertReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	defer func() {
+		if err := recover(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	ctx = WithRequestID(ctx, uuid.New())
+	ctx, span := startReconcileSpan(ctx, alertmanagerv1alpha1.WebhookAlertKind, req)
+	defer span.End()
+	log := log.Logger(ctx, "controllers", "webhookalert_controller", "Reconcile")
+	log = log.WithValues("webhookalert_cr", req.NamespacedName)
+	log.Info("Start of the request")
+
+	if !r.Namespaces.Allows(ctx, req.Namespace) {
+		log.V(1).Info("namespace is not watched by this instance. skipping")
+		return ctrl.Result{}, nil
+	}
+	if !r.Shards.Owns(ctx, req.Namespace) {
+		log.V(1).Info("namespace belongs to a shard owned by another replica. skipping")
+		return ctrl.Result{}, nil
+	}
+
+	var whAlert alertmanagerv1alpha1.WebhookAlert
+	if err := r.Get(ctx, req.NamespacedName, &whAlert); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	ctx = audit.WithActor(ctx, audit.ActorFor(&whAlert, alertmanagerv1alpha1.WebhookAlertKind))
+
+	// Check if it is delete request
+	if !whAlert.ObjectMeta.DeletionTimestamp.IsZero() {
+		requeueFlag := false
+		if err := r.HandleDelete(ctx, &whAlert); err != nil {
+			log.Error(err, "unable to delete the alert")
+			requeueFlag = true
+		}
+		return ctrl.Result{Requeue: requeueFlag}, nil
+	}
+
+	//First time use case
+	if !utils.ContainsString(whAlert.ObjectMeta.Finalizers, webhookAlertFinalizerName) {
+		log.Info("New webhook alert resource. Adding the finalizer", "finalizer", webhookAlertFinalizerName)
+		whAlert.ObjectMeta.Finalizers = append(whAlert.ObjectMeta.Finalizers, webhookAlertFinalizerName)
+		r.CommonClient.UpdateMeta(ctx, &whAlert)
+		//That's fine- Let it come for requeue and we can create the alert
+		return ctrl.Result{}, nil
+	}
+
+	payload, err := r.renderPayload(ctx, &whAlert)
+	if err != nil {
+		// There is no use of requeue in this case
+		r.Recorder.Event(&whAlert, v1.EventTypeWarning, string(alertmanagerv1alpha1.MalformedSpec), err.Error())
+		whAlert.Status.State = alertmanagerv1alpha1.MalformedSpec
+		whAlert.Status.ErrorDescription = err.Error()
+		whAlert.Status.ObservedGeneration = whAlert.ObjectMeta.Generation
+		return r.CommonClient.UpdateStatus(ctx, &whAlert, alertmanagerv1alpha1.MalformedSpec)
+	}
+	lastChangeChecksum := utils.CalculateChecksum(ctx, string(payload))
+
+	endpoint, err := r.resolveEndpoint(ctx, &whAlert)
+	if err != nil {
+		return r.UpdateWebhookAlertStatusError(ctx, &whAlert, alertmanagerv1alpha1.Error, err)
+	}
+
+	if whAlert.Status.ID != "" {
+		// Drift check - read the alert back and compare it with the rendered payload
+		remote, err := r.WebhookClient.ReadAlert(ctx, endpoint, whAlert.Status.ID)
+		switch {
+		case errors.Is(err, webhook.ErrNotFound):
+			log.Info("alert doesn't exist in the webhook anymore, so reset alertID and create a new alert", "alertID", whAlert.Status.ID)
+			whAlert.Status.ID = ""
+		case err != nil:
+			return r.UpdateWebhookAlertStatusError(ctx, &whAlert, alertmanagerv1alpha1.Error, err)
+		case webhook.PayloadMatches(remote, payload):
+			log.Info("There is no change in the alert.. skipping")
+		default:
+			log.Info("alert differs from the rendered payload, updating it", "alertID", whAlert.Status.ID)
+			if err := r.WebhookClient.UpdateAlert(ctx, endpoint, whAlert.Status.ID, payload); err != nil {
+				return r.UpdateWebhookAlertStatusError(ctx, &whAlert, alertmanagerv1alpha1.Error, err)
+			}
+			whAlert.Status.LastUpdatedTimestamp = metav1.Now()
+			r.Recorder.Event(&whAlert, v1.EventTypeNormal, "Successful", fmt.Sprintf("successfully updated an alert id = %s", whAlert.Status.ID))
+		}
+	}
+
+	if whAlert.Status.ID == "" {
+		id, err := r.WebhookClient.CreateAlert(ctx, endpoint, payload)
+		if err != nil {
+			return r.UpdateWebhookAlertStatusError(ctx, &whAlert, alertmanagerv1alpha1.Error, err)
+		}
+		log.Info("alert successfully got created", "alertID", id)
+		whAlert.Status.ID = id
+		whAlert.Status.LastUpdatedTimestamp = metav1.Now()
+		r.Recorder.Event(&whAlert, v1.EventTypeNormal, "Successful", fmt.Sprintf("successfully created an alert id = %s", id))
+	}
+
+	whAlert.Status.LastChangeChecksum = lastChangeChecksum
+	whAlert.Status.ObservedGeneration = whAlert.ObjectMeta.Generation
+	whAlert.Status.RetryCount = 0
+	whAlert.Status.ErrorDescription = ""
+	whAlert.Status.State = alertmanagerv1alpha1.Ready
+	return r.CommonClient.UpdateStatus(ctx, &whAlert, alertmanagerv1alpha1.Ready)
+}
+
+// HandleDelete function handles the deleting webhook alerts
+func (r *WebhookAlertReconciler) HandleDelete(ctx context.Context, whAlert *alertmanagerv1alpha1.WebhookAlert) error {
+	log := log.Logger(ctx, "controllers", "webhookalert_controller", "HandleDelete")
+	log = log.WithValues("webhookalert_cr", whAlert.Name, "namespace", whAlert.Namespace)
+
+	if whAlert.Status.ID != "" {
+		endpoint, err := r.resolveEndpoint(ctx, whAlert)
+		if err == nil {
+			err = r.WebhookClient.DeleteAlert(ctx, endpoint, whAlert.Status.ID)
+		}
+		if err != nil && !errors.Is(err, webhook.ErrNotFound) {
+			// Finalizer is kept so the deletion gets retried
+			log.Error(err, "unable to delete the alert", "alertID", whAlert.Status.ID)
+			whAlert.Status.State = alertmanagerv1alpha1.Error
+			whAlert.Status.ErrorDescription = err.Error()
+			whAlert.Status.RetryCount = whAlert.Status.RetryCount + 1
+			r.CommonClient.UpdateStatus(ctx, whAlert, alertmanagerv1alpha1.Error)
+			return err
+		}
+	}
+
+	// Ok. Lets delete the finalizer so controller can delete the custom object
+	log.Info("Removing finalizer from WebhookAlert")
+	whAlert.ObjectMeta.Finalizers = utils.RemoveString(whAlert.ObjectMeta.Finalizers, webhookAlertFinalizerName)
+	r.CommonClient.UpdateMeta(ctx, whAlert)
+	log.Info("Successfully deleted webhookAlert")
+	r.Recorder.Event(whAlert, v1.EventTypeNormal, "Deleted", "Successfully deleted WebhookAlert")
+	return nil
+}
+
+// renderPayload executes the payload template with the params and makes sure the result is valid JSON
+func (r *WebhookAlertReconciler) renderPayload(ctx context.Context, whAlert *alertmanagerv1alpha1.WebhookAlert) ([]byte, error) {
+	rendered, err := template.ProcessTemplate(ctx, whAlert.Spec.Payload, whAlert.Spec.Params)
+	if err != nil {
+		return nil, fmt.Errorf("unable to render the payload: %w", err)
+	}
+	if !json.Valid([]byte(rendered)) {
+		return nil, errors.New("rendered payload is not a valid JSON document")
+	}
+	return []byte(rendered), nil
+}
+
+// resolveEndpoint converts the spec endpoint to webhook.Endpoint, reading the auth token from the secret if configured
+func (r *WebhookAlertReconciler) resolveEndpoint(ctx context.Context, whAlert *alertmanagerv1alpha1.WebhookAlert) (webhook.Endpoint, error) {
+	endpoint := webhook.Endpoint{
+		URL:     whAlert.Spec.Endpoint.URL,
+		Timeout: time.Duration(whAlert.Spec.Endpoint.TimeoutSeconds) * time.Second,
+	}
+	ref := whAlert.Spec.Endpoint.AuthSecretRef
+	if ref == nil {
+		return endpoint, nil
+	}
+	secret, err := r.K8sClient.GetK8sSecret(ctx, ref.Name, whAlert.Namespace)
+	if err != nil {
+		if ref.Optional != nil && *ref.Optional {
+			return endpoint, nil
+		}
+		return endpoint, err
+	}
+	token, ok := secret.Data[ref.Key]
+	if !ok && (ref.Optional == nil || !*ref.Optional) {
+		return endpoint, fmt.Errorf("key %s not found in secret %s", ref.Key, ref.Name)
+	}
+	endpoint.Token = string(token)
+	return endpoint, nil
+}
+
+// UpdateWebhookAlertStatusError updates the status with the error and requeues the request
+func (r *WebhookAlertReconciler) UpdateWebhookAlertStatusError(
+	ctx context.Context,
+	whAlert *alertmanagerv1alpha1.WebhookAlert,
+	state alertmanagerv1alpha1.State,
+	err error,
+) (ctrl.Result, error) {
+	log := log.Logger(ctx, "controllers", "webhookalert_controller", "UpdateWebhookAlertStatusError")
+	log.Error(err, "error occurred in webhook alert", "webhookAlert", whAlert.Name)
+	r.Recorder.Event(whAlert, v1.EventTypeWarning, string(state), fmt.Sprintf("error occurred in webhook alert %s: %s", whAlert.Name, err.Error()))
+	whAlert.Status.State = state
+	whAlert.Status.ErrorDescription = err.Error()
+	whAlert.Status.RetryCount = whAlert.Status.RetryCount + 1
+	return r.CommonClient.UpdateStatus(ctx, whAlert, state, errRequeueTime)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *WebhookAlertReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&alertmanagerv1alpha1.WebhookAlert{}).
+		WithEventFilter(controllercommon.StatusUpdatePredicate{})
+	b = r.Namespaces.Setup(b, &alertmanagerv1alpha1.WebhookAlertList{})
+	return r.Shards.Setup(b, &alertmanagerv1alpha1.WebhookAlertList{}).Complete(r)
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/keikoproj/alert-manager/internal/utils"
+	"github.com/keikoproj/alert-manager/pkg/webhook"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// endpointURL matches the webhook endpoint by its url so every context gets its own responses from the shared mock
+type endpointURL string
+
+func (u endpointURL) Matches(x interface{}) bool {
+	endpoint, ok := x.(webhook.Endpoint)
+	return ok && endpoint.URL == string(u)
+}
+
+func (u endpointURL) String() string {
+	return fmt.Sprintf("endpoint url is %s", string(u))
+}
+
+// WebhookAlertController tests validate the controller's behavior when managing WebhookAlert CRs
+var _ = Describe("WebhookAlertController", Label("controller", "webhookalert"), func() {
+	const (
+		alertName      = "webhook-test-alert"
+		alertNamespace = "default"
+
+		timeout  = time.Second * 60
+		interval = time.Millisecond * 500
+	)
+
+	Context("Webhook alert creation", func() {
+		BeforeEach(func() {
+			// CreateAlert: Simulates the endpoint returning an ID for the rendered payload
+			endpoint := endpointURL("https://alerts.example.com/api")
+			mockWebhook.EXPECT().CreateAlert(gomock.Any(), endpoint, gomock.Any()).
+				Return("webhook-alert-id-123", nil).AnyTimes()
+			// ReadAlert: Endpoint echoes the fields it manages along with the payload, which is not a drift
+			mockWebhook.EXPECT().ReadAlert(gomock.Any(), endpoint, gomock.Any()).
+				Return([]byte(`{"id":"webhook-alert-id-123","name":"checkout-latency","severity":"warn","createdAt":"2025-01-01T00:00:00Z"}`), nil).AnyTimes()
+			mockWebhook.EXPECT().UpdateAlert(gomock.Any(), endpoint, gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			mockWebhook.EXPECT().DeleteAlert(gomock.Any(), endpoint, gomock.Any()).Return(nil).AnyTimes()
+		})
+
+		It("Should render the payload and record the id returned by the endpoint", func() {
+			ctx := context.Background()
+
+			alert := &v1alpha1.WebhookAlert{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      alertName,
+					Namespace: alertNamespace,
+				},
+				Spec: v1alpha1.WebhookAlertSpec{
+					Endpoint: v1alpha1.WebhookEndpoint{
+						URL: "https://alerts.example.com/api",
+					},
+					Payload: `{"name":"{{ .app }}-latency","severity":"warn"}`,
+					Params: v1alpha1.OrderedMap{
+						"app": "checkout",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, alert)).Should(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, alert)).Should(Succeed())
+			})
+
+			By("Verifying the alert transitions to Ready with the endpoint id")
+			lookupKey := types.NamespacedName{Name: alertName, Namespace: alertNamespace}
+			created := &v1alpha1.WebhookAlert{}
+			Eventually(func() string {
+				if err := k8sClient.Get(ctx, lookupKey, created); err != nil {
+					return ""
+				}
+				return created.Status.ID
+			}, timeout, interval).Should(Equal("webhook-alert-id-123"))
+			Expect(created.Status.State).To(Equal(v1alpha1.Ready))
+		})
+	})
+
+	Context("Webhook alert deletion", func() {
+		const (
+			deleteAlertName = "webhook-delete-alert"
+			deleteAlertID   = "webhook-delete-id"
+		)
+		// endpointDown makes the endpoint fail the deletes until it is back
+		var endpointDown atomic.Bool
+
+		BeforeEach(func() {
+			endpoint := endpointURL("https://flaky.example.com/api")
+			mockWebhook.EXPECT().CreateAlert(gomock.Any(), endpoint, gomock.Any()).Return(deleteAlertID, nil).AnyTimes()
+			mockWebhook.EXPECT().ReadAlert(gomock.Any(), endpoint, gomock.Any()).Return([]byte(`{"name":"flaky"}`), nil).AnyTimes()
+			mockWebhook.EXPECT().UpdateAlert(gomock.Any(), endpoint, gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			mockWebhook.EXPECT().DeleteAlert(gomock.Any(), endpoint, deleteAlertID).DoAndReturn(func(context.Context, webhook.Endpoint, string) error {
+				if endpointDown.Load() {
+					return errors.New("endpoint is unavailable")
+				}
+				// Alert got deleted by someone else in the meantime
+				return webhook.ErrNotFound
+			}).AnyTimes()
+		})
+
+		It("Should keep the finalizer until the alert is deleted from the endpoint", func() {
+			ctx := context.Background()
+
+			alert := &v1alpha1.WebhookAlert{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      deleteAlertName,
+					Namespace: alertNamespace,
+				},
+				Spec: v1alpha1.WebhookAlertSpec{
+					Endpoint: v1alpha1.WebhookEndpoint{
+						URL: "https://flaky.example.com/api",
+					},
+					Payload: `{"name":"flaky"}`,
+				},
+			}
+			Expect(k8sClient.Create(ctx, alert)).Should(Succeed())
+
+			lookupKey := types.NamespacedName{Name: deleteAlertName, Namespace: alertNamespace}
+			created := &v1alpha1.WebhookAlert{}
+			Eventually(func() string {
+				if err := k8sClient.Get(ctx, lookupKey, created); err != nil {
+					return ""
+				}
+				return created.Status.ID
+			}, timeout, interval).Should(Equal(deleteAlertID))
+
+			By("Keeping the finalizer while the endpoint fails the delete")
+			endpointDown.Store(true)
+			Expect(k8sClient.Delete(ctx, created)).Should(Succeed())
+			Eventually(func() v1alpha1.State {
+				if err := k8sClient.Get(ctx, lookupKey, created); err != nil {
+					return ""
+				}
+				return created.Status.State
+			}, timeout, interval).Should(Equal(v1alpha1.Error))
+			Expect(utils.ContainsString(created.Finalizers, "webhookalert.finalizers.alertmanager.keikoproj.io")).To(BeTrue())
+
+			By("Removing the finalizer once the endpoint no longer knows the alert")
+			endpointDown.Store(false)
+			Eventually(func() bool {
+				return apierrors.IsNotFound(k8sClient.Get(ctx, lookupKey, &v1alpha1.WebhookAlert{}))
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
+})
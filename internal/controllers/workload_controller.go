@@ -0,0 +1,226 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	internalconfig "github.com/keikoproj/alert-manager/internal/config"
+	"github.com/keikoproj/alert-manager/pkg/log"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// DeploymentKind generates alerts configs for Deployments
+	DeploymentKind = "Deployment"
+	// StatefulSetKind generates alerts configs for StatefulSets
+	StatefulSetKind = "StatefulSet"
+	// NamespaceKind generates alerts configs for Namespaces
+	NamespaceKind = "Namespace"
+)
+
+// errAlertsConfigNotGenerated is returned when an alerts config with the generated name exists but was not generated from the workload
+var errAlertsConfigNotGenerated = errors.New("alerts config was not generated from the workload")
+
+// WorkloadReconciler generates an AlertsConfig for the workloads annotated with an alert pack.
+// One reconciler is registered for each workload Kind
+type WorkloadReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	//Kind of the workload. One of Deployment, StatefulSet or Namespace
+	Kind string
+	//Namespaces limits the reconciles to the watched namespaces. nil if all the namespaces are watched
+	Namespaces *NamespaceFilter
+}
+
+//+kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
+// Reconcile creates, updates or deletes the AlertsConfig generated from the workload.
+// Generated AlertsConfig is owned by the workload so it gets garbage collected when the workload goes away
+func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx = context.WithValue(ctx, requestId, uuid.New())
+	ctx, span := startReconcileSpan(ctx, r.Kind, req)
+	defer span.End()
+	log := log.Logger(ctx, "controllers", "workload_controller", "Reconcile")
+	log = log.WithValues("kind", r.Kind, "workload", req.NamespacedName)
+
+	workload, err := r.newObject()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.Get(ctx, req.NamespacedName, workload); err != nil {
+		// Generated alerts config is garbage collected with the workload
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !workload.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+	if !r.Namespaces.Allows(ctx, generatedAlertsConfigNamespace(workload)) {
+		log.V(1).Info("namespace is not watched by this instance. skipping")
+		return ctrl.Result{}, nil
+	}
+
+	alertsConfig := &alertmanagerv1alpha1.AlertsConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      generatedAlertsConfigName(r.Kind, workload.GetName()),
+			Namespace: generatedAlertsConfigNamespace(workload),
+		},
+	}
+
+	alertPack := workload.GetAnnotations()[alertmanagerv1alpha1.AlertPackAnnotation]
+	if alertPack == "" {
+		// Alert pack annotation might have been removed. Delete the alerts config if we generated it before
+		return ctrl.Result{}, r.deleteGeneratedAlertsConfig(ctx, alertsConfig)
+	}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, alertsConfig, func() error {
+		if !alertsConfig.CreationTimestamp.IsZero() && alertsConfig.Labels[alertmanagerv1alpha1.GeneratedByLabel] != strings.ToLower(r.Kind) {
+			// user created alerts config with the same name. it is not taken over
+			return errAlertsConfigNotGenerated
+		}
+		if alertsConfig.Labels == nil {
+			alertsConfig.Labels = make(map[string]string)
+		}
+		alertsConfig.Labels[alertmanagerv1alpha1.GeneratedByLabel] = strings.ToLower(r.Kind)
+		alertsConfig.Spec = generateAlertsConfigSpec(workload)
+		return controllerutil.SetControllerReference(workload, alertsConfig, r.Scheme)
+	})
+	if errors.Is(err, errAlertsConfigNotGenerated) {
+		// There is no use of requeue in this case. Workload is reconciled again once it changes
+		log.Info("alerts config with the same name was not generated from the workload. skipping", "alertsConfig", alertsConfig.Name)
+		r.Recorder.Event(workload, v1.EventTypeWarning, string(alertmanagerv1alpha1.Error), fmt.Sprintf("unable to generate alerts config %s since an alerts config with the same name already exists", alertsConfig.Name))
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		log.Error(err, "unable to generate alerts config")
+		r.Recorder.Event(workload, v1.EventTypeWarning, string(alertmanagerv1alpha1.Error), "unable to generate alerts config due to error "+err.Error())
+		return ctrl.Result{RequeueAfter: time.Duration(errRequeueTime) * time.Millisecond}, nil
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Info("alerts config got generated", "alertsConfig", alertsConfig.Name, "operation", result)
+	}
+	return ctrl.Result{}, nil
+}
+
+// deleteGeneratedAlertsConfig function deletes the alerts config only if it was generated from a workload
+func (r *WorkloadReconciler) deleteGeneratedAlertsConfig(ctx context.Context, alertsConfig *alertmanagerv1alpha1.AlertsConfig) error {
+	if err := r.Get(ctx, types.NamespacedName{Namespace: alertsConfig.Namespace, Name: alertsConfig.Name}, alertsConfig); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if alertsConfig.Labels[alertmanagerv1alpha1.GeneratedByLabel] != strings.ToLower(r.Kind) {
+		// user created alerts config with the same name. leave it alone
+		return nil
+	}
+	return client.IgnoreNotFound(r.Delete(ctx, alertsConfig))
+}
+
+// generateAlertsConfigSpec function builds the alerts config spec from the workload.
+// name and namespace params are always provided, others come from the configured label and annotation mappings
+func generateAlertsConfigSpec(workload client.Object) alertmanagerv1alpha1.AlertsConfigSpec {
+	params := alertmanagerv1alpha1.OrderedMap{
+		"name":      workload.GetName(),
+		"namespace": generatedAlertsConfigNamespace(workload),
+	}
+	for param, label := range internalconfig.Props.WorkloadParamsFromLabels() {
+		if value, ok := workload.GetLabels()[label]; ok {
+			params[param] = value
+		}
+	}
+	for param, annotation := range internalconfig.Props.WorkloadParamsFromAnnotations() {
+		if value, ok := workload.GetAnnotations()[annotation]; ok {
+			params[param] = value
+		}
+	}
+
+	templateKind := workload.GetAnnotations()[alertmanagerv1alpha1.AlertPackKindAnnotation]
+	if templateKind == "" {
+		templateKind = "WavefrontAlert"
+	}
+	return alertmanagerv1alpha1.AlertsConfigSpec{
+		GlobalGVK: alertmanagerv1alpha1.GVK{
+			Group:   alertmanagerv1alpha1.GroupVersion.Group,
+			Version: alertmanagerv1alpha1.GroupVersion.Version,
+			Kind:    templateKind,
+		},
+		TemplateSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				alertmanagerv1alpha1.AlertPackAnnotation: workload.GetAnnotations()[alertmanagerv1alpha1.AlertPackAnnotation],
+			},
+		},
+		GlobalParams: params,
+	}
+}
+
+// generatedAlertsConfigName returns the name of the alerts config generated for the workload
+func generatedAlertsConfigName(kind string, name string) string {
+	return fmt.Sprintf("%s-%s", strings.ToLower(kind), name)
+}
+
+// generatedAlertsConfigNamespace returns the namespace of the generated alerts config. Namespace itself in case of Namespace workload
+func generatedAlertsConfigNamespace(workload client.Object) string {
+	if workload.GetNamespace() == "" {
+		return workload.GetName()
+	}
+	return workload.GetNamespace()
+}
+
+// newObject returns an empty object of the workload kind
+func (r *WorkloadReconciler) newObject() (client.Object, error) {
+	switch r.Kind {
+	case DeploymentKind:
+		return &appsv1.Deployment{}, nil
+	case StatefulSetKind:
+		return &appsv1.StatefulSet{}, nil
+	case NamespaceKind:
+		return &v1.Namespace{}, nil
+	}
+	return nil, fmt.Errorf("unsupported workload kind %s", r.Kind)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	workload, err := r.newObject()
+	if err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(strings.ToLower(r.Kind)+"-alertsconfig").
+		// workload status changes all the time, only labels and annotations matter here
+		For(workload, builder.WithPredicates(predicate.Or(predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
+		Owns(&alertmanagerv1alpha1.AlertsConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers_test
+
+import (
+	"context"
+	"time"
+
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// WorkloadController tests validate the AlertsConfig generation from annotated workloads
+var _ = Describe("WorkloadController", Label("controller", "workload"), func() {
+	const (
+		deploymentName = "test-workload"
+		namespace      = "default"
+		timeout        = time.Second * 60
+		interval       = time.Millisecond * 250
+	)
+
+	Context("When a Deployment is annotated with an alert pack", func() {
+		It("Should generate the AlertsConfig and delete it when the annotation is removed", func() {
+			ctx := context.Background()
+			labels := map[string]string{"app": deploymentName}
+
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        deploymentName,
+					Namespace:   namespace,
+					Annotations: map[string]string{alertmanagerv1alpha1.AlertPackAnnotation: "standard-http"},
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: labels},
+					Template: v1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: labels},
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{{Name: "app", Image: "nginx"}},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, deployment)).Should(Succeed())
+
+			By("Checking the generated AlertsConfig")
+			acLookupKey := types.NamespacedName{Name: "deployment-" + deploymentName, Namespace: namespace}
+			generated := &alertmanagerv1alpha1.AlertsConfig{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, acLookupKey, generated)
+			}, timeout, interval).Should(Succeed())
+			Expect(generated.Spec.GlobalParams).To(HaveKeyWithValue("name", deploymentName))
+			Expect(generated.Spec.GlobalParams).To(HaveKeyWithValue("namespace", namespace))
+			Expect(generated.Spec.TemplateSelector.MatchLabels).To(HaveKeyWithValue(alertmanagerv1alpha1.AlertPackAnnotation, "standard-http"))
+			Expect(generated.OwnerReferences).To(HaveLen(1))
+			Expect(generated.OwnerReferences[0].Name).To(Equal(deploymentName))
+
+			By("Removing the alert pack annotation")
+			Eventually(func() error {
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: deploymentName, Namespace: namespace}, deployment); err != nil {
+					return err
+				}
+				deployment.Annotations = nil
+				return k8sClient.Update(ctx, deployment)
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, acLookupKey, generated)
+				return apierrors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, deployment)).Should(Succeed())
+			})
+		})
+
+		It("Should not take over an AlertsConfig created by the user with the same name", func() {
+			ctx := context.Background()
+			const name = "user-owned-workload"
+			labels := map[string]string{"app": name}
+
+			userAlertsConfig := &alertmanagerv1alpha1.AlertsConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "deployment-" + name, Namespace: namespace},
+				Spec: alertmanagerv1alpha1.AlertsConfigSpec{
+					GlobalParams: alertmanagerv1alpha1.OrderedMap{"owner": "user"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, userAlertsConfig)).Should(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, userAlertsConfig)).Should(Succeed())
+			})
+
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   namespace,
+					Annotations: map[string]string{alertmanagerv1alpha1.AlertPackAnnotation: "standard-http"},
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: labels},
+					Template: v1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: labels},
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{{Name: "app", Image: "nginx"}},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, deployment)).Should(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, deployment)).Should(Succeed())
+			})
+
+			acLookupKey := types.NamespacedName{Name: userAlertsConfig.Name, Namespace: namespace}
+			Consistently(func() bool {
+				alertsConfig := &alertmanagerv1alpha1.AlertsConfig{}
+				if err := k8sClient.Get(ctx, acLookupKey, alertsConfig); err != nil {
+					return false
+				}
+				return len(alertsConfig.OwnerReferences) == 0 && alertsConfig.Spec.GlobalParams["owner"] == "user"
+			}, time.Second*5, interval).Should(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,161 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/keikoproj/alert-manager/internal/utils"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+func (o *Options) newInstancesCommand() *cobra.Command {
+	var kind string
+	var render bool
+	cmd := &cobra.Command{
+		Use:   "instances TEMPLATE",
+		Short: "List the alerts rendered from the template by the alerts configs",
+		Long: "List the alerts rendered from the template by the alerts configs. " +
+			"Alerts configs in all the namespaces are listed for ClusterWavefrontAlert templates",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			templateKind, err := parseKind(kind)
+			if err != nil {
+				return err
+			}
+			return o.runInstances(cmd.Context(), args[0], templateKind, render)
+		},
+	}
+	cmd.Flags().StringVar(&kind, "kind", alertmanagerv1alpha1.WavefrontAlertKind, "Kind of the template: WavefrontAlert or ClusterWavefrontAlert")
+	cmd.Flags().BoolVar(&render, "render", false, "Render every alert with the same code as the controller and print the wavefront alert request")
+	return cmd
+}
+
+// instance is an alert of an alerts config rendered from the template
+type instance struct {
+	alertsConfig *alertmanagerv1alpha1.AlertsConfig
+	status       alertmanagerv1alpha1.AlertStatus
+}
+
+func (o *Options) runInstances(ctx context.Context, template string, kind string, render bool) error {
+	if kind == alertmanagerv1alpha1.AlertsConfigKind {
+		return fmt.Errorf("%s is not a template kind", kind)
+	}
+	namespace := o.listNamespace()
+	if kind == alertmanagerv1alpha1.ClusterWavefrontAlertKind {
+		namespace = ""
+	}
+	var alertsConfigs alertmanagerv1alpha1.AlertsConfigList
+	if err := o.Client.List(ctx, &alertsConfigs, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+	var instances []instance
+	for i := range alertsConfigs.Items {
+		alertsConfig := &alertsConfigs.Items[i]
+		status, inStatus := alertsConfig.Status.AlertsStatus[template]
+		config, inSpec := alertsConfig.Spec.Alerts[template]
+		switch {
+		case inStatus && status.AssociatedAlert.Kind == kind,
+			inStatus && status.AssociatedAlert.Kind == "" && kind == alertmanagerv1alpha1.WavefrontAlertKind:
+		case !inStatus && inSpec && templateKind(alertsConfig.Spec.GlobalGVK, config) == kind:
+			// not rendered yet
+		default:
+			continue
+		}
+		instances = append(instances, instance{alertsConfig: alertsConfig, status: status})
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		if instances[i].alertsConfig.Namespace != instances[j].alertsConfig.Namespace {
+			return instances[i].alertsConfig.Namespace < instances[j].alertsConfig.Namespace
+		}
+		return instances[i].alertsConfig.Name < instances[j].alertsConfig.Name
+	})
+
+	if render {
+		return o.renderInstances(ctx, template, kind, instances)
+	}
+	w := tabwriter.NewWriter(o.Out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tALERTSCONFIG\tALERT\tSTATE\tFIRING STATE\tID\tLINK")
+	for _, i := range instances {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", i.alertsConfig.Namespace, i.alertsConfig.Name, orDash(i.status.Name),
+			orDash(string(i.status.State)), orDash(string(i.status.FiringState)), orDash(i.status.ID), orDash(i.status.Link))
+	}
+	return w.Flush()
+}
+
+// renderInstances function prints the wavefront alert request of every instance rendered the same way as the alerts config controller
+func (o *Options) renderInstances(ctx context.Context, template string, kind string, instances []instance) error {
+	if err := o.loadClusterIdentity(ctx); err != nil {
+		return fmt.Errorf("unable to load the cluster identity from the controller config map: %w", err)
+	}
+	var clusterTemplate *alertmanagerv1alpha1.WavefrontAlert
+	if kind == alertmanagerv1alpha1.ClusterWavefrontAlertKind {
+		var clusterAlert alertmanagerv1alpha1.ClusterWavefrontAlert
+		if err := o.Client.Get(ctx, types.NamespacedName{Name: template}, &clusterAlert); err != nil {
+			return err
+		}
+		clusterTemplate = clusterAlert.AsWavefrontAlert()
+	}
+	for _, i := range instances {
+		fmt.Fprintf(o.Out, "# %s/%s\n", i.alertsConfig.Namespace, i.alertsConfig.Name)
+		alert, err := o.renderInstance(ctx, template, clusterTemplate, i.alertsConfig)
+		if err != nil {
+			fmt.Fprintf(o.Out, "# unable to render the alert: %s\n---\n", err)
+			continue
+		}
+		out, err := yaml.Marshal(alert)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(o.Out, "%s---\n", out)
+	}
+	return nil
+}
+
+// renderInstance function renders the template with the alerts config params
+func (o *Options) renderInstance(ctx context.Context, template string, clusterTemplate *alertmanagerv1alpha1.WavefrontAlert, alertsConfig *alertmanagerv1alpha1.AlertsConfig) (*wf.Alert, error) {
+	wfAlert := clusterTemplate
+	if wfAlert == nil {
+		wfAlert = &alertmanagerv1alpha1.WavefrontAlert{}
+		if err := o.Client.Get(ctx, types.NamespacedName{Namespace: alertsConfig.Namespace, Name: template}, wfAlert); err != nil {
+			return nil, err
+		}
+	}
+	params := utils.MergeMaps(ctx, alertsConfig.Spec.GlobalParams, alertsConfig.Spec.Alerts[template].Params)
+	var alert wf.Alert
+	// rendering overwrites the template spec
+	if err := wavefront.RenderAlert(ctx, wfAlert.DeepCopy(), params, &alert); err != nil {
+		return nil, fmt.Errorf("alerts config %s entry %s: %w", alertsConfig.Name, template, err)
+	}
+	wavefront.AddOwnershipTags(&alert, wavefront.Owner{
+		ClusterID: wavefront.Cluster.ID,
+		Namespace: alertsConfig.Namespace,
+		Kind:      alertmanagerv1alpha1.AlertsConfigKind,
+		Name:      alertsConfig.Name,
+		Instance:  template,
+	})
+	return &alert, nil
+}
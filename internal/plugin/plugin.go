@@ -0,0 +1,182 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugin implements the kubectl alertmanager plugin. It reuses the api types and the rendering code of the controllers
+// but not the controller config, so it can run from anywhere with a kubeconfig
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	configcommon "github.com/keikoproj/alert-manager/internal/config/common"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Options are the flags and the clients shared by all the sub commands
+type Options struct {
+	// Client is created from the kubeconfig if not set
+	Client client.Client
+	Out    io.Writer
+
+	Kubeconfig          string
+	Context             string
+	Namespace           string
+	AllNamespaces       bool
+	ControllerNamespace string
+}
+
+// NewCommand function returns the kubectl-alertmanager root command
+func NewCommand(out io.Writer) *cobra.Command {
+	o := &Options{Out: out}
+	cmd := &cobra.Command{
+		Use:           "kubectl-alertmanager",
+		Short:         "Inspect and operate the alert-manager resources",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.complete()
+		},
+	}
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&o.Kubeconfig, "kubeconfig", "", "Path to the kubeconfig file. KUBECONFIG or ~/.kube/config is used if empty")
+	flags.StringVar(&o.Context, "context", "", "Name of the kubeconfig context to use")
+	flags.StringVarP(&o.Namespace, "namespace", "n", "", "Namespace of the resources. Namespace of the kubeconfig context is used if empty")
+	flags.BoolVarP(&o.AllNamespaces, "all-namespaces", "A", false, "Use the resources in all the namespaces")
+	flags.StringVar(&o.ControllerNamespace, "controller-namespace", configcommon.AlertManagerNamespaceName,
+		"Namespace of the alert-manager controller. Its config map is used to render the alerts the same way as the controller")
+
+	cmd.AddCommand(o.newStatusCommand(), o.newInstancesCommand(), o.newResyncCommand(), o.newWhyCommand())
+	return cmd
+}
+
+// complete function creates the client from the kubeconfig
+func (o *Options) complete() error {
+	if o.Client != nil {
+		return nil
+	}
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = o.Kubeconfig
+	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{CurrentContext: o.Context})
+	if o.Namespace == "" {
+		ns, _, err := config.Namespace()
+		if err != nil {
+			return err
+		}
+		o.Namespace = ns
+	}
+	restConfig, err := config.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("unable to load the kubeconfig: %w", err)
+	}
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return err
+	}
+	if err := alertmanagerv1alpha1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	o.Client, err = client.New(restConfig, client.Options{Scheme: scheme})
+	return err
+}
+
+// listNamespace function returns the namespace to list the resources in. Empty means all the namespaces
+func (o *Options) listNamespace() string {
+	if o.AllNamespaces {
+		return ""
+	}
+	return o.Namespace
+}
+
+// loadClusterIdentity function sets the cluster identity from the controller config map so the alerts are rendered with the same name
+// as the controller does. Alerts are rendered without the cluster identity if the config map can't be read
+func (o *Options) loadClusterIdentity(ctx context.Context) error {
+	var cm v1.ConfigMap
+	if err := o.Client.Get(ctx, types.NamespacedName{Namespace: o.ControllerNamespace, Name: configcommon.AlertManagerConfigMapName}, &cm); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	identity, err := wavefront.NewClusterIdentity(strings.TrimSpace(cm.Data[configcommon.ClusterID]),
+		strings.TrimSpace(cm.Data[configcommon.ClusterAlertNamePolicy]), cm.Data[configcommon.ClusterAlertNameSeparator])
+	if err != nil {
+		return err
+	}
+	wavefront.Cluster = identity
+	return nil
+}
+
+// parseKind function returns the kind for the kind name, plural or short name used with kubectl
+func parseKind(kind string) (string, error) {
+	switch strings.ToLower(kind) {
+	case "wavefrontalert", "wavefrontalerts", "wfalert", "wfalerts":
+		return alertmanagerv1alpha1.WavefrontAlertKind, nil
+	case "alertsconfig", "alertsconfigs":
+		return alertmanagerv1alpha1.AlertsConfigKind, nil
+	case "clusterwavefrontalert", "clusterwavefrontalerts", "cwfalert", "cwfalerts":
+		return alertmanagerv1alpha1.ClusterWavefrontAlertKind, nil
+	}
+	return "", fmt.Errorf("unsupported kind %s. must be one of WavefrontAlert, AlertsConfig or ClusterWavefrontAlert", kind)
+}
+
+// getObject function gets the resource of the kind. Namespace is ignored for cluster scoped kinds
+func (o *Options) getObject(ctx context.Context, kind string, name string) (client.Object, error) {
+	var obj client.Object
+	key := types.NamespacedName{Namespace: o.Namespace, Name: name}
+	switch kind {
+	case alertmanagerv1alpha1.WavefrontAlertKind:
+		obj = &alertmanagerv1alpha1.WavefrontAlert{}
+	case alertmanagerv1alpha1.AlertsConfigKind:
+		obj = &alertmanagerv1alpha1.AlertsConfig{}
+	case alertmanagerv1alpha1.ClusterWavefrontAlertKind:
+		obj = &alertmanagerv1alpha1.ClusterWavefrontAlert{}
+		key.Namespace = ""
+	default:
+		return nil, fmt.Errorf("unsupported kind %s", kind)
+	}
+	if err := o.Client.Get(ctx, key, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// templateKind function returns the template kind of the alerts config entry. Empty kind means WavefrontAlert
+func templateKind(global alertmanagerv1alpha1.GVK, config alertmanagerv1alpha1.Config) string {
+	kind := global.Kind
+	if config.GVK.Kind != "" {
+		kind = config.GVK.Kind
+	}
+	if kind == "" {
+		return alertmanagerv1alpha1.WavefrontAlertKind
+	}
+	return kind
+}
+
+// orDash function returns "-" for the empty values in the tables
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
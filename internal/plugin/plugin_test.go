@@ -0,0 +1,197 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestOptions(t *testing.T, objs ...client.Object) (*Options, *bytes.Buffer) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, alertmanagerv1alpha1.AddToScheme(scheme))
+	out := &bytes.Buffer{}
+	return &Options{
+		Client:              fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+		Out:                 out,
+		Namespace:           "team-a",
+		ControllerNamespace: "alert-manager-system",
+	}, out
+}
+
+func testObjects() []client.Object {
+	minutes := intstr.FromInt32(5)
+	template := &alertmanagerv1alpha1.WavefrontAlert{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "cpu"},
+		Spec: alertmanagerv1alpha1.WavefrontAlertSpec{
+			AlertType:         "CLASSIC",
+			AlertName:         "cpu-{{ .app }}",
+			Condition:         "ts(cpu, app={{ .app }}) > {{ .threshold }}",
+			DisplayExpression: "ts(cpu, app={{ .app }})",
+			Minutes:           &minutes,
+			ResolveAfter:      &minutes,
+			Severity:          "warn",
+			ExportedParams:    []string{"app", "threshold"},
+		},
+		Status: alertmanagerv1alpha1.WavefrontAlertStatus{State: alertmanagerv1alpha1.ReadyToBeUsed},
+	}
+	standalone := &alertmanagerv1alpha1.WavefrontAlert{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "disk"},
+		Status: alertmanagerv1alpha1.WavefrontAlertStatus{
+			State: alertmanagerv1alpha1.Ready,
+			AlertsStatus: map[string]alertmanagerv1alpha1.AlertStatus{
+				"disk": {ID: "100", Name: "disk", State: alertmanagerv1alpha1.Ready, Link: "https://wavefront/alerts/100"},
+			},
+		},
+	}
+	alertsConfig := &alertmanagerv1alpha1.AlertsConfig{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "checkout", UID: "uid-checkout"},
+		Spec: alertmanagerv1alpha1.AlertsConfigSpec{
+			GlobalGVK:    alertmanagerv1alpha1.GVK{Kind: alertmanagerv1alpha1.WavefrontAlertKind},
+			GlobalParams: alertmanagerv1alpha1.OrderedMap{"app": "checkout"},
+			Alerts: map[string]alertmanagerv1alpha1.Config{
+				"cpu": {Params: alertmanagerv1alpha1.OrderedMap{"threshold": "80"}},
+			},
+		},
+		Status: alertmanagerv1alpha1.AlertsConfigStatus{
+			State:      alertmanagerv1alpha1.Error,
+			RetryCount: 2,
+			AlertsStatus: map[string]alertmanagerv1alpha1.AlertStatus{
+				"cpu": {
+					ID:               "200",
+					Name:             "cpu-checkout",
+					State:            alertmanagerv1alpha1.Error,
+					ErrorDescription: "wavefront returned 400",
+					AssociatedAlert:  alertmanagerv1alpha1.AssociatedAlert{CR: "cpu", Kind: alertmanagerv1alpha1.WavefrontAlertKind},
+					FiringState:      alertmanagerv1alpha1.Firing,
+				},
+			},
+		},
+	}
+	pending := &alertmanagerv1alpha1.AlertsConfig{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "payments"},
+		Spec: alertmanagerv1alpha1.AlertsConfigSpec{
+			GlobalParams: alertmanagerv1alpha1.OrderedMap{"app": "payments"},
+			Alerts: map[string]alertmanagerv1alpha1.Config{
+				"cpu": {Params: alertmanagerv1alpha1.OrderedMap{"threshold": "90"}},
+			},
+		},
+	}
+	event := &v1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: "team-a", Name: "checkout.1"},
+		InvolvedObject: v1.ObjectReference{Kind: alertmanagerv1alpha1.AlertsConfigKind, Name: "checkout", UID: "uid-checkout"},
+		Type:           v1.EventTypeWarning,
+		Reason:         "Error",
+		Message:        "unable to update the alert",
+		LastTimestamp:  metav1.NewTime(time.Now()),
+	}
+	return []client.Object{template, standalone, alertsConfig, pending, event}
+}
+
+func TestStatus(t *testing.T) {
+	o, out := newTestOptions(t, testObjects()...)
+	assert.NoError(t, o.runStatus(context.Background()))
+
+	output := out.String()
+	assert.Contains(t, output, "WavefrontAlert  ReadyToBeUsed  1")
+	assert.Contains(t, output, "AlertsConfig    Error          1")
+	assert.Contains(t, output, "https://wavefront/alerts/100")
+	assert.Contains(t, output, "cpu-checkout")
+	assert.NotContains(t, output, "WavefrontAlert  cpu ", "templates are listed with their alerts configs")
+}
+
+func TestInstances(t *testing.T) {
+	t.Run("lists the alerts configs using the template", func(t *testing.T) {
+		o, out := newTestOptions(t, testObjects()...)
+		assert.NoError(t, o.runInstances(context.Background(), "cpu", alertmanagerv1alpha1.WavefrontAlertKind, false))
+		output := out.String()
+		assert.Contains(t, output, "checkout")
+		assert.Contains(t, output, "200")
+		assert.Contains(t, output, "FIRING")
+		assert.Contains(t, output, "payments", "alerts config not rendered yet is listed")
+	})
+
+	t.Run("renders the alerts", func(t *testing.T) {
+		o, out := newTestOptions(t, testObjects()...)
+		assert.NoError(t, o.runInstances(context.Background(), "cpu", alertmanagerv1alpha1.WavefrontAlertKind, true))
+		output := out.String()
+		assert.Contains(t, output, "ts(cpu, app=checkout) > 80")
+		assert.Contains(t, output, "ts(cpu, app=payments) > 90")
+	})
+
+	t.Run("rejects the alerts config kind", func(t *testing.T) {
+		o, _ := newTestOptions(t)
+		assert.Error(t, o.runInstances(context.Background(), "cpu", alertmanagerv1alpha1.AlertsConfigKind, false))
+	})
+}
+
+func TestResync(t *testing.T) {
+	o, out := newTestOptions(t, testObjects()...)
+	assert.NoError(t, o.runResync(context.Background(), alertmanagerv1alpha1.AlertsConfigKind, "checkout"))
+	assert.Contains(t, out.String(), "reconcile requested")
+
+	var alertsConfig alertmanagerv1alpha1.AlertsConfig
+	assert.NoError(t, o.Client.Get(context.Background(), types.NamespacedName{Namespace: "team-a", Name: "checkout"}, &alertsConfig))
+	assert.NotEmpty(t, alertsConfig.Annotations[alertmanagerv1alpha1.ReconcileRequestAnnotation])
+
+	assert.Error(t, o.runResync(context.Background(), alertmanagerv1alpha1.AlertsConfigKind, "missing"))
+}
+
+func TestWhy(t *testing.T) {
+	t.Run("explains the failed alerts", func(t *testing.T) {
+		o, out := newTestOptions(t, testObjects()...)
+		assert.NoError(t, o.runWhy(context.Background(), alertmanagerv1alpha1.AlertsConfigKind, "checkout", ""))
+		output := out.String()
+		assert.Contains(t, output, "retry count 2")
+		assert.Contains(t, output, "wavefront returned 400")
+		assert.Contains(t, output, "unable to update the alert")
+	})
+
+	t.Run("reports no errors", func(t *testing.T) {
+		o, out := newTestOptions(t, testObjects()...)
+		assert.NoError(t, o.runWhy(context.Background(), alertmanagerv1alpha1.WavefrontAlertKind, "disk", "disk"))
+		assert.Contains(t, out.String(), "No errors found")
+	})
+
+	t.Run("fails for an unknown alert", func(t *testing.T) {
+		o, _ := newTestOptions(t, testObjects()...)
+		assert.Error(t, o.runWhy(context.Background(), alertmanagerv1alpha1.AlertsConfigKind, "checkout", "memory"))
+	})
+}
+
+func TestParseKind(t *testing.T) {
+	for _, kind := range []string{"wfalerts", "WavefrontAlert", "alertsconfig", "cwfalert"} {
+		_, err := parseKind(kind)
+		assert.NoError(t, err, kind)
+	}
+	_, err := parseKind("pods")
+	assert.Error(t, err)
+}
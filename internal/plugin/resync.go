@@ -0,0 +1,65 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func (o *Options) newResyncCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "resync KIND NAME",
+		Short: "Trigger a reconcile of the resource by setting the reconcile request annotation",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kind, err := parseKind(args[0])
+			if err != nil {
+				return err
+			}
+			return o.runResync(cmd.Context(), kind, args[1])
+		},
+	}
+}
+
+func (o *Options) runResync(ctx context.Context, kind string, name string) error {
+	obj, err := o.getObject(ctx, kind, name)
+	if err != nil {
+		return err
+	}
+	requestedAt := time.Now().UTC().Format(time.RFC3339Nano)
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{alertmanagerv1alpha1.ReconcileRequestAnnotation: requestedAt},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if err := o.Client.Patch(ctx, obj, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return err
+	}
+	fmt.Fprintf(o.Out, "%s/%s reconcile requested at %s\n", kind, name, requestedAt)
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func (o *Options) newStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Summarize the WavefrontAlerts and AlertsConfigs by state with their wavefront links",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.runStatus(cmd.Context())
+		},
+	}
+}
+
+// statusRow is an alert instance in the status output
+type statusRow struct {
+	namespace string
+	kind      string
+	name      string
+	alert     string
+	state     alertmanagerv1alpha1.State
+	link      string
+}
+
+func (o *Options) runStatus(ctx context.Context) error {
+	var wfAlerts alertmanagerv1alpha1.WavefrontAlertList
+	if err := o.Client.List(ctx, &wfAlerts, client.InNamespace(o.listNamespace())); err != nil {
+		return err
+	}
+	var alertsConfigs alertmanagerv1alpha1.AlertsConfigList
+	if err := o.Client.List(ctx, &alertsConfigs, client.InNamespace(o.listNamespace())); err != nil {
+		return err
+	}
+
+	counts := map[string]map[alertmanagerv1alpha1.State]int{}
+	count := func(kind string, state alertmanagerv1alpha1.State) {
+		if counts[kind] == nil {
+			counts[kind] = map[alertmanagerv1alpha1.State]int{}
+		}
+		counts[kind][state]++
+	}
+	var rows []statusRow
+	for _, wfAlert := range wfAlerts.Items {
+		count(alertmanagerv1alpha1.WavefrontAlertKind, wfAlert.Status.State)
+		// templates are listed with the alerts configs using them
+		if len(wfAlert.Spec.ExportedParams) > 0 {
+			continue
+		}
+		rows = append(rows, alertRows(wfAlert.Namespace, alertmanagerv1alpha1.WavefrontAlertKind, wfAlert.Name, wfAlert.Status.State, wfAlert.Status.AlertsStatus)...)
+	}
+	for _, alertsConfig := range alertsConfigs.Items {
+		count(alertmanagerv1alpha1.AlertsConfigKind, alertsConfig.Status.State)
+		rows = append(rows, alertRows(alertsConfig.Namespace, alertmanagerv1alpha1.AlertsConfigKind, alertsConfig.Name, alertsConfig.Status.State, alertsConfig.Status.AlertsStatus)...)
+	}
+
+	w := tabwriter.NewWriter(o.Out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tSTATE\tCOUNT")
+	for _, kind := range []string{alertmanagerv1alpha1.WavefrontAlertKind, alertmanagerv1alpha1.AlertsConfigKind} {
+		states := make([]string, 0, len(counts[kind]))
+		for state := range counts[kind] {
+			states = append(states, string(state))
+		}
+		sort.Strings(states)
+		for _, state := range states {
+			fmt.Fprintf(w, "%s\t%s\t%d\n", kind, orDash(state), counts[kind][alertmanagerv1alpha1.State(state)])
+		}
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "NAMESPACE\tKIND\tNAME\tALERT\tSTATE\tLINK")
+	for _, row := range rows {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", row.namespace, row.kind, row.name, orDash(row.alert), orDash(string(row.state)), orDash(row.link))
+	}
+	return w.Flush()
+}
+
+// alertRows function returns a row per alert in the status, sorted by the alert key. Resource without any alert has a single row
+func alertRows(namespace string, kind string, name string, state alertmanagerv1alpha1.State, alertsStatus map[string]alertmanagerv1alpha1.AlertStatus) []statusRow {
+	if len(alertsStatus) == 0 {
+		return []statusRow{{namespace: namespace, kind: kind, name: name, state: state}}
+	}
+	keys := make([]string, 0, len(alertsStatus))
+	for key := range alertsStatus {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	rows := make([]statusRow, 0, len(keys))
+	for _, key := range keys {
+		alert := alertsStatus[key]
+		rows = append(rows, statusRow{namespace: namespace, kind: kind, name: name, alert: alert.Name, state: alert.State, link: alert.Link})
+	}
+	return rows
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maxEvents is the number of the latest events printed by why
+const maxEvents = 10
+
+func (o *Options) newWhyCommand() *cobra.Command {
+	var kind string
+	cmd := &cobra.Command{
+		Use:   "why NAME [ALERT]",
+		Short: "Explain why the resource or one of its alerts is not ready",
+		Long: "Print the error of the resource and of its alerts which are not ready along with the latest events. " +
+			"ALERT is the key of the alert in the resource status, for ex: the template name for an AlertsConfig",
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resourceKind, err := parseKind(kind)
+			if err != nil {
+				return err
+			}
+			alert := ""
+			if len(args) == 2 {
+				alert = args[1]
+			}
+			return o.runWhy(cmd.Context(), resourceKind, args[0], alert)
+		},
+	}
+	cmd.Flags().StringVar(&kind, "kind", alertmanagerv1alpha1.AlertsConfigKind, "Kind of the resource: AlertsConfig, WavefrontAlert or ClusterWavefrontAlert")
+	return cmd
+}
+
+// resourceStatus is the status shared by all the kinds
+type resourceStatus struct {
+	state            alertmanagerv1alpha1.State
+	retryCount       int
+	errorDescription string
+	alertsStatus     map[string]alertmanagerv1alpha1.AlertStatus
+}
+
+func (o *Options) runWhy(ctx context.Context, kind string, name string, alert string) error {
+	obj, err := o.getObject(ctx, kind, name)
+	if err != nil {
+		return err
+	}
+	status := statusOf(obj)
+
+	found := false
+	fmt.Fprintf(o.Out, "%s %s: %s (retry count %d)\n", kind, name, orDash(string(status.state)), status.retryCount)
+	if status.errorDescription != "" {
+		found = true
+		fmt.Fprintf(o.Out, "  error: %s\n", status.errorDescription)
+	}
+
+	if alert != "" {
+		alertStatus, ok := status.alertsStatus[alert]
+		if !ok {
+			return fmt.Errorf("alert %s not found in the status of %s %s", alert, kind, name)
+		}
+		o.printAlertStatus(alert, alertStatus)
+		found = found || alertStatus.ErrorDescription != "" || alertStatus.State != alertmanagerv1alpha1.Ready
+	} else {
+		keys := make([]string, 0, len(status.alertsStatus))
+		for key := range status.alertsStatus {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			alertStatus := status.alertsStatus[key]
+			if alertStatus.State == alertmanagerv1alpha1.Ready && alertStatus.ErrorDescription == "" {
+				continue
+			}
+			found = true
+			o.printAlertStatus(key, alertStatus)
+		}
+	}
+
+	events, err := o.events(ctx, kind, obj)
+	if err != nil {
+		return err
+	}
+	if len(events) > 0 {
+		found = true
+		fmt.Fprintln(o.Out, "Events:")
+		for _, event := range events {
+			fmt.Fprintf(o.Out, "  %s  %s  %s  %s\n", event.LastTimestamp.UTC().Format("2006-01-02T15:04:05Z"), event.Type, event.Reason, event.Message)
+		}
+	}
+	if !found {
+		fmt.Fprintln(o.Out, "No errors found")
+	}
+	return nil
+}
+
+func (o *Options) printAlertStatus(key string, alertStatus alertmanagerv1alpha1.AlertStatus) {
+	fmt.Fprintf(o.Out, "Alert %s: %s\n", key, orDash(string(alertStatus.State)))
+	if alertStatus.Name != "" {
+		fmt.Fprintf(o.Out, "  name: %s\n", alertStatus.Name)
+	}
+	if alertStatus.Link != "" {
+		fmt.Fprintf(o.Out, "  link: %s\n", alertStatus.Link)
+	}
+	if alertStatus.ErrorDescription != "" {
+		fmt.Fprintf(o.Out, "  error: %s\n", alertStatus.ErrorDescription)
+	}
+	if !alertStatus.LastUpdatedTimestamp.IsZero() {
+		fmt.Fprintf(o.Out, "  last updated: %s\n", alertStatus.LastUpdatedTimestamp.UTC().Format("2006-01-02T15:04:05Z"))
+	}
+}
+
+// statusOf function returns the status of the resource. Alerts of a ClusterWavefrontAlert are keyed by namespace/alerts config
+func statusOf(obj client.Object) resourceStatus {
+	switch o := obj.(type) {
+	case *alertmanagerv1alpha1.WavefrontAlert:
+		return resourceStatus{state: o.Status.State, retryCount: o.Status.RetryCount, errorDescription: o.Status.ErrorDescription, alertsStatus: o.Status.AlertsStatus}
+	case *alertmanagerv1alpha1.AlertsConfig:
+		return resourceStatus{state: o.Status.State, retryCount: o.Status.RetryCount, errorDescription: o.Status.ErrorDescription, alertsStatus: o.Status.AlertsStatus}
+	case *alertmanagerv1alpha1.ClusterWavefrontAlert:
+		alertsStatus := map[string]alertmanagerv1alpha1.AlertStatus{}
+		for namespace, namespaceStatus := range o.Status.Namespaces {
+			for name, alertStatus := range namespaceStatus.AlertsStatus {
+				alertsStatus[namespace+"/"+name] = alertStatus
+			}
+		}
+		return resourceStatus{state: o.Status.State, retryCount: o.Status.RetryCount, errorDescription: o.Status.ErrorDescription, alertsStatus: alertsStatus}
+	}
+	return resourceStatus{}
+}
+
+// events function returns the latest events of the resource, oldest first. Events of the cluster scoped resources are in the default namespace
+func (o *Options) events(ctx context.Context, kind string, obj client.Object) ([]v1.Event, error) {
+	namespace := obj.GetNamespace()
+	if namespace == "" {
+		namespace = v1.NamespaceDefault
+	}
+	var list v1.EventList
+	if err := o.Client.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	var events []v1.Event
+	for _, event := range list.Items {
+		if event.InvolvedObject.Kind == kind && event.InvolvedObject.Name == obj.GetName() && event.InvolvedObject.UID == obj.GetUID() {
+			events = append(events, event)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].LastTimestamp.Before(&events[j].LastTimestamp)
+	})
+	if len(events) > maxEvents {
+		events = events[len(events)-maxEvents:]
+	}
+	return events, nil
+}
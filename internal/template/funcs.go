@@ -0,0 +1,195 @@
+package template
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// FuncMap returns the functions available in the alert templates
+//
+//	default "value" .param    - .param if it is not empty otherwise "value". Use (index . "param") for optional params
+//	upper, lower              - change the case
+//	replace "old" "new" .param
+//	join "," .list, split "," .param
+//	quote .param              - double quoted and escaped string
+//	toJson .param             - json encoded value
+//	regexMatch "^a.*" .param  - true if the param matches the regex
+//	add, sub, mul, div, mod   - numeric math on params. Integers stay integers
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"default":    defaultValue,
+		"upper":      strings.ToUpper,
+		"lower":      strings.ToLower,
+		"replace":    replace,
+		"join":       join,
+		"split":      split,
+		"quote":      quote,
+		"toJson":     toJSON,
+		"regexMatch": regexMatch,
+		"add":        add,
+		"sub":        sub,
+		"mul":        mul,
+		"div":        div,
+		"mod":        mod,
+	}
+}
+
+func defaultValue(def interface{}, val ...interface{}) interface{} {
+	if len(val) == 0 || val[0] == nil {
+		return def
+	}
+	if s, ok := val[0].(string); ok && s == "" {
+		return def
+	}
+	return val[0]
+}
+
+func replace(old, repl, s string) string {
+	return strings.ReplaceAll(s, old, repl)
+}
+
+func join(sep string, list interface{}) (string, error) {
+	switch l := list.(type) {
+	case []string:
+		return strings.Join(l, sep), nil
+	case []interface{}:
+		items := make([]string, 0, len(l))
+		for _, item := range l {
+			items = append(items, fmt.Sprint(item))
+		}
+		return strings.Join(items, sep), nil
+	case string:
+		return l, nil
+	}
+	return "", fmt.Errorf("join: unsupported type %T", list)
+}
+
+func split(sep, s string) []string {
+	return strings.Split(s, sep)
+}
+
+func quote(s interface{}) string {
+	return strconv.Quote(fmt.Sprint(s))
+}
+
+func toJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func regexMatch(regex string, s string) (bool, error) {
+	return regexp.MatchString(regex, s)
+}
+
+// number is a param converted to int64 if possible otherwise float64
+type number struct {
+	i       int64
+	f       float64
+	isFloat bool
+}
+
+func toNumber(v interface{}) (number, error) {
+	switch n := v.(type) {
+	case int:
+		return number{i: int64(n)}, nil
+	case int64:
+		return number{i: n}, nil
+	case float64:
+		return number{f: n, isFloat: true}, nil
+	case string:
+		s := strings.TrimSpace(n)
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return number{i: i}, nil
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return number{}, fmt.Errorf("%q is not a number", n)
+		}
+		return number{f: f, isFloat: true}, nil
+	}
+	return number{}, fmt.Errorf("%v is not a number", v)
+}
+
+func (n number) float() float64 {
+	if n.isFloat {
+		return n.f
+	}
+	return float64(n.i)
+}
+
+// arithmetic applies the int or float operation based on the operands
+func arithmetic(a, b interface{}, intOp func(x, y int64) (int64, error), floatOp func(x, y float64) (float64, error)) (interface{}, error) {
+	x, err := toNumber(a)
+	if err != nil {
+		return nil, err
+	}
+	y, err := toNumber(b)
+	if err != nil {
+		return nil, err
+	}
+	if !x.isFloat && !y.isFloat {
+		return intOp(x.i, y.i)
+	}
+	return floatOp(x.float(), y.float())
+}
+
+func add(a, b interface{}) (interface{}, error) {
+	return arithmetic(a, b,
+		func(x, y int64) (int64, error) { return x + y, nil },
+		func(x, y float64) (float64, error) { return x + y, nil })
+}
+
+func sub(a, b interface{}) (interface{}, error) {
+	return arithmetic(a, b,
+		func(x, y int64) (int64, error) { return x - y, nil },
+		func(x, y float64) (float64, error) { return x - y, nil })
+}
+
+func mul(a, b interface{}) (interface{}, error) {
+	return arithmetic(a, b,
+		func(x, y int64) (int64, error) { return x * y, nil },
+		func(x, y float64) (float64, error) { return x * y, nil })
+}
+
+var errDivideByZero = errors.New("division by zero")
+
+func div(a, b interface{}) (interface{}, error) {
+	return arithmetic(a, b,
+		func(x, y int64) (int64, error) {
+			if y == 0 {
+				return 0, errDivideByZero
+			}
+			return x / y, nil
+		},
+		func(x, y float64) (float64, error) {
+			if y == 0 {
+				return 0, errDivideByZero
+			}
+			return x / y, nil
+		})
+}
+
+func mod(a, b interface{}) (interface{}, error) {
+	return arithmetic(a, b,
+		func(x, y int64) (int64, error) {
+			if y == 0 {
+				return 0, errDivideByZero
+			}
+			return x % y, nil
+		},
+		func(x, y float64) (float64, error) {
+			if y == 0 {
+				return 0, errDivideByZero
+			}
+			return math.Mod(x, y), nil
+		})
+}
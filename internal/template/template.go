@@ -3,26 +3,93 @@ package template
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
 	"github.com/keikoproj/alert-manager/pkg/log"
-	"html/template"
 )
 
 // ProcessTemplate process the go lang template byb substituting with the provided values
+// Templates are rendered as plain text and referring a param which is not provided is an error
 func ProcessTemplate(ctx context.Context, input string, val map[string]string) (string, error) {
+	return processTemplate(ctx, "alert.tmpl", input, val)
+}
+
+// ProcessJSONTemplate renders every string value in the json document as a go lang template.
+// Each value is rendered separately so substituted values can't break the json document and errors include the field name
+func ProcessJSONTemplate(ctx context.Context, input []byte, val map[string]string) ([]byte, error) {
+	log := log.Logger(ctx, "internal.template", "ProcessJSONTemplate")
+
+	var doc interface{}
+	if err := json.Unmarshal(input, &doc); err != nil {
+		log.Error(err, "input is not a valid json")
+		return nil, err
+	}
+	rendered, err := processJSONValue(ctx, "", doc, val)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(rendered)
+}
+
+// processJSONValue walks the json value and renders string values. path is used as the template name
+func processJSONValue(ctx context.Context, path string, value interface{}, val map[string]string) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		if !strings.Contains(v, "{{") {
+			return v, nil
+		}
+		return processTemplate(ctx, path, v, val)
+	case map[string]interface{}:
+		// sort the keys so the first failing field is always the same
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fieldPath := k
+			if path != "" {
+				fieldPath = path + "." + k
+			}
+			rendered, err := processJSONValue(ctx, fieldPath, v[k], val)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = rendered
+		}
+		return v, nil
+	case []interface{}:
+		for i := range v {
+			rendered, err := processJSONValue(ctx, fmt.Sprintf("%s[%d]", path, i), v[i], val)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = rendered
+		}
+		return v, nil
+	}
+	return value, nil
+}
+
+func processTemplate(ctx context.Context, name string, input string, val map[string]string) (string, error) {
 	log := log.Logger(ctx, "internal.template", "ProcessTemplate")
-	log.V(4).Info("processing template", "input", input)
+	log.V(4).Info("processing template", "name", name, "input", input)
 
-	tmpl, err := template.New("alert.tmpl").Parse(input)
+	tmpl, err := template.New(name).Option("missingkey=error").Funcs(FuncMap()).Parse(input)
 	if err != nil {
-		log.Error(err, "template is NOT valid")
-		return "", err
+		log.Error(err, "template is NOT valid", "field", name)
+		return "", fmt.Errorf("invalid template in field %s: %w", name, err)
 	}
 
 	buf := &bytes.Buffer{}
 	if err := tmpl.Execute(buf, val); err != nil {
-		log.Error(err, "unable to execute template")
-		return "", err
+		log.Error(err, "unable to execute template", "field", name)
+		return "", fmt.Errorf("unable to render field %s: %w", name, err)
 	}
-	log.Info("Template executed successfully", "temp", buf.String())
+	log.V(1).Info("Template executed successfully", "field", name, "temp", buf.String())
 	return buf.String(), nil
 }
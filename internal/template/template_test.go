@@ -55,4 +55,80 @@ var _ = Describe("Template", func() {
 		})
 
 	})
+	Describe("Test text rendering", func() {
+		It("Should not escape conditions and urls", func() {
+			resp, err := template.ProcessTemplate(context.Background(), "ts({{ .metric }}) > 10 && {{ .url }}", map[string]string{
+				"metric": "app.errors",
+				"url":    "https://example.com/runbook?a=1&b=2",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal("ts(app.errors) > 10 && https://example.com/runbook?a=1&b=2"))
+		})
+		It("Should error out for missing params", func() {
+			_, err := template.ProcessTemplate(context.Background(), "some {{ .missing }} variable", map[string]string{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("missing"))
+		})
+	})
+
+	Describe("Test ProcessJSONTemplate", func() {
+		It("Should render each field and keep the json valid", func() {
+			wfAlert := v1alpha1.WavefrontAlert{
+				Spec: v1alpha1.WavefrontAlertSpec{
+					AlertName: "{{ .app }}-alert",
+					Condition: `ts(app.errors, app="{{ .app }}") > {{ .threshold }}`,
+					Tags:      []string{"{{ .team }}"},
+				},
+			}
+			tempBytes, _ := json.Marshal(wfAlert.Spec)
+			resp, err := template.ProcessJSONTemplate(context.Background(), tempBytes, map[string]string{
+				"app":       `my"app`,
+				"threshold": "10",
+				"team":      "platform",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			var spec v1alpha1.WavefrontAlertSpec
+			Expect(json.Unmarshal(resp, &spec)).To(Succeed())
+			Expect(spec.AlertName).To(Equal(`my"app-alert`))
+			Expect(spec.Condition).To(Equal(`ts(app.errors, app="my"app") > 10`))
+			Expect(spec.Tags).To(Equal([]string{"platform"}))
+		})
+		It("Should report the field which failed", func() {
+			tempBytes := []byte(`{"alertName": "ok", "tags": ["{{ .team }}"]}`)
+			_, err := template.ProcessJSONTemplate(context.Background(), tempBytes, map[string]string{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("tags[0]"))
+			Expect(err.Error()).To(ContainSubstring("team"))
+		})
+	})
+
+	Describe("Test functions", func() {
+		render := func(input string, params map[string]string) string {
+			resp, err := template.ProcessTemplate(context.Background(), input, params)
+			Expect(err).NotTo(HaveOccurred())
+			return resp
+		}
+		It("Should support string functions", func() {
+			params := map[string]string{"app": "My-App", "hosts": "a,b", "empty": ""}
+			Expect(render(`{{ .app | upper }}`, params)).To(Equal("MY-APP"))
+			Expect(render(`{{ .app | lower }}`, params)).To(Equal("my-app"))
+			Expect(render(`{{ .app | replace "-" "_" }}`, params)).To(Equal("My_App"))
+			Expect(render(`{{ .hosts | split "," | join "|" }}`, params)).To(Equal("a|b"))
+			Expect(render(`{{ .app | quote }}`, params)).To(Equal(`"My-App"`))
+			Expect(render(`{{ .hosts | split "," | toJson }}`, params)).To(Equal(`["a","b"]`))
+			Expect(render(`{{ .empty | default "none" }}`, params)).To(Equal("none"))
+			Expect(render(`{{ index . "missing" | default "none" }}`, params)).To(Equal("none"))
+			Expect(render(`{{ if regexMatch "^My" .app }}yes{{ end }}`, params)).To(Equal("yes"))
+		})
+		It("Should support numeric functions", func() {
+			params := map[string]string{"threshold": "80", "ratio": "0.5"}
+			Expect(render(`{{ add .threshold 10 }}`, params)).To(Equal("90"))
+			Expect(render(`{{ sub .threshold 10 }}`, params)).To(Equal("70"))
+			Expect(render(`{{ mul .threshold .ratio }}`, params)).To(Equal("40"))
+			Expect(render(`{{ div .threshold 3 }}`, params)).To(Equal("26"))
+			Expect(render(`{{ mod .threshold 3 }}`, params)).To(Equal("2"))
+			_, err := template.ProcessTemplate(context.Background(), `{{ div .threshold 0 }}`, params)
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
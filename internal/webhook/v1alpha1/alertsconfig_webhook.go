@@ -0,0 +1,148 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/keikoproj/alert-manager/internal/utils"
+	"github.com/keikoproj/alert-manager/pkg/log"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/validate-alertmanager-keikoproj-io-v1alpha1-alertsconfig,mutating=false,failurePolicy=ignore,sideEffects=None,groups=alertmanager.keikoproj.io,resources=alertsconfigs,verbs=create;update,versions=v1alpha1,name=valertsconfig-v1alpha1.keikoproj.io,admissionReviewVersions=v1
+
+// SetupAlertsConfigWebhookWithManager function registers the AlertsConfig validating webhook.
+// Conversion webhook is registered as well since AlertsConfig is served in multiple versions
+func SetupAlertsConfigWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &alertmanagerv1alpha1.AlertsConfig{}).
+		WithValidator(&AlertsConfigValidator{Client: mgr.GetAPIReader()}).
+		Complete()
+}
+
+// AlertsConfigValidator rejects the AlertsConfig whose alert params don't match the exported params schema of their template
+type AlertsConfigValidator struct {
+	// Client reads the templates of the alerts
+	Client client.Reader
+}
+
+// ValidateCreate validates the params of every alert of the new AlertsConfig
+func (v *AlertsConfigValidator) ValidateCreate(ctx context.Context, obj *alertmanagerv1alpha1.AlertsConfig) (admission.Warnings, error) {
+	return v.validateParams(ctx, nil, obj)
+}
+
+// ValidateUpdate validates the params of the alerts which got changed. Deleting resource is not validated so the finalizer can be removed
+func (v *AlertsConfigValidator) ValidateUpdate(ctx context.Context, oldObj, newObj *alertmanagerv1alpha1.AlertsConfig) (admission.Warnings, error) {
+	if newObj.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	return v.validateParams(ctx, oldObj, newObj)
+}
+
+// ValidateDelete doesn't validate anything
+func (v *AlertsConfigValidator) ValidateDelete(ctx context.Context, obj *alertmanagerv1alpha1.AlertsConfig) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateParams function validates the merged global and alert params of every alert in alerts section against the exported params schema of its template.
+// Alert whose params and template kind are the same as in the old spec is not validated again so the existing resources can still be updated.
+// Missing template is returned as a warning since the template can be created after the alerts config.
+// Templates selected by the template selector get only the global params and are validated by the reconciler
+func (v *AlertsConfigValidator) validateParams(ctx context.Context, oldObj *alertmanagerv1alpha1.AlertsConfig, obj *alertmanagerv1alpha1.AlertsConfig) (admission.Warnings, error) {
+	log := log.Logger(ctx, "internal.webhook.v1alpha1", "validateParams")
+	alertsPath := field.NewPath("spec", "alerts")
+
+	var allErrs field.ErrorList
+	var warnings admission.Warnings
+	// sorted so the errors are in the same order for the same spec
+	for _, name := range slices.Sorted(maps.Keys(obj.Spec.Alerts)) {
+		config := obj.Spec.Alerts[name]
+		kind := templateKind(obj.Spec.GlobalGVK, config)
+		params := utils.MergeMaps(ctx, obj.Spec.GlobalParams, config.Params)
+		if oldObj != nil {
+			if oldConfig, ok := oldObj.Spec.Alerts[name]; ok && templateKind(oldObj.Spec.GlobalGVK, oldConfig) == kind &&
+				reflect.DeepEqual(utils.MergeMaps(ctx, oldObj.Spec.GlobalParams, oldConfig.Params), params) {
+				continue
+			}
+		}
+		template, err := v.getTemplate(ctx, obj.Namespace, name, kind)
+		if apierrors.IsNotFound(err) {
+			warnings = append(warnings, fmt.Sprintf("%s: %s %s not found. params are validated once it is created", alertsPath.Key(name), kind, name))
+			continue
+		}
+		if err != nil {
+			return warnings, apierrors.NewInternalError(err)
+		}
+		// standalone template is rejected by the reconciler
+		if template == nil || len(template.ExportedParams) == 0 {
+			continue
+		}
+		params = utils.MergeMaps(ctx, template.ExportedParamsDefaultValues, params)
+		if err := wavefront.ValidateParamsSchema(ctx, template.ExportedParams, template.ExportedParamsSchema, params); err != nil {
+			allErrs = append(allErrs, field.Invalid(alertsPath.Key(name).Child("params"), config.Params, err.Error()))
+		}
+	}
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+	log.Info("rejecting invalid alerts config params", "namespace", obj.Namespace, "name", obj.Name, "errors", allErrs.ToAggregate().Error())
+	return warnings, apierrors.NewInvalid(schema.GroupKind{Group: alertmanagerv1alpha1.GroupVersion.Group, Kind: alertmanagerv1alpha1.AlertsConfigKind}, obj.Name, allErrs)
+}
+
+// getTemplate function returns the spec of the WavefrontAlert in the namespace or of the ClusterWavefrontAlert.
+// Nil is returned for the other kinds
+func (v *AlertsConfigValidator) getTemplate(ctx context.Context, namespace string, name string, kind string) (*alertmanagerv1alpha1.WavefrontAlertSpec, error) {
+	switch kind {
+	case alertmanagerv1alpha1.WavefrontAlertKind:
+		var wfAlert alertmanagerv1alpha1.WavefrontAlert
+		if err := v.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &wfAlert); err != nil {
+			return nil, err
+		}
+		return &wfAlert.Spec, nil
+	case alertmanagerv1alpha1.ClusterWavefrontAlertKind:
+		var clusterAlert alertmanagerv1alpha1.ClusterWavefrontAlert
+		if err := v.Client.Get(ctx, types.NamespacedName{Name: name}, &clusterAlert); err != nil {
+			return nil, err
+		}
+		return &clusterAlert.Spec.WavefrontAlertSpec, nil
+	}
+	return nil, nil
+}
+
+// templateKind function returns the template kind of the alerts config entry. Empty kind means WavefrontAlert
+func templateKind(globalGVK alertmanagerv1alpha1.GVK, config alertmanagerv1alpha1.Config) string {
+	kind := globalGVK.Kind
+	if config.GVK.Kind != "" {
+		kind = config.GVK.Kind
+	}
+	if kind == "" {
+		return alertmanagerv1alpha1.WavefrontAlertKind
+	}
+	return kind
+}
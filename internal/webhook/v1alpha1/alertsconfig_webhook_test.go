@@ -0,0 +1,93 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func alertsConfig(threshold string) *alertmanagerv1alpha1.AlertsConfig {
+	return &alertmanagerv1alpha1.AlertsConfig{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "app"},
+		Spec: alertmanagerv1alpha1.AlertsConfigSpec{
+			GlobalParams: alertmanagerv1alpha1.OrderedMap{"app": "checkout"},
+			Alerts: map[string]alertmanagerv1alpha1.Config{
+				"cpu": {Params: alertmanagerv1alpha1.OrderedMap{"threshold": threshold}},
+			},
+		},
+	}
+}
+
+func TestAlertsConfigValidator(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	assert.NoError(t, alertmanagerv1alpha1.AddToScheme(scheme))
+	template := wavefrontAlert("ts(cpu, app={{ .app }}) > {{ .threshold }}")
+	template.Spec.ExportedParams = []string{"app", "threshold"}
+	template.Spec.ExportedParamsSchema = map[string]alertmanagerv1alpha1.ParamSchema{
+		"threshold": {Type: alertmanagerv1alpha1.ParamTypeInt},
+	}
+	v := &AlertsConfigValidator{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(template).Build()}
+
+	t.Run("accepts valid params", func(t *testing.T) {
+		_, err := v.ValidateCreate(ctx, alertsConfig("80"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects params not matching the schema", func(t *testing.T) {
+		_, err := v.ValidateCreate(ctx, alertsConfig("eighty"))
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "spec.alerts[cpu].params")
+		assert.Contains(t, err.Error(), "param threshold")
+	})
+
+	t.Run("rejects missing required param", func(t *testing.T) {
+		obj := alertsConfig("80")
+		obj.Spec.GlobalParams = nil
+		_, err := v.ValidateCreate(ctx, obj)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "param app")
+	})
+
+	t.Run("accepts an update keeping the invalid params", func(t *testing.T) {
+		oldObj := alertsConfig("eighty")
+		newObj := oldObj.DeepCopy()
+		newObj.Spec.DeletionPolicy = alertmanagerv1alpha1.DeletionPolicyRetain
+		_, err := v.ValidateUpdate(ctx, oldObj, newObj)
+		assert.NoError(t, err)
+
+		newObj.Spec.GlobalParams["app"] = "cart"
+		_, err = v.ValidateUpdate(ctx, oldObj, newObj)
+		assert.Error(t, err)
+	})
+
+	t.Run("warns about a missing template", func(t *testing.T) {
+		obj := alertsConfig("80")
+		obj.Spec.Alerts["memory"] = alertmanagerv1alpha1.Config{}
+		warnings, err := v.ValidateCreate(ctx, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"spec.alerts[memory]: WavefrontAlert memory not found. params are validated once it is created"}, []string(warnings))
+	})
+}
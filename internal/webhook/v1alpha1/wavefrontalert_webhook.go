@@ -0,0 +1,132 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/keikoproj/alert-manager/pkg/log"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/validate-alertmanager-keikoproj-io-v1alpha1-wavefrontalert,mutating=false,failurePolicy=ignore,sideEffects=None,groups=alertmanager.keikoproj.io,resources=wavefrontalerts,verbs=create;update,versions=v1alpha1,name=vwavefrontalert-v1alpha1.keikoproj.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-alertmanager-keikoproj-io-v1alpha1-clusterwavefrontalert,mutating=false,failurePolicy=ignore,sideEffects=None,groups=alertmanager.keikoproj.io,resources=clusterwavefrontalerts,verbs=create;update,versions=v1alpha1,name=vclusterwavefrontalert-v1alpha1.keikoproj.io,admissionReviewVersions=v1
+
+// SetupWavefrontAlertWebhookWithManager function registers the WavefrontAlert validating webhook.
+// Conversion webhook is registered as well since WavefrontAlert is served in multiple versions
+func SetupWavefrontAlertWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &alertmanagerv1alpha1.WavefrontAlert{}).
+		WithValidator(&WavefrontAlertValidator{}).
+		Complete()
+}
+
+// SetupClusterWavefrontAlertWebhookWithManager function registers the ClusterWavefrontAlert validating webhook
+func SetupClusterWavefrontAlertWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &alertmanagerv1alpha1.ClusterWavefrontAlert{}).
+		WithValidator(&ClusterWavefrontAlertValidator{}).
+		Complete()
+}
+
+// WavefrontAlertValidator rejects the WavefrontAlert with an invalid condition or display expression and warns about unknown functions
+type WavefrontAlertValidator struct{}
+
+// ValidateCreate lints the wavefront queries of the new WavefrontAlert
+func (v *WavefrontAlertValidator) ValidateCreate(ctx context.Context, obj *alertmanagerv1alpha1.WavefrontAlert) (admission.Warnings, error) {
+	return validateQueries(ctx, alertmanagerv1alpha1.WavefrontAlertKind, obj.Name, nil, &obj.Spec)
+}
+
+// ValidateUpdate lints the wavefront queries which got changed. Deleting resource is not validated so the finalizer can be removed
+func (v *WavefrontAlertValidator) ValidateUpdate(ctx context.Context, oldObj, newObj *alertmanagerv1alpha1.WavefrontAlert) (admission.Warnings, error) {
+	if newObj.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	return validateQueries(ctx, alertmanagerv1alpha1.WavefrontAlertKind, newObj.Name, &oldObj.Spec, &newObj.Spec)
+}
+
+// ValidateDelete doesn't validate anything
+func (v *WavefrontAlertValidator) ValidateDelete(ctx context.Context, obj *alertmanagerv1alpha1.WavefrontAlert) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ClusterWavefrontAlertValidator rejects the ClusterWavefrontAlert with an invalid condition or display expression and warns about unknown functions
+type ClusterWavefrontAlertValidator struct{}
+
+// ValidateCreate lints the wavefront queries of the new ClusterWavefrontAlert
+func (v *ClusterWavefrontAlertValidator) ValidateCreate(ctx context.Context, obj *alertmanagerv1alpha1.ClusterWavefrontAlert) (admission.Warnings, error) {
+	return validateQueries(ctx, alertmanagerv1alpha1.ClusterWavefrontAlertKind, obj.Name, nil, &obj.Spec.WavefrontAlertSpec)
+}
+
+// ValidateUpdate lints the wavefront queries which got changed. Deleting resource is not validated so the finalizer can be removed
+func (v *ClusterWavefrontAlertValidator) ValidateUpdate(ctx context.Context, oldObj, newObj *alertmanagerv1alpha1.ClusterWavefrontAlert) (admission.Warnings, error) {
+	if newObj.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	return validateQueries(ctx, alertmanagerv1alpha1.ClusterWavefrontAlertKind, newObj.Name, &oldObj.Spec.WavefrontAlertSpec, &newObj.Spec.WavefrontAlertSpec)
+}
+
+// ValidateDelete doesn't validate anything
+func (v *ClusterWavefrontAlertValidator) ValidateDelete(ctx context.Context, obj *alertmanagerv1alpha1.ClusterWavefrontAlert) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateQueries function lints the condition and the display expression. Templates are linted before rendering.
+// Query which is the same as in the old spec is not linted again so the existing resources can still be updated.
+// Only syntax errors are rejected, unknown functions are returned as warnings since wavefront may still accept them
+func validateQueries(ctx context.Context, kind string, name string, oldSpec *alertmanagerv1alpha1.WavefrontAlertSpec, spec *alertmanagerv1alpha1.WavefrontAlertSpec) (admission.Warnings, error) {
+	log := log.Logger(ctx, "internal.webhook.v1alpha1", "validateQueries")
+	specPath := field.NewPath("spec")
+	queries := []struct {
+		path     *field.Path
+		value    string
+		oldValue string
+	}{
+		{path: specPath.Child("condition"), value: spec.Condition},
+		{path: specPath.Child("displayExpression"), value: spec.DisplayExpression},
+	}
+	if oldSpec != nil {
+		queries[0].oldValue = oldSpec.Condition
+		queries[1].oldValue = oldSpec.DisplayExpression
+	}
+
+	var allErrs field.ErrorList
+	var warnings admission.Warnings
+	for _, query := range queries {
+		// empty query is rejected by the schema
+		if query.value == "" || (oldSpec != nil && query.value == query.oldValue) {
+			continue
+		}
+		errs, queryWarnings := wavefront.LintQueryWithWarnings(query.value)
+		if len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(query.path, query.value, errs.Error()))
+		}
+		for _, warning := range queryWarnings {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", query.path, warning.Error()))
+		}
+	}
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+	log.Info("rejecting invalid wavefront query", "kind", kind, "name", name, "errors", allErrs.ToAggregate().Error())
+	return warnings, apierrors.NewInvalid(schema.GroupKind{Group: alertmanagerv1alpha1.GroupVersion.Group, Kind: kind}, name, allErrs)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func wavefrontAlert(condition string) *alertmanagerv1alpha1.WavefrontAlert {
+	return &alertmanagerv1alpha1.WavefrontAlert{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "cpu"},
+		Spec: alertmanagerv1alpha1.WavefrontAlertSpec{
+			AlertName:         "cpu",
+			Condition:         condition,
+			DisplayExpression: "ts(cpu, app={{ .app }})",
+		},
+	}
+}
+
+func TestWavefrontAlertValidator(t *testing.T) {
+	ctx := context.Background()
+	v := &WavefrontAlertValidator{}
+
+	t.Run("accepts a valid template", func(t *testing.T) {
+		_, err := v.ValidateCreate(ctx, wavefrontAlert("ts(cpu, app={{ .app }}) > {{ .threshold }}"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects an invalid condition", func(t *testing.T) {
+		_, err := v.ValidateCreate(ctx, wavefrontAlert("rate(ts(cpu) > 80"))
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "spec.condition")
+		assert.Contains(t, err.Error(), "position 5: missing ) for rate(")
+	})
+
+	t.Run("accepts an update keeping the invalid condition", func(t *testing.T) {
+		oldObj := wavefrontAlert("tss(cpu) > 80")
+		newObj := oldObj.DeepCopy()
+		newObj.Spec.Severity = "warn"
+		_, err := v.ValidateUpdate(ctx, oldObj, newObj)
+		assert.NoError(t, err)
+
+		newObj.Spec.Condition = "tss(cpu) > 90"
+		warnings, err := v.ValidateUpdate(ctx, oldObj, newObj)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"spec.condition: position 1: unknown function tss"}, []string(warnings))
+
+		newObj.Spec.Condition = "tss(cpu) >> 90"
+		_, err = v.ValidateUpdate(ctx, oldObj, newObj)
+		assert.Error(t, err)
+	})
+
+	t.Run("warns about an unknown function", func(t *testing.T) {
+		warnings, err := v.ValidateCreate(ctx, wavefrontAlert("nonNegativeDerivative(ts(requests)) > 0"))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"spec.condition: position 1: unknown function nonNegativeDerivative"}, []string(warnings))
+	})
+
+	t.Run("accepts an update of the deleting resource", func(t *testing.T) {
+		oldObj := wavefrontAlert("ts(cpu) > 80")
+		newObj := wavefrontAlert("tss(cpu) > 80")
+		now := metav1.Now()
+		newObj.DeletionTimestamp = &now
+		_, err := v.ValidateUpdate(ctx, oldObj, newObj)
+		assert.NoError(t, err)
+	})
+}
+
+func TestClusterWavefrontAlertValidator(t *testing.T) {
+	v := &ClusterWavefrontAlertValidator{}
+	obj := &alertmanagerv1alpha1.ClusterWavefrontAlert{
+		ObjectMeta: metav1.ObjectMeta{Name: "cpu"},
+		Spec:       alertmanagerv1alpha1.ClusterWavefrontAlertSpec{WavefrontAlertSpec: wavefrontAlert("ts(cpu) == 1").Spec},
+	}
+	_, err := v.ValidateCreate(context.Background(), obj)
+	assert.True(t, apierrors.IsInvalid(err))
+	assert.Contains(t, err.Error(), `use "=" instead`)
+}
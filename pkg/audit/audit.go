@@ -0,0 +1,380 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/keikoproj/alert-manager/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Operation is the wavefront mutation being audited
+type Operation string
+
+const (
+	OperationCreate Operation = "Create"
+	OperationUpdate Operation = "Update"
+	OperationDelete Operation = "Delete"
+	OperationSnooze Operation = "Snooze"
+)
+
+// ObjectKind is the kind of the object changed by the audited operation
+type ObjectKind string
+
+const (
+	// ObjectAlert is the wavefront alert. Its id is in AlertID instead of ObjectID
+	ObjectAlert         ObjectKind = "Alert"
+	ObjectDashboard     ObjectKind = "Dashboard"
+	ObjectDerivedMetric ObjectKind = "DerivedMetric"
+	ObjectWebhookAlert  ObjectKind = "WebhookAlert"
+)
+
+// Result of the audited operation
+type Result string
+
+const (
+	ResultSuccess Result = "Success"
+	ResultFailure Result = "Failure"
+)
+
+const (
+	defaultBufferSize    = 1000
+	defaultBatchSize     = 100
+	defaultFlushInterval = 5 * time.Second
+	defaultMaxRetries    = 5
+	shutdownFlushTimeout = 10 * time.Second
+)
+
+var (
+	auditRecords = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "alert_manager_audit_records_total",
+		Help: "Number of audit records by the result of their delivery to the sink",
+	}, []string{"sink", "result"})
+	auditDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "alert_manager_audit_records_dropped_total",
+		Help: "Number of audit records dropped because the buffer was full",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(auditRecords, auditDropped)
+}
+
+// Actor is the resource which triggered the mutation
+type Actor struct {
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	UID        string `json:"uid,omitempty"`
+	Generation int64  `json:"generation,omitempty"`
+	// User is the field manager which last applied or updated the resource spec
+	User string `json:"user,omitempty"`
+}
+
+// Record is a single audited mutation of a wavefront alert, dashboard or derived metric, or of a webhook alert.
+// Every replica chains its records with the keyed hash of its previous record so a removed or modified record can be detected
+type Record struct {
+	Sequence  int64       `json:"sequence"`
+	Timestamp metav1.Time `json:"timestamp"`
+	ClusterID string      `json:"clusterID,omitempty"`
+	// Replica identifies the chain of the record. It is the replica which started the chain, which a restarted replica continues
+	Replica   string    `json:"replica"`
+	Operation Operation `json:"operation"`
+	// Object is the kind of the changed object
+	Object ObjectKind `json:"object,omitempty"`
+	// AlertID is the id of the changed wavefront alert
+	AlertID string `json:"alertID,omitempty"`
+	// ObjectID is the id of the changed object other than a wavefront alert
+	ObjectID string `json:"objectID,omitempty"`
+	Actor    Actor  `json:"actor"`
+	// Before and After are the JSON of the object before and after the change
+	Before   json.RawMessage `json:"before,omitempty"`
+	After    json.RawMessage `json:"after,omitempty"`
+	Diff     []string        `json:"diff,omitempty"`
+	Result   Result          `json:"result"`
+	Error    string          `json:"error,omitempty"`
+	PrevHash string          `json:"prevHash,omitempty"`
+	Hash     string          `json:"hash"`
+}
+
+type actorKey struct{}
+
+// WithActor function returns the context carrying the actor of the wavefront mutations done with it
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext function returns the actor set with WithActor
+func ActorFromContext(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorKey{}).(Actor)
+	return actor
+}
+
+// ActorFor function returns the actor for the resource. User is the manager of the latest Apply in the managed fields,
+// or of the latest Update if the resource was never applied. Status updates are ignored
+func ActorFor(obj metav1.Object, kind string) Actor {
+	actor := Actor{
+		Kind:       kind,
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		UID:        string(obj.GetUID()),
+		Generation: obj.GetGeneration(),
+	}
+	var applied, updated *metav1.ManagedFieldsEntry
+	for i := range obj.GetManagedFields() {
+		entry := &obj.GetManagedFields()[i]
+		if entry.Subresource != "" {
+			continue
+		}
+		switch entry.Operation {
+		case metav1.ManagedFieldsOperationApply:
+			if applied == nil || newerEntry(entry, applied) {
+				applied = entry
+			}
+		case metav1.ManagedFieldsOperationUpdate:
+			if updated == nil || newerEntry(entry, updated) {
+				updated = entry
+			}
+		}
+	}
+	if applied != nil {
+		actor.User = applied.Manager
+	} else if updated != nil {
+		actor.User = updated.Manager
+	}
+	return actor
+}
+
+func newerEntry(entry *metav1.ManagedFieldsEntry, than *metav1.ManagedFieldsEntry) bool {
+	if entry.Time == nil {
+		return false
+	}
+	return than.Time == nil || entry.Time.After(than.Time.Time)
+}
+
+// Auditor buffers the records and delivers them in batches to all the sinks, retrying failed deliveries.
+// Records are dropped when the buffer is full so auditing never blocks the reconciles
+type Auditor struct {
+	Sinks []Sink
+	// ClusterID is added to every record
+	ClusterID string
+	// BatchSize is the max number of records delivered at once
+	BatchSize int
+	// FlushInterval is the max time a record is buffered before its delivery
+	FlushInterval time.Duration
+	// MaxRetries is the number of retries of a failed delivery before the batch is dropped for the sink
+	MaxRetries int
+
+	// Replica is added to every record and identifies the chain of the records. Replaced by the one of the resumed chain
+	Replica string
+
+	key      []byte
+	records  chan Record
+	sequence int64
+	lastHash string
+}
+
+// NewAuditor function returns an auditor buffering up to bufferSize records before dropping new ones.
+// Records are chained with the HMAC-SHA256 of the records using the key
+func NewAuditor(clusterID string, replica string, key []byte, bufferSize int, sinks ...Sink) *Auditor {
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+	return &Auditor{
+		Sinks:         sinks,
+		ClusterID:     clusterID,
+		Replica:       replica,
+		key:           key,
+		BatchSize:     defaultBatchSize,
+		FlushInterval: defaultFlushInterval,
+		MaxRetries:    defaultMaxRetries,
+		records:       make(chan Record, bufferSize),
+	}
+}
+
+// Record function buffers the record for delivery. Nil auditor doesn't record anything
+func (a *Auditor) Record(ctx context.Context, record Record) {
+	if a == nil {
+		return
+	}
+	log := log.Logger(ctx, "pkg.audit", "Record")
+	if record.Timestamp.IsZero() {
+		record.Timestamp = metav1.Now()
+	}
+	record.ClusterID = a.ClusterID
+	select {
+	case a.records <- record:
+	default:
+		auditDropped.Inc()
+		log.Error(nil, "audit buffer is full. dropping the audit record", "operation", record.Operation, "alertID", record.AlertID, "objectID", record.ObjectID)
+	}
+}
+
+// Start function delivers the buffered records until the context is done and flushes the remaining ones. Implements manager.Runnable
+func (a *Auditor) Start(ctx context.Context) error {
+	log := log.Logger(ctx, "pkg.audit", "Start")
+	log.Info("Starting auditor", "sinks", len(a.Sinks), "replica", a.Replica)
+	if len(a.key) == 0 {
+		return errors.New("audit hmac key must not be empty")
+	}
+	a.resume(ctx)
+	ticker := time.NewTicker(a.FlushInterval)
+	defer ticker.Stop()
+	var batch []Record
+	for {
+		select {
+		case <-ctx.Done():
+			// context is done so the remaining records are delivered with a new one
+			flushCtx, cancel := context.WithTimeout(context.Background(), shutdownFlushTimeout)
+			defer cancel()
+			for {
+				select {
+				case record := <-a.records:
+					batch = append(batch, a.chain(record))
+				default:
+					a.flush(flushCtx, batch)
+					return nil
+				}
+			}
+		case record := <-a.records:
+			batch = append(batch, a.chain(record))
+			if len(batch) >= a.BatchSize {
+				a.flush(ctx, batch)
+				batch = nil
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				a.flush(ctx, batch)
+				batch = nil
+			}
+		}
+	}
+}
+
+// NeedLeaderElection function makes every replica deliver the records of its own mutations. Implements manager.LeaderElectionRunnable
+func (a *Auditor) NeedLeaderElection() bool {
+	return false
+}
+
+// resume function continues the chain from the last record delivered to a sink which can read it back, for ex: file sink.
+// Hostname of a pod changes on every restart so the chain is continued with the replica of the last record, whichever pod wrote it.
+// Chain starts from the beginning if there is no such record or it was not hashed with the same key
+func (a *Auditor) resume(ctx context.Context) {
+	log := log.Logger(ctx, "pkg.audit", "resume")
+	for _, sink := range a.Sinks {
+		resumer, ok := sink.(ChainResumer)
+		if !ok {
+			continue
+		}
+		last, err := resumer.LastRecord()
+		if err != nil {
+			log.Error(err, "unable to read the last audit record. starting a new chain", "sink", sink.Name())
+			continue
+		}
+		if last == nil || last.Sequence <= a.sequence {
+			continue
+		}
+		if recordHash(a.key, *last) != last.Hash {
+			log.Info("last audit record was not hashed with the same key. starting a new chain", "sink", sink.Name(), "sequence", last.Sequence)
+			continue
+		}
+		a.sequence = last.Sequence
+		a.lastHash = last.Hash
+		a.Replica = last.Replica
+	}
+	if a.sequence > 0 {
+		log.Info("resuming the audit chain", "replica", a.Replica, "sequence", a.sequence)
+	}
+}
+
+// chain function sets the sequence and the hashes of the record. Only called from Start so the records are chained in order
+func (a *Auditor) chain(record Record) Record {
+	record.Replica = a.Replica
+	a.sequence++
+	record.Sequence = a.sequence
+	record.PrevHash = a.lastHash
+	record.Hash = recordHash(a.key, record)
+	a.lastHash = record.Hash
+	return record
+}
+
+// recordHash function returns the HMAC-SHA256 of the record without its hash
+func recordHash(key []byte, record Record) string {
+	record.Hash = ""
+	payload, _ := json.Marshal(record)
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// flush function delivers the batch to every sink with exponential backoff between the retries
+func (a *Auditor) flush(ctx context.Context, batch []Record) {
+	if len(batch) == 0 {
+		return
+	}
+	log := log.Logger(ctx, "pkg.audit", "flush")
+	for _, sink := range a.Sinks {
+		backoff := wait.Backoff{Duration: 500 * time.Millisecond, Factor: 2, Jitter: 0.1, Steps: a.MaxRetries + 1}
+		var lastErr error
+		err := wait.ExponentialBackoffWithContext(ctx, backoff, func(ctx context.Context) (bool, error) {
+			lastErr = sink.Write(ctx, batch)
+			return lastErr == nil, nil
+		})
+		if err != nil {
+			if lastErr == nil {
+				lastErr = err
+			}
+			auditRecords.WithLabelValues(sink.Name(), string(ResultFailure)).Add(float64(len(batch)))
+			log.Error(lastErr, "unable to deliver the audit records. dropping them", "sink", sink.Name(), "records", len(batch))
+			continue
+		}
+		auditRecords.WithLabelValues(sink.Name(), string(ResultSuccess)).Add(float64(len(batch)))
+	}
+}
+
+// VerifyChain function returns the sequence of the first record whose hash doesn't match its content with the key or which doesn't follow
+// the previous record of the same replica. Records of the replicas can be interleaved. First record of a replica starts its chain, and so does
+// a record with sequence 1 and no previous hash since a replica restarts its chain when it can't resume it. Returns 0 if all the chains are valid
+func VerifyChain(key []byte, records []Record) int64 {
+	type link struct {
+		sequence int64
+		hash     string
+	}
+	last := make(map[string]link)
+	for _, record := range records {
+		if !hmac.Equal([]byte(recordHash(key, record)), []byte(record.Hash)) {
+			return record.Sequence
+		}
+		prev, ok := last[record.Replica]
+		restarted := record.Sequence == 1 && record.PrevHash == ""
+		if ok && !restarted && (record.Sequence != prev.sequence+1 || record.PrevHash != prev.hash) {
+			return record.Sequence
+		}
+		last[record.Replica] = link{sequence: record.Sequence, hash: record.Hash}
+	}
+	return 0
+}
@@ -0,0 +1,435 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	"github.com/keikoproj/alert-manager/pkg/audit"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	"github.com/keikoproj/alert-manager/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// memorySink keeps the delivered records and fails the first failures writes
+type memorySink struct {
+	mu       sync.Mutex
+	failures int
+	records  []audit.Record
+}
+
+func (s *memorySink) Name() string {
+	return "memory"
+}
+
+func (s *memorySink) Write(ctx context.Context, records []audit.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("sink unavailable")
+	}
+	s.records = append(s.records, records...)
+	return nil
+}
+
+// fakeWavefront keeps the alerts in memory
+type fakeWavefront struct {
+	wavefront.Interface
+	alerts     map[string]wf.Alert
+	dashboards map[string]wf.Dashboard
+}
+
+func (f *fakeWavefront) CreateAlert(ctx context.Context, input *wf.Alert) error {
+	id := "1"
+	input.ID = &id
+	f.alerts[id] = *input
+	return nil
+}
+
+func (f *fakeWavefront) ReadAlert(ctx context.Context, alertID string) (*wf.Alert, error) {
+	alert, ok := f.alerts[alertID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &alert, nil
+}
+
+func (f *fakeWavefront) UpdateAlert(ctx context.Context, input *wf.Alert) ([]string, error) {
+	var diff []string
+	if live := f.alerts[*input.ID]; live.Condition != input.Condition {
+		diff = []string{"condition: " + live.Condition + " -> " + input.Condition}
+	}
+	f.alerts[*input.ID] = *input
+	return diff, nil
+}
+
+func (f *fakeWavefront) DeleteAlert(ctx context.Context, alertID string) error {
+	delete(f.alerts, alertID)
+	return nil
+}
+
+func (f *fakeWavefront) CreateDashboard(ctx context.Context, input *wf.Dashboard) error {
+	f.dashboards[input.ID] = *input
+	return nil
+}
+
+func (f *fakeWavefront) ReadDashboard(ctx context.Context, dashboardID string) (*wf.Dashboard, error) {
+	dashboard, ok := f.dashboards[dashboardID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &dashboard, nil
+}
+
+func (f *fakeWavefront) DeleteDashboard(ctx context.Context, dashboardID string) error {
+	delete(f.dashboards, dashboardID)
+	return nil
+}
+
+// fakeWebhook keeps the payloads in memory
+type fakeWebhook struct {
+	webhook.Interface
+	payloads map[string][]byte
+}
+
+func (f *fakeWebhook) CreateAlert(ctx context.Context, endpoint webhook.Endpoint, payload []byte) (string, error) {
+	f.payloads["a1"] = payload
+	return "a1", nil
+}
+
+func (f *fakeWebhook) ReadAlert(ctx context.Context, endpoint webhook.Endpoint, id string) ([]byte, error) {
+	payload, ok := f.payloads[id]
+	if !ok {
+		return nil, webhook.ErrNotFound
+	}
+	return payload, nil
+}
+
+func (f *fakeWebhook) UpdateAlert(ctx context.Context, endpoint webhook.Endpoint, id string, payload []byte) error {
+	f.payloads[id] = payload
+	return nil
+}
+
+func (f *fakeWebhook) DeleteAlert(ctx context.Context, endpoint webhook.Endpoint, id string) error {
+	if _, ok := f.payloads[id]; !ok {
+		return webhook.ErrNotFound
+	}
+	delete(f.payloads, id)
+	return nil
+}
+
+// condition function returns the condition of the recorded alert JSON
+func condition(t *testing.T, raw json.RawMessage) string {
+	var alert wf.Alert
+	assert.NoError(t, json.Unmarshal(raw, &alert))
+	return alert.Condition
+}
+
+func TestActorFor(t *testing.T) {
+	t0 := metav1.NewTime(time.Now().Add(-time.Hour))
+	t1 := metav1.NewTime(time.Now())
+	obj := &metav1.ObjectMeta{
+		Namespace:  "team-a",
+		Name:       "latency",
+		Generation: 3,
+		ManagedFields: []metav1.ManagedFieldsEntry{
+			{Manager: "argocd", Operation: metav1.ManagedFieldsOperationApply, Time: &t0},
+			{Manager: "kubectl-edit", Operation: metav1.ManagedFieldsOperationUpdate, Time: &t1},
+			{Manager: "alert-manager", Operation: metav1.ManagedFieldsOperationUpdate, Time: &t1, Subresource: "status"},
+		},
+	}
+
+	t.Run("uses the last applied manager", func(t *testing.T) {
+		actor := audit.ActorFor(obj, "WavefrontAlert")
+		assert.Equal(t, audit.Actor{Kind: "WavefrontAlert", Namespace: "team-a", Name: "latency", Generation: 3, User: "argocd"}, actor)
+	})
+
+	t.Run("uses the last updated manager if never applied", func(t *testing.T) {
+		notApplied := obj.DeepCopy()
+		notApplied.ManagedFields = notApplied.ManagedFields[1:]
+		assert.Equal(t, "kubectl-edit", audit.ActorFor(notApplied, "WavefrontAlert").User)
+	})
+}
+
+var auditKey = []byte("test-key")
+
+// runAuditor function starts the auditor, records the operations and stops the auditor once the sink has wantRecords records
+func runAuditor(t *testing.T, auditor *audit.Auditor, operations []audit.Operation, delivered func() int, wantRecords int) {
+	auditor.FlushInterval = 10 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		assert.NoError(t, auditor.Start(ctx))
+		close(done)
+	}()
+	for _, operation := range operations {
+		auditor.Record(ctx, audit.Record{Operation: operation, Result: audit.ResultSuccess})
+	}
+	assert.Eventually(t, func() bool { return delivered() == wantRecords }, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+}
+
+func TestAuditor(t *testing.T) {
+	sink := &memorySink{failures: 1}
+	auditor := audit.NewAuditor("cluster-a", "replica-a", auditKey, 10, sink)
+	auditor.FlushInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		_ = auditor.Start(ctx)
+		close(done)
+	}()
+	for i := 0; i < 3; i++ {
+		auditor.Record(ctx, audit.Record{Operation: audit.OperationCreate, Result: audit.ResultSuccess})
+	}
+	assert.Eventually(t, func() bool {
+		sink.mu.Lock()
+		defer sink.mu.Unlock()
+		return len(sink.records) == 3
+	}, 5*time.Second, 10*time.Millisecond, "records are delivered after the failed write is retried")
+	cancel()
+	<-done
+
+	records := sink.records
+	assert.Equal(t, "cluster-a", records[0].ClusterID)
+	assert.Equal(t, "replica-a", records[0].Replica)
+	assert.Equal(t, []int64{1, 2, 3}, []int64{records[0].Sequence, records[1].Sequence, records[2].Sequence})
+	assert.Equal(t, int64(0), audit.VerifyChain(auditKey, records))
+	assert.Equal(t, int64(1), audit.VerifyChain([]byte("other-key"), records), "records can't be verified without the key")
+
+	records[1].Operation = audit.OperationDelete
+	assert.Equal(t, int64(2), audit.VerifyChain(auditKey, records), "modified record is detected")
+	assert.Equal(t, int64(3), audit.VerifyChain(auditKey, []audit.Record{sink.records[0], sink.records[2]}), "removed record is detected")
+
+	assert.Error(t, audit.NewAuditor("cluster-a", "replica-a", nil, 10, sink).Start(context.Background()), "key is required")
+}
+
+func TestAuditorChainResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink := &audit.FileSink{Path: path}
+	readRecords := func() []audit.Record {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil
+		}
+		defer f.Close()
+		var records []audit.Record
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var record audit.Record
+			assert.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+			records = append(records, record)
+		}
+		return records
+	}
+	delivered := func() int { return len(readRecords()) }
+
+	runAuditor(t, audit.NewAuditor("cluster-a", "pod-1", auditKey, 10, sink), []audit.Operation{audit.OperationCreate, audit.OperationUpdate}, delivered, 2)
+	// pod restarts with a new hostname and continues the chain from the file
+	runAuditor(t, audit.NewAuditor("cluster-a", "pod-2", auditKey, 10, sink), []audit.Operation{audit.OperationDelete}, delivered, 3)
+
+	records := readRecords()
+	assert.Equal(t, []string{"pod-1", "pod-1", "pod-1"}, []string{records[0].Replica, records[1].Replica, records[2].Replica})
+	assert.Equal(t, []int64{1, 2, 3}, []int64{records[0].Sequence, records[1].Sequence, records[2].Sequence})
+	assert.Equal(t, records[1].Hash, records[2].PrevHash)
+	assert.Equal(t, int64(0), audit.VerifyChain(auditKey, records), "chain is verified across the restart")
+
+	assert.Equal(t, int64(3), audit.VerifyChain(auditKey, []audit.Record{records[0], records[2]}), "removed record before the restart is detected")
+
+	// chain of another key is not continued
+	runAuditor(t, audit.NewAuditor("cluster-a", "pod-3", []byte("other-key"), 10, sink), []audit.Operation{audit.OperationCreate}, delivered, 4)
+	records = readRecords()
+	assert.Equal(t, "pod-3", records[3].Replica)
+	assert.Equal(t, int64(1), records[3].Sequence)
+}
+
+func TestWavefrontClient(t *testing.T) {
+	sink := &memorySink{}
+	auditor := audit.NewAuditor("", "replica-a", auditKey, 10, sink)
+	auditor.FlushInterval = 10 * time.Millisecond
+	client := &audit.WavefrontClient{Interface: &fakeWavefront{alerts: map[string]wf.Alert{}, dashboards: map[string]wf.Dashboard{}}, Auditor: auditor}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		_ = auditor.Start(ctx)
+		close(done)
+	}()
+
+	actorCtx := audit.WithActor(ctx, audit.Actor{Kind: "WavefrontAlert", Name: "latency"})
+	alert := &wf.Alert{Name: "latency", Condition: "ts(a) > 1"}
+	assert.NoError(t, client.CreateAlert(actorCtx, alert))
+	_, err := client.UpdateAlert(actorCtx, &wf.Alert{ID: alert.ID, Name: "latency", Condition: "ts(a) > 1"})
+	assert.NoError(t, err)
+	_, err = client.UpdateAlert(actorCtx, &wf.Alert{ID: alert.ID, Name: "latency", Condition: "ts(a) > 2"})
+	assert.NoError(t, err)
+	assert.NoError(t, client.DeleteAlert(actorCtx, *alert.ID))
+	assert.NoError(t, client.DeleteAlert(actorCtx, *alert.ID))
+
+	dashboardCtx := audit.WithActor(ctx, audit.Actor{Kind: "WavefrontDashboard", Name: "overview"})
+	assert.NoError(t, client.CreateDashboard(dashboardCtx, &wf.Dashboard{ID: "overview", Name: "Overview"}))
+	assert.NoError(t, client.DeleteDashboard(dashboardCtx, "overview"))
+	assert.NoError(t, client.DeleteDashboard(dashboardCtx, "overview"))
+
+	cancel()
+	<-done
+
+	records := sink.records
+	if assert.Len(t, records, 5, "skipped update and delete of a missing object are not audited") {
+		assert.Equal(t, audit.OperationCreate, records[0].Operation)
+		assert.Equal(t, "latency", records[0].Actor.Name)
+		assert.Equal(t, audit.ObjectAlert, records[0].Object)
+		assert.Equal(t, "ts(a) > 1", condition(t, records[0].After))
+
+		assert.Equal(t, audit.OperationUpdate, records[1].Operation)
+		assert.Equal(t, "ts(a) > 1", condition(t, records[1].Before))
+		assert.Equal(t, "ts(a) > 2", condition(t, records[1].After))
+		assert.Equal(t, []string{"condition: ts(a) > 1 -> ts(a) > 2"}, records[1].Diff)
+
+		assert.Equal(t, audit.OperationDelete, records[2].Operation)
+		assert.Equal(t, "1", records[2].AlertID)
+		assert.Equal(t, "ts(a) > 2", condition(t, records[2].Before))
+		assert.Nil(t, records[2].After)
+
+		assert.Equal(t, audit.ObjectDashboard, records[3].Object)
+		assert.Equal(t, audit.OperationCreate, records[3].Operation)
+		assert.Equal(t, "overview", records[3].ObjectID)
+		assert.Equal(t, audit.OperationDelete, records[4].Operation)
+		assert.Equal(t, "overview", records[4].ObjectID)
+		assert.NotNil(t, records[4].Before)
+	}
+	assert.Equal(t, int64(0), audit.VerifyChain(auditKey, records))
+}
+
+func TestWebhookClient(t *testing.T) {
+	sink := &memorySink{}
+	auditor := audit.NewAuditor("", "replica-a", auditKey, 10, sink)
+	auditor.FlushInterval = 10 * time.Millisecond
+	client := &audit.WebhookClient{Interface: &fakeWebhook{payloads: map[string][]byte{}}, Auditor: auditor}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		_ = auditor.Start(ctx)
+		close(done)
+	}()
+
+	actorCtx := audit.WithActor(ctx, audit.Actor{Kind: "WebhookAlert", Name: "latency"})
+	endpoint := webhook.Endpoint{URL: "https://alerts.example.com/api"}
+	id, err := client.CreateAlert(actorCtx, endpoint, []byte(`{"threshold":1}`))
+	assert.NoError(t, err)
+	assert.NoError(t, client.UpdateAlert(actorCtx, endpoint, id, []byte("not json")))
+	assert.NoError(t, client.DeleteAlert(actorCtx, endpoint, id))
+	assert.ErrorIs(t, client.DeleteAlert(actorCtx, endpoint, id), webhook.ErrNotFound)
+
+	cancel()
+	<-done
+
+	records := sink.records
+	if assert.Len(t, records, 3, "delete of a missing alert is not audited") {
+		assert.Equal(t, audit.ObjectWebhookAlert, records[0].Object)
+		assert.Equal(t, "a1", records[0].ObjectID)
+		assert.JSONEq(t, `{"threshold":1}`, string(records[0].After))
+
+		assert.Equal(t, audit.OperationUpdate, records[1].Operation)
+		assert.JSONEq(t, `{"threshold":1}`, string(records[1].Before))
+		assert.Nil(t, records[1].After, "payload which is not JSON is not recorded")
+
+		assert.Equal(t, audit.OperationDelete, records[2].Operation)
+		assert.Nil(t, records[2].Before)
+	}
+	assert.Equal(t, int64(0), audit.VerifyChain(auditKey, records))
+}
+
+func TestSinks(t *testing.T) {
+	records := []audit.Record{{Sequence: 1, Operation: audit.OperationCreate}, {Sequence: 2, Operation: audit.OperationDelete}}
+
+	t.Run("file sink appends json lines", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit.log")
+		sink := &audit.FileSink{Path: path}
+		assert.NoError(t, sink.Write(context.Background(), records[:1]))
+		assert.NoError(t, sink.Write(context.Background(), records[1:]))
+
+		f, err := os.Open(path)
+		assert.NoError(t, err)
+		defer f.Close()
+		var sequences []int64
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var record audit.Record
+			assert.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+			sequences = append(sequences, record.Sequence)
+		}
+		assert.Equal(t, []int64{1, 2}, sequences)
+	})
+
+	t.Run("webhook sink posts json lines", func(t *testing.T) {
+		var received int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer test-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			scanner := bufio.NewScanner(r.Body)
+			for scanner.Scan() {
+				received++
+			}
+		}))
+		defer server.Close()
+
+		sink, err := audit.NewWebhookSink(server.URL, "test-token")
+		assert.NoError(t, err)
+		assert.NoError(t, sink.Write(context.Background(), records))
+		assert.Equal(t, 2, received)
+
+		sink.Token = "wrong-token"
+		assert.Error(t, sink.Write(context.Background(), records))
+	})
+
+	t.Run("parses the sinks", func(t *testing.T) {
+		sinks, err := audit.ParseSinks("stdout, file:/tmp/audit.log,webhook:https://audit.example.com")
+		assert.NoError(t, err)
+		assert.Len(t, sinks, 3)
+		assert.Equal(t, "file", sinks[1].Name())
+
+		sinks, err = audit.ParseSinks("")
+		assert.NoError(t, err)
+		assert.Empty(t, sinks)
+
+		_, err = audit.ParseSinks("file:")
+		assert.Error(t, err)
+		_, err = audit.ParseSinks("webhook:ftp://audit.example.com")
+		assert.Error(t, err)
+		_, err = audit.ParseSinks("syslog")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// WebhookTokenEnv is the environment variable with the bearer token sent to the webhook sinks
+	WebhookTokenEnv = "AUDIT_WEBHOOK_TOKEN"
+	// HMACKeyEnv is the environment variable with the key of the HMAC chaining the records
+	HMACKeyEnv = "AUDIT_HMAC_KEY"
+
+	defaultWebhookTimeout = 30 * time.Second
+)
+
+// Sink receives the audit records
+type Sink interface {
+	// Name is used in the logs and metrics
+	Name() string
+	// Write delivers the records in order. Records are retried if an error is returned
+	Write(ctx context.Context, records []Record) error
+}
+
+// ChainResumer is implemented by the sinks which can read back the last delivered record,
+// so a restarted replica continues the chain. Every replica must have its own sink to resume from
+type ChainResumer interface {
+	// LastRecord returns the last record or nil if there is none
+	LastRecord() (*Record, error)
+}
+
+// encodeLines function encodes the records as JSON lines
+func encodeLines(records []Record) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, record := range records {
+		if err := enc.Encode(record); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// WriterSink writes JSON lines to the writer, for ex: stdout
+type WriterSink struct {
+	SinkName string
+	Writer   io.Writer
+
+	mu sync.Mutex
+}
+
+func (s *WriterSink) Name() string {
+	return s.SinkName
+}
+
+func (s *WriterSink) Write(ctx context.Context, records []Record) error {
+	lines, err := encodeLines(records)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.Writer.Write(lines)
+	return err
+}
+
+// FileSink appends JSON lines to the file. File is opened on every write so it can be rotated
+type FileSink struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+func (s *FileSink) Name() string {
+	return "file"
+}
+
+func (s *FileSink) Write(ctx context.Context, records []Record) error {
+	lines, err := encodeLines(records)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(lines); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LastRecord function returns the last record in the file. Lines which are not records are skipped
+func (s *FileSink) LastRecord() (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.Open(s.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var last *Record
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		var record Record
+		if len(bytes.TrimSpace(line)) > 0 && json.Unmarshal(line, &record) == nil && record.Hash != "" {
+			last = &record
+		}
+		if err == io.EOF {
+			return last, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// WebhookSink POSTs the records as JSON lines to the URL. Any non 2xx response is retried
+type WebhookSink struct {
+	URL   string
+	Token string
+
+	client *http.Client
+}
+
+// NewWebhookSink function returns the webhook sink for the http(s) url
+func NewWebhookSink(rawURL string, token string) (*WebhookSink, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid audit webhook url %s: %w", rawURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid audit webhook url %s: scheme must be http or https", rawURL)
+	}
+	return &WebhookSink{URL: rawURL, Token: token, client: &http.Client{Timeout: defaultWebhookTimeout}}, nil
+}
+
+func (s *WebhookSink) Name() string {
+	return "webhook"
+}
+
+func (s *WebhookSink) Write(ctx context.Context, records []Record) error {
+	lines, err := encodeLines(records)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(lines))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("audit webhook returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// ParseSinks function parses the comma separated sinks, for ex: "stdout,file:/var/log/audit.log,webhook:https://audit.example.com"
+func ParseSinks(spec string) ([]Sink, error) {
+	var sinks []Sink
+	for _, s := range strings.Split(spec, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		kind, target, _ := strings.Cut(s, ":")
+		switch kind {
+		case "stdout":
+			sinks = append(sinks, &WriterSink{SinkName: "stdout", Writer: os.Stdout})
+		case "file":
+			if target == "" {
+				return nil, fmt.Errorf("audit sink %s: file path is required", s)
+			}
+			sinks = append(sinks, &FileSink{Path: target})
+		case "webhook":
+			sink, err := NewWebhookSink(target, os.Getenv(WebhookTokenEnv))
+			if err != nil {
+				return nil, err
+			}
+			sinks = append(sinks, sink)
+		default:
+			return nil, fmt.Errorf("unknown audit sink %s. must be one of stdout, file:<path> or webhook:<url>", s)
+		}
+	}
+	return sinks, nil
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+)
+
+// WavefrontClient audits every mutation of the alerts, dashboards and derived metrics done with the wrapped wavefront client.
+// Live object is read before updates and deletes for the before payload
+type WavefrontClient struct {
+	wavefront.Interface
+	Auditor *Auditor
+}
+
+var _ wavefront.Interface = &WavefrontClient{}
+
+func (c *WavefrontClient) CreateAlert(ctx context.Context, input *wf.Alert) error {
+	err := c.Interface.CreateAlert(ctx, input)
+	record := alertRecord(ctx, OperationCreate, alertID(input), err)
+	record.After = toJSON(input)
+	c.Auditor.Record(ctx, record)
+	return err
+}
+
+func (c *WavefrontClient) UpdateAlert(ctx context.Context, input *wf.Alert) ([]string, error) {
+	before := c.readAlert(ctx, alertID(input))
+	diff, err := c.Interface.UpdateAlert(ctx, input)
+	if err == nil && len(diff) == 0 {
+		// update got skipped
+		return diff, err
+	}
+	record := alertRecord(ctx, OperationUpdate, alertID(input), err)
+	record.Before = toJSON(before)
+	record.After = toJSON(input)
+	record.Diff = diff
+	c.Auditor.Record(ctx, record)
+	return diff, err
+}
+
+func (c *WavefrontClient) DeleteAlert(ctx context.Context, alertID string) error {
+	before := c.readAlert(ctx, alertID)
+	err := c.Interface.DeleteAlert(ctx, alertID)
+	if err == nil && before == nil {
+		// alert was already deleted
+		return nil
+	}
+	record := alertRecord(ctx, OperationDelete, alertID, err)
+	record.Before = toJSON(before)
+	c.Auditor.Record(ctx, record)
+	return err
+}
+
+func (c *WavefrontClient) SnoozeAlert(ctx context.Context, alertID string) error {
+	before := c.readAlert(ctx, alertID)
+	err := c.Interface.SnoozeAlert(ctx, alertID)
+	record := alertRecord(ctx, OperationSnooze, alertID, err)
+	record.Before = toJSON(before)
+	c.Auditor.Record(ctx, record)
+	return err
+}
+
+func (c *WavefrontClient) CreateDashboard(ctx context.Context, input *wf.Dashboard) error {
+	err := c.Interface.CreateDashboard(ctx, input)
+	record := objectRecord(ctx, OperationCreate, ObjectDashboard, dashboardID(input), err)
+	record.After = toJSON(input)
+	c.Auditor.Record(ctx, record)
+	return err
+}
+
+func (c *WavefrontClient) UpdateDashboard(ctx context.Context, input *wf.Dashboard) error {
+	var before *wf.Dashboard
+	if live, err := c.Interface.ReadDashboard(ctx, dashboardID(input)); err == nil {
+		before = live
+	}
+	err := c.Interface.UpdateDashboard(ctx, input)
+	record := objectRecord(ctx, OperationUpdate, ObjectDashboard, dashboardID(input), err)
+	record.Before = toJSON(before)
+	record.After = toJSON(input)
+	c.Auditor.Record(ctx, record)
+	return err
+}
+
+func (c *WavefrontClient) DeleteDashboard(ctx context.Context, dashboardID string) error {
+	var before *wf.Dashboard
+	if live, err := c.Interface.ReadDashboard(ctx, dashboardID); err == nil {
+		before = live
+	}
+	err := c.Interface.DeleteDashboard(ctx, dashboardID)
+	if err == nil && before == nil {
+		// dashboard was already deleted
+		return nil
+	}
+	record := objectRecord(ctx, OperationDelete, ObjectDashboard, dashboardID, err)
+	record.Before = toJSON(before)
+	c.Auditor.Record(ctx, record)
+	return err
+}
+
+func (c *WavefrontClient) CreateDerivedMetric(ctx context.Context, input *wf.DerivedMetric) error {
+	err := c.Interface.CreateDerivedMetric(ctx, input)
+	record := objectRecord(ctx, OperationCreate, ObjectDerivedMetric, derivedMetricID(input), err)
+	record.After = toJSON(input)
+	c.Auditor.Record(ctx, record)
+	return err
+}
+
+func (c *WavefrontClient) UpdateDerivedMetric(ctx context.Context, input *wf.DerivedMetric) error {
+	var before *wf.DerivedMetric
+	if id := derivedMetricID(input); id != "" {
+		if live, err := c.Interface.ReadDerivedMetric(ctx, id); err == nil {
+			before = live
+		}
+	}
+	err := c.Interface.UpdateDerivedMetric(ctx, input)
+	record := objectRecord(ctx, OperationUpdate, ObjectDerivedMetric, derivedMetricID(input), err)
+	record.Before = toJSON(before)
+	record.After = toJSON(input)
+	c.Auditor.Record(ctx, record)
+	return err
+}
+
+func (c *WavefrontClient) DeleteDerivedMetric(ctx context.Context, derivedMetricID string) error {
+	var before *wf.DerivedMetric
+	if live, err := c.Interface.ReadDerivedMetric(ctx, derivedMetricID); err == nil {
+		before = live
+	}
+	err := c.Interface.DeleteDerivedMetric(ctx, derivedMetricID)
+	if err == nil && before == nil {
+		// derived metric was already deleted
+		return nil
+	}
+	record := objectRecord(ctx, OperationDelete, ObjectDerivedMetric, derivedMetricID, err)
+	record.Before = toJSON(before)
+	c.Auditor.Record(ctx, record)
+	return err
+}
+
+// readAlert function returns the live alert or nil if it can't be read
+func (c *WavefrontClient) readAlert(ctx context.Context, alertID string) *wf.Alert {
+	if alertID == "" {
+		return nil
+	}
+	live, err := c.Interface.ReadAlert(ctx, alertID)
+	if err != nil {
+		return nil
+	}
+	return live
+}
+
+// alertRecord function returns the record of the operation on the wavefront alert done by the actor of the context
+func alertRecord(ctx context.Context, operation Operation, alertID string, err error) Record {
+	record := objectRecord(ctx, operation, ObjectAlert, "", err)
+	record.AlertID = alertID
+	return record
+}
+
+// objectRecord function returns the record of the operation on the object done by the actor of the context
+func objectRecord(ctx context.Context, operation Operation, object ObjectKind, objectID string, err error) Record {
+	record := Record{
+		Operation: operation,
+		Object:    object,
+		ObjectID:  objectID,
+		Actor:     ActorFromContext(ctx),
+		Result:    ResultSuccess,
+	}
+	if err != nil {
+		record.Result = ResultFailure
+		record.Error = err.Error()
+	}
+	return record
+}
+
+func alertID(alert *wf.Alert) string {
+	if alert == nil || alert.ID == nil {
+		return ""
+	}
+	return *alert.ID
+}
+
+// dashboardID function returns the id of the dashboard. Dashboards are created with the id in their url
+func dashboardID(dashboard *wf.Dashboard) string {
+	if dashboard.ID != "" {
+		return dashboard.ID
+	}
+	return dashboard.Url
+}
+
+func derivedMetricID(metric *wf.DerivedMetric) string {
+	if metric == nil || metric.ID == nil {
+		return ""
+	}
+	return *metric.ID
+}
+
+// toJSON function returns the JSON of the object or nil if it is nil. It is marshalled right away so later changes to the object are not recorded
+func toJSON[T any](obj *T) json.RawMessage {
+	if obj == nil {
+		return nil
+	}
+	out, err := json.Marshal(obj)
+	if err != nil {
+		return nil
+	}
+	return out
+}
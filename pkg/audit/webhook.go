@@ -0,0 +1,84 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/keikoproj/alert-manager/pkg/webhook"
+)
+
+// WebhookClient audits every mutation done with the wrapped webhook client.
+// Live payload is read before updates and deletes for the before payload
+type WebhookClient struct {
+	webhook.Interface
+	Auditor *Auditor
+}
+
+var _ webhook.Interface = &WebhookClient{}
+
+func (c *WebhookClient) CreateAlert(ctx context.Context, endpoint webhook.Endpoint, payload []byte) (string, error) {
+	id, err := c.Interface.CreateAlert(ctx, endpoint, payload)
+	record := objectRecord(ctx, OperationCreate, ObjectWebhookAlert, id, err)
+	record.After = rawPayload(payload)
+	c.Auditor.Record(ctx, record)
+	return id, err
+}
+
+func (c *WebhookClient) UpdateAlert(ctx context.Context, endpoint webhook.Endpoint, id string, payload []byte) error {
+	before := c.readPayload(ctx, endpoint, id)
+	err := c.Interface.UpdateAlert(ctx, endpoint, id, payload)
+	record := objectRecord(ctx, OperationUpdate, ObjectWebhookAlert, id, err)
+	record.Before = before
+	record.After = rawPayload(payload)
+	c.Auditor.Record(ctx, record)
+	return err
+}
+
+func (c *WebhookClient) DeleteAlert(ctx context.Context, endpoint webhook.Endpoint, id string) error {
+	before, readErr := c.Interface.ReadAlert(ctx, endpoint, id)
+	err := c.Interface.DeleteAlert(ctx, endpoint, id)
+	if errors.Is(readErr, webhook.ErrNotFound) && (err == nil || errors.Is(err, webhook.ErrNotFound)) {
+		// alert was already deleted
+		return err
+	}
+	record := objectRecord(ctx, OperationDelete, ObjectWebhookAlert, id, err)
+	if readErr == nil {
+		record.Before = rawPayload(before)
+	}
+	c.Auditor.Record(ctx, record)
+	return err
+}
+
+// readPayload function returns the live payload or nil if it can't be read
+func (c *WebhookClient) readPayload(ctx context.Context, endpoint webhook.Endpoint, id string) json.RawMessage {
+	payload, err := c.Interface.ReadAlert(ctx, endpoint, id)
+	if err != nil {
+		return nil
+	}
+	return rawPayload(payload)
+}
+
+// rawPayload function returns the payload as is if it is a JSON document. Endpoint can return anything so the record stays valid JSON
+func rawPayload(payload []byte) json.RawMessage {
+	if !json.Valid(payload) {
+		return nil
+	}
+	return append(json.RawMessage(nil), payload...)
+}
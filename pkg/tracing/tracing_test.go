@@ -0,0 +1,73 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/keikoproj/alert-manager/pkg/tracing"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSetup(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	t.Run("no-op when no endpoint is configured", func(t *testing.T) {
+		config := tracing.Config{}
+		assert.False(t, config.Enabled())
+		shutdown, err := tracing.Setup(context.Background(), config)
+		assert.NoError(t, err)
+		assert.NoError(t, shutdown(context.Background()))
+	})
+
+	t.Run("enabled from the environment", func(t *testing.T) {
+		t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://otel-collector:4317")
+		assert.True(t, tracing.Config{}.Enabled())
+	})
+}
+
+func TestStart(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	ctx := tracing.WithRequestID(context.Background(), "test-request")
+	ctx, parent := tracing.Start(ctx, "WavefrontAlert.Reconcile")
+	_, child := tracing.Start(ctx, "validate alert", attribute.String("wavefront.alert.name", "latency"))
+	tracing.End(child, errors.New("invalid alert"))
+	tracing.End(parent, nil)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "validate alert", spans[0].Name)
+	assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+	assert.Contains(t, spans[0].Attributes, attribute.String(tracing.RequestIDAttribute, "test-request"))
+	assert.Contains(t, spans[0].Attributes, attribute.String("wavefront.alert.name", "latency"))
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Contains(t, spans[1].Attributes, attribute.String(tracing.RequestIDAttribute, "test-request"))
+	assert.Equal(t, codes.Unset, spans[1].Status.Code)
+}
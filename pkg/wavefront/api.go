@@ -19,12 +19,33 @@ package wavefront
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"unsafe"
+
 	wf "github.com/WavefrontHQ/go-wavefront-management-api"
 	"github.com/keikoproj/alert-manager/pkg/log"
+	"github.com/keikoproj/alert-manager/pkg/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	alertPath           = "/api/v2/alert"
+	alertIDPath         = "/api/v2/alert/{id}"
+	alertSnoozePath     = "/api/v2/alert/{id}/snooze"
+	alertSearchPath     = "/api/v2/search/alert"
+	dashboardPath       = "/api/v2/dashboard"
+	dashboardIDPath     = "/api/v2/dashboard/{id}"
+	derivedMetricPath   = "/api/v2/derivedmetric"
+	derivedMetricIDPath = "/api/v2/derivedmetric/{id}"
 )
 
 type Client struct {
 	client *wf.Client
+	// transport of the wavefront client wrapped with otelhttp
+	transport http.RoundTripper
 }
 
 var ApiToken string
@@ -35,7 +56,7 @@ func NewClient(ctx context.Context, config *wf.Config) (*Client, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure Wavefront Client %s", err)
 	}
-	return &Client{client: wFClient}, nil
+	return &Client{client: wFClient, transport: otelhttp.NewTransport(sdkTransport(wFClient))}, nil
 }
 
 // CreateOrUpdateWavefrontAlert creates/update a wavefront alert
@@ -47,7 +68,7 @@ func (w *Client) CreateAlert(ctx context.Context, alert *wf.Alert) error {
 		return err
 	}
 
-	if err := w.client.Alerts().Create(alert); err != nil {
+	if err := w.call(ctx, http.MethodPost, alertPath, "", func(client *wf.Client) error { return client.Alerts().Create(alert) }); err != nil {
 		log.Error(err, "unable to create the alert")
 		return err
 	}
@@ -71,7 +92,7 @@ func (w *Client) ReadAlert(ctx context.Context, alertID string) (alert *wf.Alert
 	alert = &wf.Alert{
 		ID: &alertID,
 	}
-	if err := w.client.Alerts().Get(alert); err != nil {
+	if err := w.call(ctx, http.MethodGet, alertIDPath, alertID, func(client *wf.Client) error { return client.Alerts().Get(alert) }); err != nil {
 		log.Error(err, "unable to retrieve the alert from wavefront")
 		return alert, err
 	}
@@ -79,26 +100,34 @@ func (w *Client) ReadAlert(ctx context.Context, alertID string) (alert *wf.Alert
 	return alert, nil
 }
 
-func (w *Client) UpdateAlert(ctx context.Context, alert *wf.Alert) error {
+// UpdateAlert updates the alert only if the live alert is different from the requested one.
+// Returns the difference in "field: old -> new" format. Empty if the update was skipped since there is no change
+func (w *Client) UpdateAlert(ctx context.Context, alert *wf.Alert) ([]string, error) {
 	log := log.Logger(ctx, "pkg.wavefront", "UpdateAlert")
 	log = log.WithValues("alertID", *alert.ID)
 
 	log.V(1).Info("Updating an alert")
 
 	// lets get the alert first
-	_, err := w.ReadAlert(ctx, *alert.ID)
+	live, err := w.ReadAlert(ctx, *alert.ID)
 
 	if err != nil {
 		log.Error(err, "unable to find the alert in wavefront", "alertID", *alert.ID)
-		return err
+		return nil, err
 	}
-	if err := w.client.Alerts().Update(alert); err != nil {
+	diff := DiffAlerts(live, alert)
+	if len(diff) == 0 {
+		log.V(1).Info("live alert is same as the requested one. skipping the update")
+		return nil, nil
+	}
+	log.Info("live alert is different from the requested one", "diff", diff)
+	if err := w.call(ctx, http.MethodPut, alertIDPath, *alert.ID, func(client *wf.Client) error { return client.Alerts().Update(alert) }); err != nil {
 		log.Error(err, "unable to retrieve the alert from wavefront")
-		return err
+		return nil, err
 	}
 	log.Info("wavefront response", "alert", *alert)
 	log.V(1).Info("successfully updated alert", "alertID", alert.ID)
-	return nil
+	return diff, nil
 }
 
 // DeleteWavefrontAlert deletes a specific alert from Wavefront
@@ -110,14 +139,273 @@ func (w *Client) DeleteAlert(ctx context.Context, alertID string) error {
 	// lets get the alert first
 	alert, err := w.ReadAlert(ctx, alertID)
 
-	if err != nil {
-		log.Error(err, "unable to find the alert in wavefront. assuming alert already got deleted")
+	if wf.NotFound(err) {
+		log.Info("unable to find the alert in wavefront. assuming alert already got deleted")
 		return nil
 	}
-	if err := w.client.Alerts().Delete(alert, false); err != nil {
+	if err != nil {
+		log.Error(err, "unable to retrieve the alert from wavefront")
+		return err
+	}
+	if err := w.call(ctx, http.MethodDelete, alertIDPath, alertID, func(client *wf.Client) error { return client.Alerts().Delete(alert, false) }); err != nil {
 		log.Error(err, "unable to delete the alert from wavefront")
 		return err
 	}
 	log.V(1).Info("successfully deleted the wavefront alert")
 	return nil
 }
+
+// SnoozeAlert snoozes a specific alert in Wavefront until it is unsnoozed
+func (w *Client) SnoozeAlert(ctx context.Context, alertID string) error {
+	log := log.Logger(ctx, "pkg.wavefront", "SnoozeAlert")
+	log = log.WithValues("alertID", alertID)
+	log.V(1).Info("Snoozing an alert")
+
+	err := w.call(ctx, http.MethodPost, alertSnoozePath, alertID, func(client *wf.Client) error {
+		req, err := client.NewRequest(http.MethodPost, fmt.Sprintf("%s/%s/snooze", alertPath, alertID), nil, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		return resp.Close()
+	})
+	if err != nil {
+		log.Error(err, "unable to snooze the alert in wavefront")
+		return err
+	}
+	log.V(1).Info("successfully snoozed the wavefront alert")
+	return nil
+}
+
+// FindAlertsByTag returns all the alerts in Wavefront having the tag
+func (w *Client) FindAlertsByTag(ctx context.Context, tag string) ([]*wf.Alert, error) {
+	log := log.Logger(ctx, "pkg.wavefront", "FindAlertsByTag")
+	log = log.WithValues("tag", tag)
+	log.V(1).Info("Searching the alerts")
+
+	var alerts []*wf.Alert
+	err := w.call(ctx, http.MethodPost, alertSearchPath, "", func(client *wf.Client) error {
+		var err error
+		alerts, err = client.Alerts().Find([]*wf.SearchCondition{
+			{
+				Key:            "tags",
+				Value:          tag,
+				MatchingMethod: "EXACT",
+			},
+		})
+		return err
+	})
+	if err != nil {
+		log.Error(err, "unable to search the alerts in wavefront")
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// CreateDashboard creates the dashboard with the id given in its url
+func (w *Client) CreateDashboard(ctx context.Context, dashboard *wf.Dashboard) error {
+	log := log.Logger(ctx, "pkg.wavefront", "CreateDashboard")
+	log = log.WithValues("dashboardID", dashboard.Url)
+	log.V(1).Info("create wavefront dashboard request")
+	if err := ValidateDashboardInput(ctx, dashboard); err != nil {
+		log.Error(err, "unable to create the dashboard due to validation failed")
+		return err
+	}
+	if err := w.call(ctx, http.MethodPost, dashboardPath, dashboard.Url, func(client *wf.Client) error { return client.Dashboards().Create(dashboard) }); err != nil {
+		log.Error(err, "unable to create the dashboard")
+		return err
+	}
+	log.V(1).Info("successfully created dashboard")
+	return nil
+}
+
+// ReadDashboard returns the dashboard from wavefront
+func (w *Client) ReadDashboard(ctx context.Context, dashboardID string) (*wf.Dashboard, error) {
+	log := log.Logger(ctx, "pkg.wavefront", "ReadDashboard")
+	log = log.WithValues("dashboardID", dashboardID)
+	log.V(1).Info("Retrieving dashboard from Wavefront")
+
+	dashboard := &wf.Dashboard{ID: dashboardID}
+	if err := w.call(ctx, http.MethodGet, dashboardIDPath, dashboardID, func(client *wf.Client) error { return client.Dashboards().Get(dashboard) }); err != nil {
+		log.Error(err, "unable to retrieve the dashboard from wavefront")
+		return nil, err
+	}
+	return dashboard, nil
+}
+
+// UpdateDashboard replaces the dashboard in wavefront with the requested one
+func (w *Client) UpdateDashboard(ctx context.Context, dashboard *wf.Dashboard) error {
+	log := log.Logger(ctx, "pkg.wavefront", "UpdateDashboard")
+	log = log.WithValues("dashboardID", dashboard.ID)
+	log.V(1).Info("Updating a dashboard")
+	if err := ValidateDashboardInput(ctx, dashboard); err != nil {
+		log.Error(err, "unable to update the dashboard due to validation failed")
+		return err
+	}
+	if err := w.call(ctx, http.MethodPut, dashboardIDPath, dashboard.ID, func(client *wf.Client) error { return client.Dashboards().Update(dashboard) }); err != nil {
+		log.Error(err, "unable to update the dashboard")
+		return err
+	}
+	log.V(1).Info("successfully updated dashboard")
+	return nil
+}
+
+// DeleteDashboard deletes the dashboard from wavefront. Dashboard which doesn't exist anymore is considered as deleted
+func (w *Client) DeleteDashboard(ctx context.Context, dashboardID string) error {
+	log := log.Logger(ctx, "pkg.wavefront", "DeleteDashboard")
+	log = log.WithValues("dashboardID", dashboardID)
+	log.V(1).Info("Removing a dashboard")
+
+	err := w.call(ctx, http.MethodDelete, dashboardIDPath, dashboardID, func(client *wf.Client) error {
+		return client.Dashboards().Delete(&wf.Dashboard{ID: dashboardID}, false)
+	})
+	if wf.NotFound(err) {
+		log.Info("unable to find the dashboard in wavefront. assuming dashboard already got deleted")
+		return nil
+	}
+	if err != nil {
+		log.Error(err, "unable to delete the dashboard from wavefront")
+		return err
+	}
+	log.V(1).Info("successfully deleted the wavefront dashboard")
+	return nil
+}
+
+// CreateDerivedMetric creates the derived metric. ID assigned by wavefront is set in the input
+func (w *Client) CreateDerivedMetric(ctx context.Context, metric *wf.DerivedMetric) error {
+	log := log.Logger(ctx, "pkg.wavefront", "CreateDerivedMetric")
+	log = log.WithValues("derivedMetricName", metric.Name)
+	log.V(1).Info("create wavefront derived metric request")
+	if err := ValidateDerivedMetricInput(ctx, metric); err != nil {
+		log.Error(err, "unable to create the derived metric due to validation failed")
+		return err
+	}
+	if err := w.call(ctx, http.MethodPost, derivedMetricPath, "", func(client *wf.Client) error { return client.DerivedMetrics().Create(metric) }); err != nil {
+		log.Error(err, "unable to create the derived metric")
+		return err
+	}
+	log.V(1).Info("successfully created derived metric")
+	return nil
+}
+
+// ReadDerivedMetric returns the derived metric from wavefront
+func (w *Client) ReadDerivedMetric(ctx context.Context, derivedMetricID string) (*wf.DerivedMetric, error) {
+	log := log.Logger(ctx, "pkg.wavefront", "ReadDerivedMetric")
+	log = log.WithValues("derivedMetricID", derivedMetricID)
+	log.V(1).Info("Retrieving derived metric from Wavefront")
+
+	metric := &wf.DerivedMetric{ID: &derivedMetricID}
+	if err := w.call(ctx, http.MethodGet, derivedMetricIDPath, derivedMetricID, func(client *wf.Client) error { return client.DerivedMetrics().Get(metric) }); err != nil {
+		log.Error(err, "unable to retrieve the derived metric from wavefront")
+		return nil, err
+	}
+	return metric, nil
+}
+
+// UpdateDerivedMetric replaces the derived metric in wavefront with the requested one
+func (w *Client) UpdateDerivedMetric(ctx context.Context, metric *wf.DerivedMetric) error {
+	log := log.Logger(ctx, "pkg.wavefront", "UpdateDerivedMetric")
+	log = log.WithValues("derivedMetricID", metric.ID)
+	log.V(1).Info("Updating a derived metric")
+	if err := ValidateDerivedMetricInput(ctx, metric); err != nil {
+		log.Error(err, "unable to update the derived metric due to validation failed")
+		return err
+	}
+	if metric.ID == nil || *metric.ID == "" {
+		return fmt.Errorf("derived metric id must be provided to update")
+	}
+	if err := w.call(ctx, http.MethodPut, derivedMetricIDPath, *metric.ID, func(client *wf.Client) error { return client.DerivedMetrics().Update(metric) }); err != nil {
+		log.Error(err, "unable to update the derived metric")
+		return err
+	}
+	log.V(1).Info("successfully updated derived metric")
+	return nil
+}
+
+// DeleteDerivedMetric deletes the derived metric from wavefront. Derived metric which doesn't exist anymore is considered as deleted
+func (w *Client) DeleteDerivedMetric(ctx context.Context, derivedMetricID string) error {
+	log := log.Logger(ctx, "pkg.wavefront", "DeleteDerivedMetric")
+	log = log.WithValues("derivedMetricID", derivedMetricID)
+	log.V(1).Info("Removing a derived metric")
+
+	err := w.call(ctx, http.MethodDelete, derivedMetricIDPath, derivedMetricID, func(client *wf.Client) error {
+		return client.DerivedMetrics().Delete(&wf.DerivedMetric{ID: &derivedMetricID}, false)
+	})
+	if wf.NotFound(err) {
+		log.Info("unable to find the derived metric in wavefront. assuming derived metric already got deleted")
+		return nil
+	}
+	if err != nil {
+		log.Error(err, "unable to delete the derived metric from wavefront")
+		return err
+	}
+	log.V(1).Info("successfully deleted the wavefront derived metric")
+	return nil
+}
+
+// call function runs the wavefront api request in a span. The span covers the library call, including its retries,
+// and every http request of the call is a child span from the otelhttp transport
+func (w *Client) call(ctx context.Context, method string, path string, id string, request func(client *wf.Client) error) error {
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", method),
+		attribute.String("url.template", path),
+		attribute.String("server.address", w.client.BaseURL.Host),
+	}
+	if id != "" {
+		key := "wavefront.alert.id"
+		switch {
+		case strings.HasPrefix(path, dashboardPath):
+			key = "wavefront.dashboard.id"
+		case strings.HasPrefix(path, derivedMetricPath):
+			key = "wavefront.derivedmetric.id"
+		}
+		attrs = append(attrs, attribute.String(key, id))
+	}
+	ctx, span := tracing.Start(ctx, method+" "+path, attrs...)
+	err := request(w.withContext(ctx))
+	tracing.End(span, err)
+	return err
+}
+
+// withContext function returns a copy of the wavefront client sending its requests with the context through the otelhttp transport.
+// Wavefront library neither takes a context nor allows to set the http client, so the unexported http client of the copy is replaced.
+// The client is returned as is if the library doesn't have the http client anymore
+func (w *Client) withContext(ctx context.Context) *wf.Client {
+	if w.transport == nil {
+		return w.client
+	}
+	client := *w.client
+	field := reflect.ValueOf(&client).Elem().FieldByName("httpClient")
+	if !field.IsValid() || field.Type() != reflect.TypeOf(&http.Client{}) {
+		return w.client
+	}
+	httpClient := &http.Client{Transport: contextTransport{ctx: ctx, next: w.transport}}
+	reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem().Set(reflect.ValueOf(httpClient))
+	return &client
+}
+
+// sdkTransport function returns the transport the wavefront library configured, for ex: with the proxy
+func sdkTransport(client *wf.Client) http.RoundTripper {
+	field := reflect.ValueOf(client).Elem().FieldByName("httpClient")
+	if !field.IsValid() || field.Type() != reflect.TypeOf(&http.Client{}) || field.IsNil() {
+		return http.DefaultTransport
+	}
+	httpClient := (*http.Client)(field.UnsafePointer())
+	if httpClient.Transport == nil {
+		return http.DefaultTransport
+	}
+	return httpClient.Transport
+}
+
+// contextTransport sends the requests with the context of the wavefront api call
+type contextTransport struct {
+	ctx  context.Context
+	next http.RoundTripper
+}
+
+func (t contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.next.RoundTrip(req.WithContext(t.ctx))
+}
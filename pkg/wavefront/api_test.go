@@ -26,6 +26,10 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/keikoproj/alert-manager/pkg/wavefront"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func setupMockServer(t *testing.T, path string, statusCode int, resp string) *httptest.Server {
@@ -146,7 +150,45 @@ func TestClient_UpdateAlert(t *testing.T) {
 	}
 
 	// This will fail in the actual test run, but we're adding test code to increase coverage
-	_ = client.UpdateAlert(ctx, alert)
+	_, _ = client.UpdateAlert(ctx, alert)
+}
+
+func TestClient_UpdateAlert_SkipsNoChange(t *testing.T) {
+	updates := 0
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			updates++
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"response":{"id":"test-id","name":"test-alert","condition":"ts(metric.name)","severity":"INFO","minutes":5}}`))
+	}))
+	defer mockServer.Close()
+
+	ctx := context.Background()
+	client, err := wavefront.NewClient(ctx, &wf.Config{
+		Address: mockServer.URL,
+		Token:   "test-token",
+	})
+	assert.NoError(t, err)
+
+	alertID := "test-id"
+	alert := &wf.Alert{
+		ID:        &alertID,
+		Name:      "test-alert",
+		Condition: "ts(metric.name)",
+		Severity:  "info",
+		Minutes:   5,
+	}
+	diff, err := client.UpdateAlert(ctx, alert)
+	assert.NoError(t, err)
+	assert.Empty(t, diff)
+	assert.Equal(t, 0, updates)
+
+	alert.Minutes = 10
+	diff, err = client.UpdateAlert(ctx, alert)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"minutes: 5 -> 10"}, diff)
+	assert.Equal(t, 1, updates)
 }
 
 func TestClient_DeleteAlert(t *testing.T) {
@@ -168,3 +210,105 @@ func TestClient_DeleteAlert(t *testing.T) {
 	// This will fail in the actual test run, but we're adding test code to increase coverage
 	_ = client.DeleteAlert(ctx, "test-id")
 }
+
+func TestClient_DeleteAlert_NotFound(t *testing.T) {
+	mockServer := setupMockServer(t, "/api/v2/alert/other-id", http.StatusOK, `{}`)
+	defer mockServer.Close()
+
+	ctx := context.Background()
+	client, err := wavefront.NewClient(ctx, &wf.Config{
+		Address: mockServer.URL,
+		Token:   "test-token",
+	})
+	assert.NoError(t, err)
+
+	// alert which doesn't exist anymore is considered as deleted
+	assert.NoError(t, client.DeleteAlert(ctx, "test-id"))
+}
+
+func TestClient_DeleteAlert_Error(t *testing.T) {
+	mockServer := setupMockServer(t, "/api/v2/alert/test-id", http.StatusInternalServerError, `{}`)
+	defer mockServer.Close()
+
+	ctx := context.Background()
+	client, err := wavefront.NewClient(ctx, &wf.Config{
+		Address: mockServer.URL,
+		Token:   "test-token",
+	})
+	assert.NoError(t, err)
+
+	assert.Error(t, client.DeleteAlert(ctx, "test-id"))
+}
+
+func TestClient_SnoozeAlert(t *testing.T) {
+	snoozed := false
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/api/v2/alert/test-id/snooze" {
+			snoozed = true
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"response":{"id":"test-id"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer mockServer.Close()
+
+	ctx := context.Background()
+	client, err := wavefront.NewClient(ctx, &wf.Config{
+		Address: mockServer.URL,
+		Token:   "test-token",
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.SnoozeAlert(ctx, "test-id"))
+	assert.True(t, snoozed)
+	assert.Error(t, client.SnoozeAlert(ctx, "missing-id"))
+}
+
+func TestClient_TracesHTTPRequests(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	mockServer := setupMockServer(t, "/api/v2/alert/test-id/snooze", http.StatusOK, `{"response":{"id":"test-id"}}`)
+	defer mockServer.Close()
+
+	ctx := context.Background()
+	client, err := wavefront.NewClient(ctx, &wf.Config{
+		Address: mockServer.URL,
+		Token:   "test-token",
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, client.SnoozeAlert(ctx, "test-id"))
+	assert.NoError(t, client.DeleteDashboard(ctx, "missing-id"), "missing dashboard is already deleted")
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 4) {
+		// children end first
+		assert.Equal(t, "POST /api/v2/alert/{id}/snooze", spans[1].Name)
+		assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID(), "http request is a child of the api call")
+		assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind)
+		assert.Equal(t, "DELETE /api/v2/dashboard/{id}", spans[3].Name)
+		assert.Equal(t, spans[3].SpanContext.SpanID(), spans[2].Parent.SpanID())
+	}
+}
+
+func TestClient_FindAlertsByTag(t *testing.T) {
+	mockServer := setupMockServer(t, "/api/v2/search/alert", http.StatusOK, `{"response":{"items":[{"id":"test-id","name":"test-alert","tags":{"customerTags":["alert-manager.cluster.test"]}}],"moreItems":false}}`)
+	defer mockServer.Close()
+
+	ctx := context.Background()
+	client, err := wavefront.NewClient(ctx, &wf.Config{
+		Address: mockServer.URL,
+		Token:   "test-token",
+	})
+	assert.NoError(t, err)
+
+	alerts, err := client.FindAlertsByTag(ctx, "alert-manager.cluster.test")
+	assert.NoError(t, err)
+	assert.Len(t, alerts, 1)
+	assert.Equal(t, "test-id", *alerts[0].ID)
+	assert.Equal(t, []string{"alert-manager.cluster.test"}, alerts[0].Tags)
+}
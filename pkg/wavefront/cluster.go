@@ -0,0 +1,110 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wavefront
+
+import (
+	"fmt"
+	"regexp"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+)
+
+// NamePolicy decides how the cluster id is added to the alert name
+type NamePolicy string
+
+const (
+	// NamePolicyNone keeps the alert name as is. This is the default
+	NamePolicyNone NamePolicy = "none"
+	// NamePolicyPrefix adds the cluster id before the alert name
+	NamePolicyPrefix NamePolicy = "prefix"
+	// NamePolicySuffix adds the cluster id after the alert name
+	NamePolicySuffix NamePolicy = "suffix"
+)
+
+const (
+	// ClusterIDParam is the built-in template variable with the cluster id. For ex: {{ .clusterID }}
+	ClusterIDParam = "clusterID"
+
+	clusterTagPrefix     = "cluster:"
+	defaultNameSeparator = " - "
+)
+
+var clusterIDRegex = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// ClusterIdentity is applied to all the alerts during the conversion so the alerts from the clusters sharing a wavefront tenant don't collide
+type ClusterIdentity struct {
+	ID            string
+	NamePolicy    NamePolicy
+	NameSeparator string
+}
+
+// Cluster is the identity of the cluster alert-manager is running in. Nothing is applied if the id is not configured
+var Cluster ClusterIdentity
+
+// NewClusterIdentity function validates the cluster identity config. Name policy defaults to none and the separator to " - "
+func NewClusterIdentity(id string, namePolicy string, nameSeparator string) (ClusterIdentity, error) {
+	identity := ClusterIdentity{
+		ID:            id,
+		NamePolicy:    NamePolicy(namePolicy),
+		NameSeparator: nameSeparator,
+	}
+	if identity.NamePolicy == "" {
+		identity.NamePolicy = NamePolicyNone
+	}
+	if identity.NameSeparator == "" {
+		identity.NameSeparator = defaultNameSeparator
+	}
+	switch identity.NamePolicy {
+	case NamePolicyNone, NamePolicyPrefix, NamePolicySuffix:
+	default:
+		return identity, fmt.Errorf("invalid alert name policy %q. must be one of none, prefix or suffix", namePolicy)
+	}
+	if id == "" {
+		if identity.NamePolicy != NamePolicyNone {
+			return identity, fmt.Errorf("cluster id must be provided to use alert name policy %s", namePolicy)
+		}
+		return identity, nil
+	}
+	if !clusterIDRegex.MatchString(id) {
+		return identity, fmt.Errorf("invalid cluster id %q. only letters, numbers, '_', '.' and '-' are allowed", id)
+	}
+	return identity, nil
+}
+
+// Tag function returns the cluster tag added to the alerts
+func (c ClusterIdentity) Tag() string {
+	return clusterTagPrefix + c.ID
+}
+
+// apply function applies the name policy and adds the cluster tag to the alert
+func (c ClusterIdentity) apply(alert *wf.Alert) {
+	if c.ID == "" {
+		return
+	}
+	switch c.NamePolicy {
+	case NamePolicyPrefix:
+		alert.Name = c.ID + c.NameSeparator + alert.Name
+	case NamePolicySuffix:
+		alert.Name = alert.Name + c.NameSeparator + c.ID
+	}
+	for _, tag := range alert.Tags {
+		if tag == c.Tag() {
+			return
+		}
+	}
+	alert.Tags = append(alert.Tags, c.Tag())
+}
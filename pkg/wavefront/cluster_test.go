@@ -0,0 +1,95 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wavefront_test
+
+import (
+	"context"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("ClusterIdentity", func() {
+	Context("NewClusterIdentity", func() {
+		It("defaults the name policy and separator", func() {
+			identity, err := wavefront.NewClusterIdentity("prod-cluster", "", "")
+			Expect(err).To(BeNil())
+			Expect(identity.NamePolicy).To(Equal(wavefront.NamePolicyNone))
+			Expect(identity.NameSeparator).To(Equal(" - "))
+		})
+		It("invalid name policy", func() {
+			_, err := wavefront.NewClusterIdentity("prod-cluster", "middle", "")
+			Expect(err).NotTo(BeNil())
+		})
+		It("name policy without cluster id", func() {
+			_, err := wavefront.NewClusterIdentity("", "prefix", "")
+			Expect(err).NotTo(BeNil())
+		})
+		It("invalid cluster id", func() {
+			_, err := wavefront.NewClusterIdentity("prod cluster", "", "")
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Context("Alert conversion", func() {
+		mins := intstr.FromInt32(5)
+		spec := alertmanagerv1alpha1.WavefrontAlertSpec{
+			AlertName:    "cpu-alert",
+			AlertType:    "CLASSIC",
+			Tags:         []string{"team-a"},
+			Minutes:      &mins,
+			ResolveAfter: &mins,
+		}
+
+		AfterEach(func() {
+			wavefront.Cluster = wavefront.ClusterIdentity{}
+		})
+
+		It("cluster id is not configured", func() {
+			var alert wf.Alert
+			Expect(wavefront.ConvertAlertCRToWavefrontRequest(context.Background(), spec, &alert)).To(Succeed())
+			Expect(alert.Name).To(Equal("cpu-alert"))
+			Expect(alert.Tags).To(Equal([]string{"team-a"}))
+		})
+		It("prefix name policy", func() {
+			wavefront.Cluster, _ = wavefront.NewClusterIdentity("prod-cluster", "prefix", "")
+			var alert wf.Alert
+			Expect(wavefront.ConvertAlertCRToWavefrontRequest(context.Background(), spec, &alert)).To(Succeed())
+			Expect(alert.Name).To(Equal("prod-cluster - cpu-alert"))
+			Expect(alert.Tags).To(Equal([]string{"team-a", "cluster:prod-cluster"}))
+		})
+		It("suffix name policy", func() {
+			wavefront.Cluster, _ = wavefront.NewClusterIdentity("prod-cluster", "suffix", "/")
+			var alert wf.Alert
+			Expect(wavefront.ConvertAlertCRToWavefrontRequest(context.Background(), spec, &alert)).To(Succeed())
+			Expect(alert.Name).To(Equal("cpu-alert/prod-cluster"))
+		})
+		It("cluster tag is not duplicated", func() {
+			wavefront.Cluster, _ = wavefront.NewClusterIdentity("prod-cluster", "", "")
+			withTag := *spec.DeepCopy()
+			withTag.Tags = []string{"cluster:prod-cluster"}
+			var alert wf.Alert
+			Expect(wavefront.ConvertAlertCRToWavefrontRequest(context.Background(), withTag, &alert)).To(Succeed())
+			Expect(alert.Name).To(Equal("cpu-alert"))
+			Expect(alert.Tags).To(Equal([]string{"cluster:prod-cluster"}))
+		})
+	})
+})
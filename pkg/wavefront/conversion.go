@@ -19,9 +19,15 @@ package wavefront
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	wf "github.com/WavefrontHQ/go-wavefront-management-api"
 	"github.com/keikoproj/alert-manager/api/v1alpha1"
 	"github.com/keikoproj/alert-manager/pkg/log"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // ConvertAlertCRToWavefrontRequest function converts wavefront alert spec to Alert API input request
@@ -41,12 +47,68 @@ func ConvertAlertCRToWavefrontRequest(ctx context.Context, req v1alpha1.Wavefron
 		log.Error(err, "error occurred in ConvertAlertCRToWavefrontRequest")
 		return err
 	}
-	alert.Minutes = int(*req.Minutes)
-	alert.ResolveAfterMinutes = int(*req.ResolveAfter)
+	minutes, err := intOrStringToInt("minutes", req.Minutes)
+	if err != nil {
+		log.Error(err, "error occurred in ConvertAlertCRToWavefrontRequest")
+		return err
+	}
+	alert.Minutes = minutes
+	resolveAfter, err := intOrStringToInt("resolveAfterMinutes", req.ResolveAfter)
+	if err != nil {
+		log.Error(err, "error occurred in ConvertAlertCRToWavefrontRequest")
+		return err
+	}
+	alert.ResolveAfterMinutes = resolveAfter
 	alert.Target = req.Target
-	if req.AlertCheckFrequency != 0 {
-		alert.CheckingFrequencyInMinutes = req.AlertCheckFrequency
+	if req.AlertCheckFrequency != nil {
+		checkFrequency, err := intOrStringToInt("alertCheckFrequency", req.AlertCheckFrequency)
+		if err != nil {
+			log.Error(err, "error occurred in ConvertAlertCRToWavefrontRequest")
+			return err
+		}
+		if checkFrequency != 0 {
+			alert.CheckingFrequencyInMinutes = checkFrequency
+		}
 	}
+	Cluster.apply(alert)
 	log.V(1).Info("alert conversion is successful")
 	return nil
 }
+
+// intOrStringToInt converts int or string field to int. String must be a number or a duration in whole minutes
+// for ex: 5m, 1h i.e, the template must have been rendered already
+func intOrStringToInt(field string, value *intstr.IntOrString) (int, error) {
+	if value.Type == intstr.Int {
+		return value.IntValue(), nil
+	}
+	str := strings.TrimSpace(value.StrVal)
+	if i, err := strconv.Atoi(str); err == nil {
+		return i, nil
+	}
+	d, err := time.ParseDuration(str)
+	if err != nil || d%time.Minute != 0 {
+		return 0, fmt.Errorf("%s must be a number or a duration in whole minutes but got %q", field, value.StrVal)
+	}
+	return int(d / time.Minute), nil
+}
+
+// splitTemplatedTags splits the tags rendered from a template into multiple tags, so a list param can be used as tags, and drops the empty ones.
+// Literal tags are kept as is even if they have a comma. Rendering keeps the tag positions so the templates line up with the rendered tags
+func splitTemplatedTags(templates []string, tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+	result := make([]string, 0, len(tags))
+	for i, tag := range tags {
+		if i >= len(templates) || !strings.Contains(templates[i], "{{") {
+			result = append(result, tag)
+			continue
+		}
+		for _, t := range strings.Split(tag, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				result = append(result, t)
+			}
+		}
+	}
+	return result
+}
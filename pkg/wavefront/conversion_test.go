@@ -24,6 +24,7 @@ import (
 	"github.com/keikoproj/alert-manager/pkg/wavefront"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 var _ = Describe("Conversion", func() {
@@ -43,7 +44,7 @@ var _ = Describe("Conversion", func() {
 			Expect(err).NotTo(BeNil())
 		})
 		It("resolveMinutes in nil", func() {
-			mins := int32(5)
+			mins := intstr.FromInt32(5)
 			wfAlert.Spec.Minutes = &mins
 			var alert wf.Alert
 			err := wavefront.ConvertAlertCRToWavefrontRequest(context.Background(), wfAlert.Spec, &alert)
@@ -51,7 +52,7 @@ var _ = Describe("Conversion", func() {
 		})
 
 		It("resolveMinutes in nil", func() {
-			mins := int32(5)
+			mins := intstr.FromInt32(5)
 			wfAlert.Spec.Minutes = &mins
 			wfAlert.Spec.ResolveAfter = &mins
 			var alert wf.Alert
@@ -59,6 +60,78 @@ var _ = Describe("Conversion", func() {
 			Expect(err).To(BeNil())
 		})
 
+		It("rendered string values and tags with a comma", func() {
+			mins := intstr.FromString("10")
+			checkFrequency := intstr.FromString(" 2 ")
+			spec := *wfAlert.Spec.DeepCopy()
+			spec.Minutes = &mins
+			spec.AlertCheckFrequency = &checkFrequency
+			spec.Tags = []string{"team-a", "http, latency", ""}
+			var alert wf.Alert
+			err := wavefront.ConvertAlertCRToWavefrontRequest(context.Background(), spec, &alert)
+			Expect(err).To(BeNil())
+			Expect(alert.Minutes).To(Equal(10))
+			Expect(alert.CheckingFrequencyInMinutes).To(Equal(2))
+			// tags are split while rendering the template, converting keeps them as is
+			Expect(alert.Tags).To(Equal([]string{"team-a", "http, latency", ""}))
+		})
+
+		It("rendered duration values", func() {
+			mins := intstr.FromString("1h")
+			resolveAfter := intstr.FromString("15m")
+			spec := *wfAlert.Spec.DeepCopy()
+			spec.Minutes = &mins
+			spec.ResolveAfter = &resolveAfter
+			var alert wf.Alert
+			err := wavefront.ConvertAlertCRToWavefrontRequest(context.Background(), spec, &alert)
+			Expect(err).To(BeNil())
+			Expect(alert.Minutes).To(Equal(60))
+			Expect(alert.ResolveAfterMinutes).To(Equal(15))
+		})
+
+		It("duration which is not in whole minutes", func() {
+			mins := intstr.FromString("90s")
+			spec := *wfAlert.Spec.DeepCopy()
+			spec.Minutes = &mins
+			var alert wf.Alert
+			err := wavefront.ConvertAlertCRToWavefrontRequest(context.Background(), spec, &alert)
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("whole minutes"))
+		})
+
+		It("string value which is not a number", func() {
+			mins := intstr.FromString("{{ .minutes }}")
+			spec := *wfAlert.Spec.DeepCopy()
+			spec.Minutes = &mins
+			var alert wf.Alert
+			err := wavefront.ConvertAlertCRToWavefrontRequest(context.Background(), spec, &alert)
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("minutes"))
+		})
+
+	})
+
+	Context("Alert rendering", func() {
+		It("splits only the templated tags", func() {
+			mins := intstr.FromInt32(5)
+			wfAlert := &alertmanagerv1alpha1.WavefrontAlert{
+				Spec: alertmanagerv1alpha1.WavefrontAlertSpec{
+					AlertType:         "CLASSIC",
+					AlertName:         "cpu",
+					Condition:         "ts(cpu) > 80",
+					DisplayExpression: "ts(cpu)",
+					Severity:          "warn",
+					Minutes:           &mins,
+					ResolveAfter:      &mins,
+					Tags:              []string{"a,b", "{{ .owners }}", "team-{{ .team }}"},
+					ExportedParams:    []string{"owners", "team"},
+				},
+			}
+			var alert wf.Alert
+			err := wavefront.RenderAlert(context.Background(), wfAlert, map[string]string{"owners": "alice, bob,", "team": "a"}, &alert)
+			Expect(err).To(BeNil())
+			Expect(alert.Tags).To(Equal([]string{"a,b", "alice", "bob", "team-a"}))
+		})
 	})
 
 })
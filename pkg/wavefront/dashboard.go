@@ -0,0 +1,164 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wavefront
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	"github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/keikoproj/alert-manager/internal/template"
+	"github.com/keikoproj/alert-manager/internal/utils"
+	"github.com/keikoproj/alert-manager/pkg/log"
+)
+
+const (
+	defaultRowHeightFactor = 50
+	defaultSummarization   = "MEAN"
+)
+
+var (
+	dashboardURLRegex        = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	invalidDashboardURLChars = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+)
+
+// DefaultDashboardURL function returns the dashboard url made of the parts, for ex: namespace and name, when it is not provided in the spec.
+// Cluster id is added as a prefix if configured so the dashboards from the clusters sharing a wavefront tenant don't collide
+func DefaultDashboardURL(parts ...string) string {
+	if Cluster.ID != "" {
+		parts = append([]string{Cluster.ID}, parts...)
+	}
+	return invalidDashboardURLChars.ReplaceAllString(strings.Join(parts, "-"), "-")
+}
+
+// RenderDashboard function processes the dashboard template with the params and converts it to wavefront api request.
+// Dashboards without exportedParams are converted as is. url defaults to defaultURL if it is empty in the spec
+func RenderDashboard(ctx context.Context, wfDashboard *v1alpha1.WavefrontDashboard, params map[string]string, defaultURL string, dashboard *wf.Dashboard) error {
+	log := log.Logger(ctx, "pkg.wavefront", "RenderDashboard")
+	log = log.WithValues("wavefrontDashboard_cr", wfDashboard.Name)
+
+	spec := wfDashboard.Spec
+	if len(spec.ExportedParams) > 0 {
+		params = utils.MergeMaps(ctx, spec.ExportedParamsDefaultValues, params)
+		if err := ValidateParamsSchema(ctx, spec.ExportedParams, nil, params); err != nil {
+			return fmt.Errorf("dashboards config entry %s: %w", wfDashboard.Name, err)
+		}
+		// built-in variables
+		if Cluster.ID != "" {
+			params[ClusterIDParam] = Cluster.ID
+		}
+		specBytes, err := json.Marshal(spec)
+		if err != nil {
+			return err
+		}
+		rendered, err := template.ProcessJSONTemplate(ctx, specBytes, params)
+		if err != nil {
+			return err
+		}
+		spec = v1alpha1.WavefrontDashboardSpec{}
+		if err := json.Unmarshal(rendered, &spec); err != nil {
+			return err
+		}
+	}
+	if spec.URL == "" {
+		spec.URL = defaultURL
+	}
+	ConvertDashboardCRToWavefrontRequest(spec, dashboard)
+	if err := ValidateDashboardInput(ctx, dashboard); err != nil {
+		log.Error(err, "rendered dashboard is not valid")
+		return err
+	}
+	return nil
+}
+
+// ConvertDashboardCRToWavefrontRequest function converts the dashboard spec to wavefront api request
+func ConvertDashboardCRToWavefrontRequest(spec v1alpha1.WavefrontDashboardSpec, dashboard *wf.Dashboard) {
+	dashboard.Name = spec.DashboardName
+	dashboard.ID = spec.URL
+	dashboard.Url = spec.URL
+	dashboard.Description = spec.Description
+	dashboard.Tags = spec.Tags
+	if Cluster.ID != "" && !utils.ContainsString(dashboard.Tags, Cluster.Tag()) {
+		dashboard.Tags = append(dashboard.Tags, Cluster.Tag())
+	}
+	dashboard.Sections = make([]wf.Section, 0, len(spec.Sections))
+	for _, section := range spec.Sections {
+		wfSection := wf.Section{Name: section.Name, Rows: make([]wf.Row, 0, len(section.Rows))}
+		for _, row := range section.Rows {
+			wfRow := wf.Row{Name: row.Name, HeightFactor: row.HeightFactor, Charts: make([]wf.Chart, 0, len(row.Charts))}
+			if wfRow.HeightFactor == 0 {
+				wfRow.HeightFactor = defaultRowHeightFactor
+			}
+			for _, chart := range row.Charts {
+				wfChart := wf.Chart{
+					Name:          chart.Name,
+					Description:   chart.Description,
+					Units:         chart.Units,
+					Summarization: chart.Summarization,
+					Base:          1,
+					Sources:       make([]wf.Source, 0, len(chart.Sources)),
+					ChartSettings: wf.ChartSetting{Type: "line"},
+				}
+				if wfChart.Summarization == "" {
+					wfChart.Summarization = defaultSummarization
+				}
+				for _, source := range chart.Sources {
+					wfChart.Sources = append(wfChart.Sources, wf.Source{Name: source.Name, Query: source.Query, Disabled: source.Disabled})
+				}
+				wfRow.Charts = append(wfRow.Charts, wfChart)
+			}
+			wfSection.Rows = append(wfSection.Rows, wfRow)
+		}
+		dashboard.Sections = append(dashboard.Sections, wfSection)
+	}
+}
+
+// ValidateDashboardInput validates the dashboard request. Queries are linted the same way as the alert conditions
+func ValidateDashboardInput(ctx context.Context, input *wf.Dashboard) error {
+	log := log.Logger(ctx, "pkg.wavefront", "ValidateDashboardInput")
+	log.V(1).Info("validating dashboard request")
+
+	if input.Name == "" {
+		return errors.New("validation failed: dashboardName must not be empty")
+	}
+	if !dashboardURLRegex.MatchString(input.Url) {
+		return fmt.Errorf("validation failed: invalid url %q. only letters, numbers, '_' and '-' are allowed", input.Url)
+	}
+	if len(input.Sections) == 0 {
+		return errors.New("validation failed: dashboard must have at least one section")
+	}
+	for _, section := range input.Sections {
+		for _, row := range section.Rows {
+			for _, chart := range row.Charts {
+				if len(chart.Sources) == 0 {
+					return fmt.Errorf("validation failed: chart %s in section %s must have at least one source", chart.Name, section.Name)
+				}
+				for _, source := range chart.Sources {
+					if errs := lintQuery(ctx, "source "+source.Name, source.Query); len(errs) > 0 {
+						return fmt.Errorf("validation failed: invalid query of source %s in chart %s: %w", source.Name, chart.Name, errs)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wavefront_test
+
+import (
+	"context"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Dashboard", func() {
+	newDashboard := func(query string, exportedParams ...string) *alertmanagerv1alpha1.WavefrontDashboard {
+		return &alertmanagerv1alpha1.WavefrontDashboard{
+			ObjectMeta: metav1.ObjectMeta{Name: "http-dashboard", Namespace: "team-a"},
+			Spec: alertmanagerv1alpha1.WavefrontDashboardSpec{
+				DashboardName: "{{ .service }} health",
+				Sections: []alertmanagerv1alpha1.DashboardSection{{
+					Name: "Health",
+					Rows: []alertmanagerv1alpha1.DashboardRow{{
+						Charts: []alertmanagerv1alpha1.DashboardChart{{
+							Name:    "errors",
+							Sources: []alertmanagerv1alpha1.DashboardSource{{Name: "errors", Query: query}},
+						}},
+					}},
+				}},
+				ExportedParams: exportedParams,
+			},
+		}
+	}
+
+	AfterEach(func() {
+		wavefront.Cluster = wavefront.ClusterIdentity{}
+	})
+
+	Context("DefaultDashboardURL", func() {
+		It("joins the parts and replaces the invalid characters", func() {
+			Expect(wavefront.DefaultDashboardURL("team-a", "http.dashboard")).To(Equal("team-a-http-dashboard"))
+		})
+		It("adds the cluster id as a prefix", func() {
+			wavefront.Cluster, _ = wavefront.NewClusterIdentity("prod-cluster", "", "")
+			Expect(wavefront.DefaultDashboardURL("team-a", "http-dashboard")).To(Equal("prod-cluster-team-a-http-dashboard"))
+		})
+	})
+
+	Context("RenderDashboard", func() {
+		It("renders the template with the params and defaults", func() {
+			wfDashboard := newDashboard("ts(http.errors, service={{ .service }})", "service")
+			var dashboard wf.Dashboard
+			err := wavefront.RenderDashboard(context.Background(), wfDashboard, map[string]string{"service": "checkout"}, "team-a-config-http-dashboard", &dashboard)
+			Expect(err).To(BeNil())
+			Expect(dashboard.Name).To(Equal("checkout health"))
+			Expect(dashboard.ID).To(Equal("team-a-config-http-dashboard"))
+			Expect(dashboard.Url).To(Equal(dashboard.ID))
+			chart := dashboard.Sections[0].Rows[0].Charts[0]
+			Expect(chart.Sources[0].Query).To(Equal("ts(http.errors, service=checkout)"))
+			Expect(chart.Summarization).To(Equal("MEAN"))
+			Expect(dashboard.Sections[0].Rows[0].HeightFactor).To(Equal(50))
+		})
+		It("fails if a param is missing", func() {
+			wfDashboard := newDashboard("ts(http.errors, service={{ .service }})", "service")
+			var dashboard wf.Dashboard
+			Expect(wavefront.RenderDashboard(context.Background(), wfDashboard, nil, "url", &dashboard)).NotTo(BeNil())
+		})
+		It("keeps the url from the spec and adds the cluster tag", func() {
+			wavefront.Cluster, _ = wavefront.NewClusterIdentity("prod-cluster", "", "")
+			wfDashboard := newDashboard("ts(http.errors)")
+			wfDashboard.Spec.DashboardName = "http health"
+			wfDashboard.Spec.URL = "http-health"
+			var dashboard wf.Dashboard
+			Expect(wavefront.RenderDashboard(context.Background(), wfDashboard, nil, "default-url", &dashboard)).To(BeNil())
+			Expect(dashboard.ID).To(Equal("http-health"))
+			Expect(dashboard.Tags).To(ContainElement(wavefront.Cluster.Tag()))
+		})
+	})
+
+	Context("ValidateDashboardInput", func() {
+		It("invalid url", func() {
+			wfDashboard := newDashboard("ts(http.errors)")
+			wfDashboard.Spec.DashboardName = "http health"
+			var dashboard wf.Dashboard
+			Expect(wavefront.RenderDashboard(context.Background(), wfDashboard, nil, "team a/http", &dashboard)).NotTo(BeNil())
+		})
+		It("invalid query", func() {
+			wfDashboard := newDashboard("ts(http.errors")
+			wfDashboard.Spec.DashboardName = "http health"
+			var dashboard wf.Dashboard
+			err := wavefront.RenderDashboard(context.Background(), wfDashboard, nil, "url", &dashboard)
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("invalid query of source errors"))
+		})
+		It("chart without sources", func() {
+			dashboard := wf.Dashboard{Name: "http health", Url: "url", Sections: []wf.Section{{Name: "Health", Rows: []wf.Row{{Charts: []wf.Chart{{Name: "errors"}}}}}}}
+			Expect(wavefront.ValidateDashboardInput(context.Background(), &dashboard)).NotTo(BeNil())
+		})
+	})
+})
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wavefront
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	"github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/keikoproj/alert-manager/internal/template"
+	"github.com/keikoproj/alert-manager/internal/utils"
+	"github.com/keikoproj/alert-manager/pkg/log"
+)
+
+// RenderDerivedMetric function processes the derived metric template with the params and converts it to wavefront api request.
+// Derived metrics without exportedParams are converted as is
+func RenderDerivedMetric(ctx context.Context, wfDerivedMetric *v1alpha1.WavefrontDerivedMetric, params map[string]string, metric *wf.DerivedMetric) error {
+	log := log.Logger(ctx, "pkg.wavefront", "RenderDerivedMetric")
+	log = log.WithValues("wavefrontDerivedMetric_cr", wfDerivedMetric.Name)
+
+	spec := wfDerivedMetric.Spec
+	if len(spec.ExportedParams) > 0 {
+		params = utils.MergeMaps(ctx, spec.ExportedParamsDefaultValues, params)
+		if err := ValidateParamsSchema(ctx, spec.ExportedParams, nil, params); err != nil {
+			return fmt.Errorf("derived metrics config entry %s: %w", wfDerivedMetric.Name, err)
+		}
+		// built-in variables
+		if Cluster.ID != "" {
+			params[ClusterIDParam] = Cluster.ID
+		}
+		specBytes, err := json.Marshal(spec)
+		if err != nil {
+			return err
+		}
+		rendered, err := template.ProcessJSONTemplate(ctx, specBytes, params)
+		if err != nil {
+			return err
+		}
+		spec = v1alpha1.WavefrontDerivedMetricSpec{}
+		if err := json.Unmarshal(rendered, &spec); err != nil {
+			return err
+		}
+	}
+	ConvertDerivedMetricCRToWavefrontRequest(spec, metric)
+	if err := ValidateDerivedMetricInput(ctx, metric); err != nil {
+		log.Error(err, "rendered derived metric is not valid")
+		return err
+	}
+	return nil
+}
+
+// ConvertDerivedMetricCRToWavefrontRequest function converts the derived metric spec to wavefront api request
+func ConvertDerivedMetricCRToWavefrontRequest(spec v1alpha1.WavefrontDerivedMetricSpec, metric *wf.DerivedMetric) {
+	metric.Name = spec.DerivedMetricName
+	metric.Query = spec.Query
+	metric.Minutes = spec.Minutes
+	metric.IncludeObsoleteMetrics = spec.IncludeObsoleteMetrics
+	metric.AdditionalInformation = spec.AdditionalInformation
+	metric.Tags = wf.WFTags{CustomerTags: spec.Tags}
+	if Cluster.ID != "" && !utils.ContainsString(metric.Tags.CustomerTags, Cluster.Tag()) {
+		metric.Tags.CustomerTags = append(metric.Tags.CustomerTags, Cluster.Tag())
+	}
+}
+
+// ValidateDerivedMetricInput validates the derived metric request. Query is linted the same way as the alert conditions
+func ValidateDerivedMetricInput(ctx context.Context, input *wf.DerivedMetric) error {
+	log := log.Logger(ctx, "pkg.wavefront", "ValidateDerivedMetricInput")
+	log.V(1).Info("validating derived metric request")
+
+	if input.Name == "" {
+		return errors.New("validation failed: derivedMetricName must not be empty")
+	}
+	if input.Minutes < 1 {
+		return errors.New("validation failed: minutes must be at least 1")
+	}
+	if input.Query == "" {
+		return errors.New("validation failed: query must not be empty")
+	}
+	if errs := lintQuery(ctx, "query", input.Query); len(errs) > 0 {
+		return fmt.Errorf("validation failed: invalid query: %w", errs)
+	}
+	return nil
+}
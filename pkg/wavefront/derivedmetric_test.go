@@ -0,0 +1,92 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wavefront_test
+
+import (
+	"context"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	alertmanagerv1alpha1 "github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("DerivedMetric", func() {
+	newDerivedMetric := func(query string, exportedParams ...string) *alertmanagerv1alpha1.WavefrontDerivedMetric {
+		return &alertmanagerv1alpha1.WavefrontDerivedMetric{
+			ObjectMeta: metav1.ObjectMeta{Name: "error-ratio", Namespace: "team-a"},
+			Spec: alertmanagerv1alpha1.WavefrontDerivedMetricSpec{
+				DerivedMetricName: "{{ .service }} errors",
+				Query:             query,
+				Minutes:           5,
+				Tags:              []string{"team-a"},
+				ExportedParams:    exportedParams,
+			},
+		}
+	}
+
+	AfterEach(func() {
+		wavefront.Cluster = wavefront.ClusterIdentity{}
+	})
+
+	Context("RenderDerivedMetric", func() {
+		It("renders the template with the params", func() {
+			wfDerivedMetric := newDerivedMetric(`aliasMetric(sum(rate(ts(http.errors, service={{ .service }}))), "derived.{{ .service }}.errors")`, "service")
+			wfDerivedMetric.Spec.IncludeObsoleteMetrics = true
+			var metric wf.DerivedMetric
+			Expect(wavefront.RenderDerivedMetric(context.Background(), wfDerivedMetric, map[string]string{"service": "checkout"}, &metric)).To(BeNil())
+			Expect(metric.Name).To(Equal("checkout errors"))
+			Expect(metric.Query).To(Equal(`aliasMetric(sum(rate(ts(http.errors, service=checkout))), "derived.checkout.errors")`))
+			Expect(metric.Minutes).To(Equal(5))
+			Expect(metric.IncludeObsoleteMetrics).To(BeTrue())
+			Expect(metric.Tags.CustomerTags).To(Equal([]string{"team-a"}))
+			Expect(metric.ID).To(BeNil())
+		})
+		It("fails if a param is missing", func() {
+			wfDerivedMetric := newDerivedMetric("ts(http.errors, service={{ .service }})", "service")
+			var metric wf.DerivedMetric
+			Expect(wavefront.RenderDerivedMetric(context.Background(), wfDerivedMetric, nil, &metric)).NotTo(BeNil())
+		})
+		It("adds the cluster tag", func() {
+			wavefront.Cluster, _ = wavefront.NewClusterIdentity("prod-cluster", "", "")
+			wfDerivedMetric := newDerivedMetric("ts(http.errors)")
+			wfDerivedMetric.Spec.DerivedMetricName = "http errors"
+			var metric wf.DerivedMetric
+			Expect(wavefront.RenderDerivedMetric(context.Background(), wfDerivedMetric, nil, &metric)).To(BeNil())
+			Expect(metric.Tags.CustomerTags).To(ContainElement(wavefront.Cluster.Tag()))
+		})
+	})
+
+	Context("ValidateDerivedMetricInput", func() {
+		It("invalid query", func() {
+			metric := wf.DerivedMetric{Name: "http errors", Query: "ts(http.errors", Minutes: 5}
+			err := wavefront.ValidateDerivedMetricInput(context.Background(), &metric)
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("invalid query"))
+		})
+		It("missing minutes", func() {
+			metric := wf.DerivedMetric{Name: "http errors", Query: "ts(http.errors)"}
+			Expect(wavefront.ValidateDerivedMetricInput(context.Background(), &metric)).NotTo(BeNil())
+		})
+		It("missing name", func() {
+			metric := wf.DerivedMetric{Query: "ts(http.errors)", Minutes: 5}
+			Expect(wavefront.ValidateDerivedMetricInput(context.Background(), &metric)).NotTo(BeNil())
+		})
+	})
+})
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wavefront
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	"github.com/keikoproj/alert-manager/internal/utils"
+)
+
+const (
+	// maxDiffValueLength keeps the diff compact enough to be stored in the status
+	maxDiffValueLength = 64
+)
+
+// PayloadHash function returns the checksum of the rendered alert payload.
+// Alert ID is not part of the hash so the same payload gives the same hash for both create and update
+func PayloadHash(ctx context.Context, alert *wf.Alert) string {
+	payload := *alert
+	payload.ID = nil
+	data, _ := json.Marshal(payload)
+	return utils.CalculateChecksum(ctx, string(data))
+}
+
+// DiffAlerts function returns the difference between the live alert and the rendered alert in "field: old -> new" format.
+// Only the fields managed by the alert spec are compared
+func DiffAlerts(live *wf.Alert, desired *wf.Alert) []string {
+	var diff []string
+	add := func(field string, old interface{}, new interface{}) {
+		oldValue, newValue := fmt.Sprint(old), fmt.Sprint(new)
+		if oldValue != newValue {
+			diff = append(diff, fmt.Sprintf("%s: %s -> %s", field, truncate(oldValue), truncate(newValue)))
+		}
+	}
+	add("name", live.Name, desired.Name)
+	add("alertType", live.AlertType, desired.AlertType)
+	add("condition", live.Condition, desired.Condition)
+	add("displayExpression", live.DisplayExpression, desired.DisplayExpression)
+	// wavefront returns the severity in upper case
+	add("severity", strings.ToUpper(live.Severity), strings.ToUpper(desired.Severity))
+	add("minutes", live.Minutes, desired.Minutes)
+	add("resolveAfterMinutes", live.ResolveAfterMinutes, desired.ResolveAfterMinutes)
+	add("target", live.Target, desired.Target)
+	add("additionalInformation", live.AdditionalInfo, desired.AdditionalInfo)
+	add("tags", sortedTags(live.Tags), sortedTags(desired.Tags))
+	if desired.CheckingFrequencyInMinutes != 0 {
+		add("alertCheckFrequency", live.CheckingFrequencyInMinutes, desired.CheckingFrequencyInMinutes)
+	}
+	return diff
+}
+
+// sortedTags returns a sorted copy of the tags since wavefront doesn't preserve the order
+func sortedTags(tags []string) []string {
+	sorted := append([]string{}, tags...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// truncate shortens long values such as conditions
+func truncate(value string) string {
+	if len(value) <= maxDiffValueLength {
+		return value
+	}
+	return value[:maxDiffValueLength] + "..."
+}
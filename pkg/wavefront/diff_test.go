@@ -0,0 +1,86 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wavefront_test
+
+import (
+	"context"
+	"strings"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Diff", func() {
+	newAlert := func() *wf.Alert {
+		return &wf.Alert{
+			Name:                "test-alert",
+			AlertType:           "CLASSIC",
+			Condition:           "ts(status.health) > 1",
+			DisplayExpression:   "ts(status.health)",
+			Severity:            "warn",
+			Minutes:             5,
+			ResolveAfterMinutes: 5,
+			Tags:                []string{"b", "a"},
+		}
+	}
+
+	Context("DiffAlerts", func() {
+		It("no difference", func() {
+			live := newAlert()
+			live.Severity = "WARN"
+			live.Tags = []string{"a", "b"}
+			Expect(wavefront.DiffAlerts(live, newAlert())).To(BeEmpty())
+		})
+
+		It("changed fields", func() {
+			desired := newAlert()
+			desired.Minutes = 10
+			desired.Tags = []string{"a", "c"}
+			Expect(wavefront.DiffAlerts(newAlert(), desired)).To(Equal([]string{
+				"minutes: 5 -> 10",
+				"tags: [a b] -> [a c]",
+			}))
+		})
+
+		It("long values are truncated", func() {
+			desired := newAlert()
+			desired.Condition = strings.Repeat("x", 100)
+			diff := wavefront.DiffAlerts(newAlert(), desired)
+			Expect(diff).To(HaveLen(1))
+			Expect(diff[0]).To(HaveSuffix("..."))
+		})
+	})
+
+	Context("PayloadHash", func() {
+		It("ignores the alert id", func() {
+			ctx := context.Background()
+			id := "1234"
+			withID := newAlert()
+			withID.ID = &id
+			Expect(wavefront.PayloadHash(ctx, withID)).To(Equal(wavefront.PayloadHash(ctx, newAlert())))
+		})
+
+		It("changes with the payload", func() {
+			ctx := context.Background()
+			changed := newAlert()
+			changed.Minutes = 10
+			Expect(wavefront.PayloadHash(ctx, changed)).NotTo(Equal(wavefront.PayloadHash(ctx, newAlert())))
+		})
+	})
+})
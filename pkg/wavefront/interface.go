@@ -22,11 +22,26 @@ import (
 	wf "github.com/WavefrontHQ/go-wavefront-management-api"
 )
 
-// Interface defining Alert CRUD operations
+// Interface defining Alert, Dashboard and DerivedMetric CRUD operations
 
 type Interface interface {
 	CreateAlert(ctx context.Context, input *wf.Alert) error
 	ReadAlert(ctx context.Context, alertID string) (output *wf.Alert, err error)
-	UpdateAlert(ctx context.Context, input *wf.Alert) error
+	// UpdateAlert returns the difference between the live alert and the input. Update is skipped if there is no difference
+	UpdateAlert(ctx context.Context, input *wf.Alert) (diff []string, err error)
 	DeleteAlert(ctx context.Context, alertID string) error
+	// SnoozeAlert snoozes the alert indefinitely instead of deleting it
+	SnoozeAlert(ctx context.Context, alertID string) error
+	// FindAlertsByTag returns all the alerts having the tag
+	FindAlertsByTag(ctx context.Context, tag string) ([]*wf.Alert, error)
+	// CreateDashboard creates the dashboard with the id given in its url
+	CreateDashboard(ctx context.Context, input *wf.Dashboard) error
+	ReadDashboard(ctx context.Context, dashboardID string) (output *wf.Dashboard, err error)
+	UpdateDashboard(ctx context.Context, input *wf.Dashboard) error
+	DeleteDashboard(ctx context.Context, dashboardID string) error
+	// CreateDerivedMetric creates the derived metric and sets the id assigned by wavefront in the input
+	CreateDerivedMetric(ctx context.Context, input *wf.DerivedMetric) error
+	ReadDerivedMetric(ctx context.Context, derivedMetricID string) (output *wf.DerivedMetric, err error)
+	UpdateDerivedMetric(ctx context.Context, input *wf.DerivedMetric) error
+	DeleteDerivedMetric(ctx context.Context, derivedMetricID string) error
 }
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wavefront
+
+import (
+	"strings"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+)
+
+const (
+	// OwnershipTagPrefix is the prefix of the tags identifying the resource which created the alert
+	OwnershipTagPrefix = "alert-manager."
+
+	ownerClusterTag   = OwnershipTagPrefix + "cluster."
+	ownerNamespaceTag = OwnershipTagPrefix + "namespace."
+	ownerKindTag      = OwnershipTagPrefix + "kind."
+	ownerNameTag      = OwnershipTagPrefix + "name."
+	ownerInstanceTag  = OwnershipTagPrefix + "instance."
+)
+
+// Owner identifies the resource which created the alert
+type Owner struct {
+	ClusterID string
+	Namespace string
+	Kind      string
+	Name      string
+	// Instance is the key of the alert in the owner status. For ex: template name for alerts config
+	Instance string
+}
+
+// Tags function returns the ownership tags of the owner
+func (o Owner) Tags() []string {
+	return []string{
+		ownerClusterTag + o.ClusterID,
+		ownerNamespaceTag + o.Namespace,
+		ownerKindTag + o.Kind,
+		ownerNameTag + o.Name,
+		ownerInstanceTag + o.Instance,
+	}
+}
+
+// ClusterTag function returns the ownership tag shared by all the alerts created from the cluster
+func ClusterTag(clusterID string) string {
+	return ownerClusterTag + clusterID
+}
+
+// AddOwnershipTags function replaces any ownership tags of the alert with the tags of the owner.
+// Nothing is added if the cluster id is not configured since the owner can't be identified across clusters
+func AddOwnershipTags(alert *wf.Alert, owner Owner) {
+	if owner.ClusterID == "" {
+		return
+	}
+	RemoveOwnershipTags(alert)
+	alert.Tags = append(alert.Tags, owner.Tags()...)
+}
+
+// RemoveOwnershipTags function removes the ownership tags from the alert. Returns true if any tag got removed
+func RemoveOwnershipTags(alert *wf.Alert) bool {
+	tags := make([]string, 0, len(alert.Tags))
+	for _, tag := range alert.Tags {
+		if !strings.HasPrefix(tag, OwnershipTagPrefix) {
+			tags = append(tags, tag)
+		}
+	}
+	removed := len(tags) != len(alert.Tags)
+	alert.Tags = tags
+	return removed
+}
+
+// ParseOwner function returns the owner from the ownership tags. Returns false if any of the tags is missing
+func ParseOwner(tags []string) (Owner, bool) {
+	var owner Owner
+	fields := map[string]*string{
+		ownerClusterTag:   &owner.ClusterID,
+		ownerNamespaceTag: &owner.Namespace,
+		ownerKindTag:      &owner.Kind,
+		ownerNameTag:      &owner.Name,
+		ownerInstanceTag:  &owner.Instance,
+	}
+	for _, tag := range tags {
+		for prefix, field := range fields {
+			if value, ok := strings.CutPrefix(tag, prefix); ok {
+				*field = value
+			}
+		}
+	}
+	for _, field := range fields {
+		if *field == "" {
+			return owner, false
+		}
+	}
+	return owner, true
+}
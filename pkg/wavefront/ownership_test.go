@@ -0,0 +1,70 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wavefront_test
+
+import (
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	"github.com/keikoproj/alert-manager/pkg/wavefront"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Ownership", func() {
+	owner := wavefront.Owner{
+		ClusterID: "prod-cluster",
+		Namespace: "team-a",
+		Kind:      "AlertsConfig",
+		Name:      "my.app",
+		Instance:  "cpu-alert",
+	}
+
+	Context("AddOwnershipTags", func() {
+		It("replaces the existing ownership tags", func() {
+			alert := &wf.Alert{Tags: []string{"team-a", "alert-manager.name.old"}}
+			wavefront.AddOwnershipTags(alert, owner)
+			Expect(alert.Tags).To(ConsistOf(append([]string{"team-a"}, owner.Tags()...)))
+		})
+
+		It("doesn't add the tags without cluster id", func() {
+			alert := &wf.Alert{Tags: []string{"team-a"}}
+			wavefront.AddOwnershipTags(alert, wavefront.Owner{Namespace: "team-a"})
+			Expect(alert.Tags).To(Equal([]string{"team-a"}))
+		})
+	})
+
+	Context("RemoveOwnershipTags", func() {
+		It("removes only the ownership tags", func() {
+			alert := &wf.Alert{Tags: append([]string{"team-a"}, owner.Tags()...)}
+			Expect(wavefront.RemoveOwnershipTags(alert)).To(BeTrue())
+			Expect(alert.Tags).To(Equal([]string{"team-a"}))
+			Expect(wavefront.RemoveOwnershipTags(alert)).To(BeFalse())
+		})
+	})
+
+	Context("ParseOwner", func() {
+		It("parses the owner from the tags", func() {
+			parsed, ok := wavefront.ParseOwner(append([]string{"team-a"}, owner.Tags()...))
+			Expect(ok).To(BeTrue())
+			Expect(parsed).To(Equal(owner))
+		})
+
+		It("fails if a tag is missing", func() {
+			_, ok := wavefront.ParseOwner(owner.Tags()[:4])
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,452 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wavefront
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// QueryError is an error found in a wavefront query expression
+type QueryError struct {
+	//Pos is the position of the error in the query, starting at 1
+	Pos int
+	//Msg describes the error
+	Msg string
+}
+
+func (e QueryError) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Pos, e.Msg)
+}
+
+// QueryErrors are the errors found in a wavefront query expression
+type QueryErrors []QueryError
+
+func (e QueryErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// arity is the number of arguments a query function accepts. max -1 means any number of arguments
+type arity struct {
+	min int
+	max int
+}
+
+// queryFunctions are the wavefront query language functions known by the linter with their number of arguments.
+// Aggregation functions accept any number of grouping arguments after the expression.
+// Wavefront has more functions than listed here, so the other functions are only reported as warnings
+var queryFunctions = map[string]arity{
+	// data
+	"ts": {1, -1}, "hs": {1, -1}, "events": {1, -1}, "spans": {1, -1}, "traces": {1, -1},
+	// aggregation
+	"sum": {1, -1}, "avg": {1, -1}, "min": {1, -1}, "max": {1, -1}, "count": {1, -1}, "median": {1, -1},
+	"variance": {1, -1}, "last": {1, -1}, "first": {1, -1}, "percentile": {2, -1},
+	"rawsum": {1, -1}, "rawavg": {1, -1}, "rawmin": {1, -1}, "rawmax": {1, -1}, "rawcount": {1, -1},
+	"rawvariance": {1, -1}, "rawmedian": {1, -1}, "rawpercentile": {2, -1},
+	// moving window
+	"mavg": {2, 2}, "mmedian": {2, 2}, "msum": {2, 2}, "mmin": {2, 2}, "mmax": {2, 2}, "mcount": {2, 2},
+	"mvar": {2, 2}, "mpercentile": {3, 3}, "flapping": {2, 2}, "missing": {2, 2},
+	// time
+	"align": {2, 3}, "lag": {2, 2}, "lead": {2, 2}, "at": {2, 2}, "hideBefore": {2, 2}, "hideAfter": {2, 2},
+	"downsample": {2, 2}, "time": {0, 0}, "timestamp": {1, 1},
+	// rate
+	"rate": {1, 1}, "deriv": {1, 1}, "ratediff": {1, 1}, "integral": {1, 1},
+	// filtering
+	"highpass": {2, 3}, "lowpass": {2, 3}, "top": {2, 2}, "bottom": {2, 2}, "topk": {2, 2}, "bottomk": {2, 2},
+	"limit": {2, 3}, "retainSeries": {2, -1}, "removeSeries": {2, -1}, "between": {3, 3},
+	// math
+	"abs": {1, 1}, "ceil": {1, 1}, "floor": {1, 1}, "round": {1, 1}, "sqrt": {1, 1}, "log": {1, 1}, "log10": {1, 1},
+	"exp": {1, 1}, "pow": {2, 2}, "sin": {1, 1}, "cos": {1, 1}, "tan": {1, 1}, "normalize": {1, 1}, "random": {0, 0},
+	// missing data and conditionals
+	"default": {2, 3}, "interpolate": {1, 1}, "exists": {1, 1}, "if": {2, 3}, "bestEffort": {1, 1}, "collect": {1, -1},
+	// metadata
+	"aliasMetric": {2, 3}, "aliasSource": {2, 3}, "taggify": {3, -1},
+}
+
+// comparisonOperators are the comparison operators of the wavefront query language
+var comparisonOperators = map[string]bool{"=": true, "!=": true, ">": true, "<": true, ">=": true, "<=": true}
+
+// comparisonSuggestions are the operators from other languages wavefront doesn't support
+var comparisonSuggestions = map[string]string{"==": "=", "=>": ">=", "=<": "<=", "<>": "!="}
+
+// templateControl matches go lang template actions which change the shape of the query, for ex: if, range
+var templateControl = regexp.MustCompile(`\{\{-?\s*(if|else|end|range|with|define|block|template)\b`)
+
+// numberLiteral matches a number or a duration for ex: 80, 0.5, 5m, 1e6
+var numberLiteral = regexp.MustCompile(`^([0-9]+\.?[0-9]*|\.[0-9]+)([eE][-+]?[0-9]+)?[a-zA-Z]?$`)
+
+// LintQuery function parses the wavefront query expression offline and returns the syntax errors found with their positions.
+// It checks balanced parentheses, comparison operators and the number of arguments of the known functions.
+// Go lang templates are treated as opaque operands so templates can be linted before rendering.
+// Query with template actions such as if or range can only be linted after rendering and is skipped
+func LintQuery(query string) QueryErrors {
+	errs, _ := LintQueryWithWarnings(query)
+	return errs
+}
+
+// LintQueryWithWarnings function returns the syntax errors of the query same as LintQuery and the warnings.
+// Functions unknown to the linter are warnings since wavefront may still accept them
+func LintQueryWithWarnings(query string) (QueryErrors, QueryErrors) {
+	if strings.TrimSpace(query) == "" {
+		return QueryErrors{{Pos: 1, Msg: "query must not be empty"}}, nil
+	}
+	if templateControl.MatchString(query) {
+		return nil, nil
+	}
+	tokens, err := lexQuery(query)
+	if err != nil {
+		return QueryErrors{*err}, nil
+	}
+	p := &queryParser{tokens: tokens}
+	if p.parseExpr() && p.peek().kind != tokenEOF {
+		p.unexpected(p.peek())
+	}
+	return p.errs, p.warnings
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	// tokenWord is a metric name, tag, function name or keyword. It can contain go lang templates
+	tokenWord
+	// tokenNumber is a number or a duration
+	tokenNumber
+	tokenString
+	tokenLParen
+	tokenRParen
+	tokenComma
+	// tokenOperator is an arithmetic operator
+	tokenOperator
+	tokenComparison
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	// pos of the token in the query, starting at 1
+	pos int
+	// template is true if the word contains a go lang template
+	template bool
+}
+
+// isWordChar returns true for the characters of metric names, tags and wildcards
+func isWordChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		strings.IndexByte("_.-*~:#", c) >= 0
+}
+
+// operandExpected returns true if the next token must be an operand, for ex: * is a wildcard rather than a multiplication
+func operandExpected(tokens []token) bool {
+	if len(tokens) == 0 {
+		return true
+	}
+	last := tokens[len(tokens)-1]
+	switch last.kind {
+	case tokenLParen, tokenComma, tokenOperator, tokenComparison:
+		return true
+	}
+	return keyword(last) != ""
+}
+
+// lexQuery function splits the query into tokens. Unterminated strings, templates and unknown operators are reported
+func lexQuery(query string) ([]token, *QueryError) {
+	var tokens []token
+	for i := 0; i < len(query); {
+		c := query[i]
+		start := i
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: start + 1})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: start + 1})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: start + 1})
+			i++
+		case c == '"' || c == '\'':
+			i++
+			for i < len(query) && query[i] != c {
+				if query[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			if i >= len(query) {
+				return nil, &QueryError{Pos: start + 1, Msg: "unterminated string"}
+			}
+			i++
+			tokens = append(tokens, token{kind: tokenString, text: query[start:i], pos: start + 1})
+		case strings.IndexByte("+-/%", c) >= 0 || c == '*' && !operandExpected(tokens):
+			tokens = append(tokens, token{kind: tokenOperator, text: string(c), pos: start + 1})
+			i++
+		case strings.IndexByte("<>=!", c) >= 0:
+			for i < len(query) && strings.IndexByte("<>=!", query[i]) >= 0 {
+				i++
+			}
+			op := query[start:i]
+			if !comparisonOperators[op] {
+				msg := fmt.Sprintf("unknown comparison operator %q", op)
+				if suggestion, ok := comparisonSuggestions[op]; ok {
+					msg += fmt.Sprintf(", use %q instead", suggestion)
+				}
+				return nil, &QueryError{Pos: start + 1, Msg: msg}
+			}
+			tokens = append(tokens, token{kind: tokenComparison, text: op, pos: start + 1})
+		case c >= '0' && c <= '9' || c == '.':
+			for i < len(query) && (isWordChar(query[i]) && query[i] != '-' && query[i] != '*' || query[i] == '{' && strings.HasPrefix(query[i:], "{{")) {
+				if query[i] == '{' {
+					end := strings.Index(query[i:], "}}")
+					if end < 0 {
+						return nil, &QueryError{Pos: i + 1, Msg: "unterminated template"}
+					}
+					i += end + 2
+					continue
+				}
+				i++
+			}
+			text := query[start:i]
+			kind := tokenWord
+			if numberLiteral.MatchString(text) {
+				kind = tokenNumber
+			}
+			tokens = append(tokens, token{kind: kind, text: text, pos: start + 1, template: strings.Contains(text, "{{")})
+		case isWordChar(c) || strings.HasPrefix(query[i:], "{{"):
+			template := false
+			for i < len(query) {
+				if strings.HasPrefix(query[i:], "{{") {
+					end := strings.Index(query[i:], "}}")
+					if end < 0 {
+						return nil, &QueryError{Pos: i + 1, Msg: "unterminated template"}
+					}
+					template = true
+					i += end + 2
+					continue
+				}
+				if !isWordChar(query[i]) {
+					break
+				}
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: query[start:i], pos: start + 1, template: template})
+		default:
+			return nil, &QueryError{Pos: start + 1, Msg: fmt.Sprintf("unexpected character %q", c)}
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(query) + 1}), nil
+}
+
+// queryParser is a recursive descent parser of the wavefront query language.
+// Parsing stops at the first syntax error, wrong number of arguments are all reported and unknown functions are warnings
+type queryParser struct {
+	tokens   []token
+	next     int
+	errs     QueryErrors
+	warnings QueryErrors
+}
+
+func (p *queryParser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *queryParser) advance() token {
+	t := p.tokens[p.next]
+	if t.kind != tokenEOF {
+		p.next++
+	}
+	return t
+}
+
+func (p *queryParser) errorf(pos int, format string, args ...interface{}) {
+	p.errs = append(p.errs, QueryError{Pos: pos, Msg: fmt.Sprintf(format, args...)})
+}
+
+func (p *queryParser) unexpected(t token) {
+	switch t.kind {
+	case tokenEOF:
+		p.errorf(t.pos, "unexpected end of the query")
+	case tokenRParen:
+		p.errorf(t.pos, "unexpected ) without a matching (")
+	default:
+		p.errorf(t.pos, "unexpected %q", t.text)
+	}
+}
+
+// keyword returns the lower case keyword if the token is and, or or not
+func keyword(t token) string {
+	if t.kind != tokenWord {
+		return ""
+	}
+	switch k := strings.ToLower(t.text); k {
+	case "and", "or", "not":
+		return k
+	}
+	return ""
+}
+
+// parseExpr parses the boolean expressions. Filters such as app=foo and env=bar are parsed the same way
+func (p *queryParser) parseExpr() bool {
+	if !p.parseNot() {
+		return false
+	}
+	for k := keyword(p.peek()); k == "and" || k == "or"; k = keyword(p.peek()) {
+		p.advance()
+		if !p.parseNot() {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *queryParser) parseNot() bool {
+	if keyword(p.peek()) == "not" {
+		p.advance()
+		return p.parseNot()
+	}
+	return p.parseComparison()
+}
+
+func (p *queryParser) parseComparison() bool {
+	if !p.parseArithmetic() {
+		return false
+	}
+	for p.peek().kind == tokenComparison {
+		op := p.advance()
+		if p.peek().kind == tokenEOF {
+			p.errorf(op.pos, "comparison %s has no right operand", op.text)
+			return false
+		}
+		if !p.parseArithmetic() {
+			return false
+		}
+	}
+	return true
+}
+
+// parseArithmetic parses the arithmetic operators. Precedence doesn't matter for linting
+func (p *queryParser) parseArithmetic() bool {
+	if !p.parseUnary() {
+		return false
+	}
+	for p.peek().kind == tokenOperator {
+		op := p.advance()
+		if p.peek().kind == tokenEOF {
+			p.errorf(op.pos, "operator %s has no right operand", op.text)
+			return false
+		}
+		if !p.parseUnary() {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *queryParser) parseUnary() bool {
+	if t := p.peek(); t.kind == tokenOperator && (t.text == "-" || t.text == "+") {
+		p.advance()
+	}
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() bool {
+	t := p.peek()
+	switch t.kind {
+	case tokenNumber, tokenString:
+		p.advance()
+		return true
+	case tokenWord:
+		if keyword(t) != "" {
+			p.unexpected(t)
+			return false
+		}
+		p.advance()
+		if p.peek().kind == tokenLParen {
+			return p.parseCall(t)
+		}
+		return true
+	case tokenLParen:
+		p.advance()
+		if !p.parseExpr() {
+			return false
+		}
+		if p.peek().kind != tokenRParen {
+			p.errorf(t.pos, "missing ) for this (")
+			return false
+		}
+		p.advance()
+		return true
+	}
+	p.unexpected(t)
+	return false
+}
+
+// parseCall parses the function arguments and checks the number of arguments of the known functions
+func (p *queryParser) parseCall(name token) bool {
+	open := p.advance()
+	args := 0
+	if p.peek().kind != tokenRParen {
+		for {
+			if !p.parseExpr() {
+				return false
+			}
+			args++
+			// filters can be listed without and, for ex: ts(cpu, env=prod app=foo)
+			for t := p.peek(); t.kind == tokenWord || t.kind == tokenString; t = p.peek() {
+				if !p.parseExpr() {
+					return false
+				}
+			}
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.advance()
+		}
+	}
+	if p.peek().kind != tokenRParen {
+		if p.peek().kind == tokenEOF {
+			p.errorf(open.pos, "missing ) for %s(", name.text)
+		} else {
+			p.errorf(p.peek().pos, "expected , or ) in %s( but got %q", name.text, p.peek().text)
+		}
+		return false
+	}
+	p.advance()
+
+	// function name rendered from a template can only be checked after rendering
+	if name.template {
+		return true
+	}
+	a, ok := queryFunctions[name.text]
+	switch {
+	case !ok:
+		p.warnings = append(p.warnings, QueryError{Pos: name.pos, Msg: fmt.Sprintf("unknown function %s", name.text)})
+	case args < a.min:
+		p.errorf(name.pos, "function %s expects at least %d argument(s) but got %d", name.text, a.min, args)
+	case a.max >= 0 && args > a.max:
+		p.errorf(name.pos, "function %s expects at most %d argument(s) but got %d", name.text, a.max, args)
+	}
+	return true
+}
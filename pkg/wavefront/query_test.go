@@ -0,0 +1,105 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wavefront_test
+
+import (
+	"testing"
+
+	wf "github.com/keikoproj/alert-manager/pkg/wavefront"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintQuery_Valid(t *testing.T) {
+	for _, query := range []string{
+		"ts(status.health)",
+		"ts(cpu) > 80",
+		`ts("kubernetes.node.cpu.usage") > 80`,
+		"100 - (ts(api.success.count) / (ts(api.success.count) + ts(api.error.count)) * 100) > 5",
+		"ts(kubernetes.pod.cpu.usage_rate, namespace=team-a and app=checkout*) >= 0.5",
+		"ts(cpu.*, source=app-1 or not env=prod)",
+		"mavg(5m, rate(ts(requests))) * 2",
+		"sum(ts(cpu), sources) / count(ts(cpu), sources) <= 1e3",
+		"percentile(95, ts(latency), app)",
+		"-ts(a) != 0",
+		"ts(cpu, app={{ .app }}) > {{ .threshold }}",
+		"{{ .condition }}",
+		"ts({{ .zzz }}) * 2",
+		"{{ .fn }}(ts(a), 1, 2)",
+		"{{ if .x }}ts(a) > 1{{ else }}bad((({{ end }}",
+		"time() > 0",
+	} {
+		assert.Empty(t, wf.LintQuery(query), query)
+	}
+}
+
+func TestLintQuery_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		query string
+		pos   int
+		msg   string
+	}{
+		{query: "", pos: 1, msg: "query must not be empty"},
+		{query: "ts(cpu > 80", pos: 3, msg: "missing ) for ts("},
+		{query: "(ts(cpu) > 80", pos: 1, msg: "missing ) for this ("},
+		{query: "ts(cpu)) > 80", pos: 8, msg: "unexpected ) without a matching ("},
+		{query: "mavg(ts(cpu)) > 80", pos: 1, msg: "function mavg expects at least 2 argument(s) but got 1"},
+		{query: "rate(ts(a), ts(b))", pos: 1, msg: "function rate expects at most 1 argument(s) but got 2"},
+		{query: "ts(cpu) == 80", pos: 9, msg: `unknown comparison operator "==", use "=" instead`},
+		{query: "ts(cpu) => 80", pos: 9, msg: `unknown comparison operator "=>", use ">=" instead`},
+		{query: "ts(cpu) >", pos: 9, msg: "comparison > has no right operand"},
+		{query: "ts(cpu) >> 1", pos: 9, msg: `unknown comparison operator ">>"`},
+		{query: "ts(cpu) > > 1", pos: 11, msg: `unexpected ">"`},
+		{query: `ts("cpu) > 80`, pos: 4, msg: "unterminated string"},
+		{query: "ts(cpu, app={{ .app ) > 1", pos: 13, msg: "unterminated template"},
+		{query: "ts(cpu) > 80 and", pos: 17, msg: "unexpected end of the query"},
+		{query: "ts(cpu) > ${threshold}", pos: 11, msg: `unexpected character '$'`},
+		{query: "ts(cpu, a b;)", pos: 12, msg: `unexpected character ';'`},
+	} {
+		errs := wf.LintQuery(tc.query)
+		if assert.NotEmpty(t, errs, tc.query) {
+			assert.Equal(t, tc.pos, errs[0].Pos, tc.query)
+			assert.Equal(t, tc.msg, errs[0].Msg, tc.query)
+		}
+	}
+}
+
+func TestLintQuery_MultipleErrors(t *testing.T) {
+	errs := wf.LintQuery("ts(cpu) + mavg(ts(cpu)) + rate()")
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "position 11: function mavg expects at least 2 argument(s) but got 1; "+
+		"position 27: function rate expects at least 1 argument(s) but got 0", errs.Error())
+}
+
+func TestLintQueryWithWarnings_UnknownFunction(t *testing.T) {
+	for _, query := range []string{
+		"nonNegativeDerivative(ts(requests)) > 0",
+		"anomalous(7d, 0.99, ts(cpu))",
+		"mseriescount(5m, ts(cpu)) < 3",
+	} {
+		errs, warnings := wf.LintQueryWithWarnings(query)
+		assert.Empty(t, errs, query)
+		if assert.Len(t, warnings, 1, query) {
+			assert.Equal(t, 1, warnings[0].Pos, query)
+			assert.Contains(t, warnings[0].Msg, "unknown function", query)
+		}
+	}
+
+	// syntax errors are still reported along with the warnings
+	errs, warnings := wf.LintQueryWithWarnings("tss(cpu > 80")
+	assert.Equal(t, "position 4: missing ) for tss(", errs.Error())
+	assert.Empty(t, warnings)
+}
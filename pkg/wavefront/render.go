@@ -0,0 +1,90 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wavefront
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	wf "github.com/WavefrontHQ/go-wavefront-management-api"
+	"github.com/keikoproj/alert-manager/api/v1alpha1"
+	"github.com/keikoproj/alert-manager/internal/template"
+	"github.com/keikoproj/alert-manager/internal/utils"
+	"github.com/keikoproj/alert-manager/pkg/log"
+)
+
+// RenderAlert function processes the alert template with the params and converts it to wavefront api request.
+// Template spec is replaced with the rendered spec. It doesn't depend on the controller config so the same rendering can be used outside the controllers
+func RenderAlert(ctx context.Context, wfAlert *v1alpha1.WavefrontAlert, params map[string]string, alert *wf.Alert) error {
+	log := log.Logger(ctx, "pkg.wavefront", "RenderAlert")
+	log = log.WithValues("alertsConfig_cr", wfAlert.Name)
+
+	wfAlertBytes, err := json.Marshal(wfAlert.Spec)
+	if err != nil {
+		// update the status and retry it
+		return err
+	}
+
+	//standalone alert
+	if len(wfAlert.Spec.ExportedParams) == 0 {
+		errMsg := "cannot use standalone alert with alertsconfig. must have exportedParams in wavefrontalert cr"
+		err := errors.New(errMsg)
+		log.Error(err, errMsg)
+		return err
+	}
+
+	// merge wavefront alert default values and alert config map values
+	params = utils.MergeMaps(ctx, wfAlert.Spec.ExportedParamsDefaultValues, params)
+	if err := ValidateParamsSchema(ctx, wfAlert.Spec.ExportedParams, wfAlert.Spec.ExportedParamsSchema, params); err != nil {
+		return fmt.Errorf("template %s: %w", wfAlert.Name, err)
+	}
+	// built-in variables
+	if Cluster.ID != "" {
+		params[ClusterIDParam] = Cluster.ID
+	}
+
+	// execute Golang Template
+	wfAlertTemplate, err := template.ProcessJSONTemplate(ctx, wfAlertBytes, params)
+	if err != nil {
+		//update the status and retry it
+		return err
+	}
+	log.Info("Template process is successful", "here", string(wfAlertTemplate))
+
+	// Unmarshal back to wavefront alert
+	// unmarshal reuses the tags slice, so the templates are copied
+	tagTemplates := append([]string(nil), wfAlert.Spec.Tags...)
+	if err := json.Unmarshal(wfAlertTemplate, &wfAlert.Spec); err != nil {
+		// update the wfAlert status and retry it
+		return err
+	}
+	wfAlert.Spec.Tags = splitTemplatedTags(tagTemplates, wfAlert.Spec.Tags)
+	// Convert to Alert
+	if err := ConvertAlertCRToWavefrontRequest(ctx, wfAlert.Spec, alert); err != nil {
+		errMsg := "unable to convert the wavefront spec to Alert API request. will not be retried"
+		log.Error(err, errMsg)
+		return err
+	}
+
+	// Validate the alert- just make sure severity and other required fields are properly replaced/substituted
+	if err := ValidateAlertInput(ctx, alert); err != nil {
+		return err
+	}
+	return nil
+}
@@ -20,13 +20,25 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/WavefrontHQ/go-wavefront-management-api"
+	"github.com/keikoproj/alert-manager/api/v1alpha1"
 	"github.com/keikoproj/alert-manager/internal/utils"
 	"github.com/keikoproj/alert-manager/pkg/log"
+	"github.com/keikoproj/alert-manager/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // ValidateAlertInput validates alert inputs
-func ValidateAlertInput(ctx context.Context, input *wavefront.Alert) error {
+func ValidateAlertInput(ctx context.Context, input *wavefront.Alert) (err error) {
+	ctx, span := tracing.Start(ctx, "validate alert", attribute.String("wavefront.alert.name", input.Name))
+	defer func() { tracing.End(span, err) }()
 	log := log.Logger(ctx, "pkg.wavefront", "validateAlertInput")
 	log.V(1).Info("validating input request")
 
@@ -43,6 +55,12 @@ func ValidateAlertInput(ctx context.Context, input *wavefront.Alert) error {
 		return err
 	}
 
+	if errs := lintQuery(ctx, "displayExpression", input.DisplayExpression); len(errs) > 0 {
+		err := fmt.Errorf("validation failed: invalid displayExpression: %w", errs)
+		log.Error(err, "validation failed: invalid displayExpression")
+		return err
+	}
+
 	// Validate Minutes and ResolveAfterMinutes
 	if input.Minutes <= 0 {
 		err := errors.New("validation failed: minutes must be greater than 0")
@@ -73,6 +91,13 @@ func validateAlertConditions(ctx context.Context, input *wavefront.Alert) error
 				log.Error(err, "validation failed: invalid severity mentioned in conditions")
 				return err
 			}
+			for severity, condition := range input.Conditions {
+				if errs := lintQuery(ctx, severity+" condition", condition); len(errs) > 0 {
+					err := fmt.Errorf("validation failed: invalid %s condition: %w", severity, errs)
+					log.Error(err, "validation failed: invalid condition")
+					return err
+				}
+			}
 		} else {
 			err := errors.New("validation failed: conditions must not be empty")
 			log.Error(err, "validation failed: conditions must not be empty")
@@ -85,6 +110,11 @@ func validateAlertConditions(ctx context.Context, input *wavefront.Alert) error
 			log.Error(err, "validation failed: condition must not be empty")
 			return err
 		}
+		if errs := lintQuery(ctx, "condition", input.Condition); len(errs) > 0 {
+			err := fmt.Errorf("validation failed: invalid condition: %w", errs)
+			log.Error(err, "validation failed: invalid condition")
+			return err
+		}
 
 		if input.Severity != "" {
 			if err := validateSeverity(ctx, input.Severity); err != nil {
@@ -144,3 +174,87 @@ func ValidateTemplateParams(ctx context.Context, exportParams []string, configVa
 	}
 	return nil
 }
+
+// ValidateParamsSchema function validates the config values against the exported params schema.
+// Params in exportParams are required unless the schema says otherwise. Params without schema are treated as string.
+// This can be used by the reconcilers and admission webhooks to validate alerts config values before rendering the template
+func ValidateParamsSchema(ctx context.Context, exportParams []string, schema map[string]v1alpha1.ParamSchema, configValues map[string]string) error {
+	log := log.Logger(ctx, "pkg.wavefront", "ValidateParamsSchema")
+	log.V(1).Info("validating config params with exported params schema")
+	for _, param := range exportParams {
+		paramSchema := schema[param]
+		if _, ok := configValues[param]; !ok && (paramSchema.Required == nil || *paramSchema.Required) {
+			return fmt.Errorf("Required exported param %s is not supplied. ", param)
+		}
+	}
+	// sorted so the same error is returned for the same values
+	for _, param := range slices.Sorted(maps.Keys(schema)) {
+		paramSchema := schema[param]
+		value, ok := configValues[param]
+		if !ok {
+			if paramSchema.Required != nil && *paramSchema.Required {
+				return fmt.Errorf("Required exported param %s is not supplied. ", param)
+			}
+			continue
+		}
+		if err := validateParamValue(paramSchema, value); err != nil {
+			return fmt.Errorf("invalid value %q for param %s: %w", value, param, err)
+		}
+	}
+	return nil
+}
+
+// validateParamValue validates the value against the param type, pattern and allowed values
+func validateParamValue(schema v1alpha1.ParamSchema, value string) error {
+	values := []string{value}
+	switch schema.Type {
+	case "", v1alpha1.ParamTypeString:
+	case v1alpha1.ParamTypeInt:
+		if _, err := strconv.Atoi(strings.TrimSpace(value)); err != nil {
+			return errors.New("must be an integer")
+		}
+	case v1alpha1.ParamTypeDuration:
+		if _, err := time.ParseDuration(strings.TrimSpace(value)); err != nil {
+			return errors.New("must be a duration for ex: 5m")
+		}
+	case v1alpha1.ParamTypeEnum:
+		if len(schema.AllowedValues) == 0 {
+			return errors.New("allowedValues must be provided in the schema for enum type")
+		}
+	case v1alpha1.ParamTypeList:
+		values = strings.Split(value, ",")
+		for i := range values {
+			values[i] = strings.TrimSpace(values[i])
+		}
+	default:
+		return fmt.Errorf("unsupported type %s in the schema", schema.Type)
+	}
+
+	var re *regexp.Regexp
+	if schema.Pattern != "" {
+		var err error
+		if re, err = regexp.Compile(schema.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern %s in the schema: %w", schema.Pattern, err)
+		}
+	}
+	for _, v := range values {
+		if re != nil && !re.MatchString(v) {
+			return fmt.Errorf("must match the pattern %s", schema.Pattern)
+		}
+		if len(schema.AllowedValues) > 0 && !utils.ContainsString(schema.AllowedValues, v) {
+			return fmt.Errorf("must be one of %s", strings.Join(schema.AllowedValues, ", "))
+		}
+	}
+	return nil
+}
+
+// lintQuery function returns the syntax errors of the query. Warnings such as unknown functions are only logged
+// since wavefront may still accept the query
+func lintQuery(ctx context.Context, field string, query string) QueryErrors {
+	errs, warnings := LintQueryWithWarnings(query)
+	if len(warnings) > 0 {
+		log := log.Logger(ctx, "pkg.wavefront", "lintQuery")
+		log.Info("query may be invalid", "field", field, "warnings", warnings.Error())
+	}
+	return errs
+}
@@ -118,6 +118,33 @@ func TestErrorMessageFormatting(t *testing.T) {
 		assert.Contains(t, err.Error(), "validation failed: invalid alert type: INVALID_TYPE")
 	})
 
+	t.Run("invalid condition error has the position", func(t *testing.T) {
+		input := &wavefront.Alert{
+			Name:                "test-alert",
+			AlertType:           wavefront.AlertTypeClassic,
+			Condition:           "ts(status.health) => 1",
+			DisplayExpression:   "ts(status.health)",
+			Severity:            "warn",
+			Minutes:             5,
+			ResolveAfterMinutes: 5,
+		}
+		err := wf.ValidateAlertInput(ctx, input)
+		assert.Error(t, err)
+		assert.Equal(t, `validation failed: invalid condition: position 19: unknown comparison operator "=>", use ">=" instead`, err.Error())
+	})
+
+	t.Run("invalid display expression error has the position", func(t *testing.T) {
+		input := &wavefront.Alert{
+			Name:                "test-alert",
+			DisplayExpression:   "mavg(ts(status.health)",
+			Minutes:             5,
+			ResolveAfterMinutes: 5,
+		}
+		err := wf.ValidateAlertInput(ctx, input)
+		assert.Error(t, err)
+		assert.Equal(t, "validation failed: invalid displayExpression: position 5: missing ) for mavg(", err.Error())
+	})
+
 	t.Run("missing exported param has correct format", func(t *testing.T) {
 		params := []string{"required_param"}
 		configValues := map[string]string{
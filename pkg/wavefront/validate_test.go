@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api"
+	"github.com/keikoproj/alert-manager/api/v1alpha1"
 	wf "github.com/keikoproj/alert-manager/pkg/wavefront"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -124,5 +125,60 @@ var _ = Describe("Validate", func() {
 				Expect(err).To(BeNil())
 			})
 		})
+
+		Context("ExportParam schema test", func() {
+			optional := false
+			schema := map[string]v1alpha1.ParamSchema{
+				"threshold": {Type: v1alpha1.ParamTypeInt},
+				"window":    {Type: v1alpha1.ParamTypeDuration},
+				"severity":  {Type: v1alpha1.ParamTypeEnum, AllowedValues: []string{"warn", "severe"}},
+				"regions":   {Type: v1alpha1.ParamTypeList, Pattern: "^[a-z]+-[a-z]+-[0-9]$"},
+				"team":      {Required: &optional},
+			}
+			exportParams := []string{"threshold", "window", "severity", "regions", "team"}
+			valid := func() map[string]string {
+				return map[string]string{
+					"threshold": "80",
+					"window":    "5m",
+					"severity":  "warn",
+					"regions":   "us-west-2, us-east-1",
+				}
+			}
+			It("Successful usecase without optional param", func() {
+				err := wf.ValidateParamsSchema(context.Background(), exportParams, schema, valid())
+				Expect(err).To(BeNil())
+			})
+			It("Missing required param", func() {
+				values := valid()
+				delete(values, "threshold")
+				err := wf.ValidateParamsSchema(context.Background(), exportParams, schema, values)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(ContainSubstring("threshold"))
+			})
+			DescribeTable("Invalid values",
+				func(param string, value string) {
+					values := valid()
+					values[param] = value
+					err := wf.ValidateParamsSchema(context.Background(), exportParams, schema, values)
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(ContainSubstring(param))
+				},
+				Entry("int", "threshold", "eighty"),
+				Entry("duration", "window", "5 minutes"),
+				Entry("enum", "severity", "info"),
+				Entry("list item pattern", "regions", "us-west-2,moon"),
+			)
+			It("Returns the error of the first invalid param in order", func() {
+				values := valid()
+				values["window"] = "5 minutes"
+				values["threshold"] = "eighty"
+				values["severity"] = "info"
+				for i := 0; i < 10; i++ {
+					err := wf.ValidateParamsSchema(context.Background(), []string{"threshold", "window", "severity"}, schema, values)
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(ContainSubstring("param severity"))
+				}
+			})
+		})
 	})
 })
@@ -0,0 +1,245 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/keikoproj/alert-manager/pkg/log"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const (
+	defaultTimeout = 30 * time.Second
+	alertsPath     = "alerts"
+)
+
+// ErrNotFound is returned when the endpoint doesn't know the requested alert id
+var ErrNotFound = errors.New("webhook alert not found")
+
+// Endpoint holds the connection details for a single webhook
+type Endpoint struct {
+	URL     string
+	Token   string
+	Timeout time.Duration
+}
+
+// createResponse is the body expected from the endpoint on create
+type createResponse struct {
+	ID string `json:"id"`
+}
+
+type Client struct {
+	client *http.Client
+}
+
+// NewClient returns new client instance for webhook alerts. Requests are traced when tracing is enabled
+func NewClient(ctx context.Context) *Client {
+	return &Client{client: &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}}
+}
+
+// CreateAlert creates an alert by POSTing the payload to <url>/alerts and returns the id from the response
+func (c *Client) CreateAlert(ctx context.Context, endpoint Endpoint, payload []byte) (string, error) {
+	log := log.Logger(ctx, "pkg.webhook", "CreateAlert")
+	log = log.WithValues("url", endpoint.URL)
+	log.V(1).Info("create webhook alert request")
+
+	body, err := c.do(ctx, endpoint, http.MethodPost, "", payload)
+	if err != nil {
+		log.Error(err, "unable to create the alert")
+		return "", err
+	}
+
+	var resp createResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		log.Error(err, "unable to parse create response")
+		return "", fmt.Errorf("invalid create response from webhook: %w", err)
+	}
+	if resp.ID == "" {
+		err := errors.New("webhook create response doesn't contain an id")
+		log.Error(err, "unable to create the alert")
+		return "", err
+	}
+	log.V(1).Info("successfully created alert", "alertID", resp.ID)
+	return resp.ID, nil
+}
+
+// ReadAlert retrieves the alert payload currently stored by the endpoint
+func (c *Client) ReadAlert(ctx context.Context, endpoint Endpoint, id string) ([]byte, error) {
+	log := log.Logger(ctx, "pkg.webhook", "ReadAlert")
+	log = log.WithValues("url", endpoint.URL, "alertID", id)
+	log.V(1).Info("Retrieving alert from webhook")
+
+	body, err := c.do(ctx, endpoint, http.MethodGet, id, nil)
+	if err != nil {
+		if !errors.Is(err, ErrNotFound) {
+			log.Error(err, "unable to retrieve the alert from webhook")
+		}
+		return nil, err
+	}
+	return body, nil
+}
+
+// UpdateAlert replaces the alert by PUTting the payload to <url>/alerts/<id>
+func (c *Client) UpdateAlert(ctx context.Context, endpoint Endpoint, id string, payload []byte) error {
+	log := log.Logger(ctx, "pkg.webhook", "UpdateAlert")
+	log = log.WithValues("url", endpoint.URL, "alertID", id)
+	log.V(1).Info("Updating an alert")
+
+	if _, err := c.do(ctx, endpoint, http.MethodPut, id, payload); err != nil {
+		log.Error(err, "unable to update the alert")
+		return err
+	}
+	log.V(1).Info("successfully updated alert")
+	return nil
+}
+
+// DeleteAlert deletes the alert with DELETE <url>/alerts/<id>. Alerts unknown to the endpoint are considered deleted
+func (c *Client) DeleteAlert(ctx context.Context, endpoint Endpoint, id string) error {
+	log := log.Logger(ctx, "pkg.webhook", "DeleteAlert")
+	log = log.WithValues("url", endpoint.URL, "alertID", id)
+	log.V(1).Info("Removing an alert")
+
+	if _, err := c.do(ctx, endpoint, http.MethodDelete, id, nil); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			log.Info("alert doesn't exist in webhook. assuming alert already got deleted")
+			return nil
+		}
+		log.Error(err, "unable to delete the alert")
+		return err
+	}
+	log.V(1).Info("successfully deleted the webhook alert")
+	return nil
+}
+
+// do sends the request to the endpoint and returns the response body for 2xx responses
+func (c *Client) do(ctx context.Context, endpoint Endpoint, method string, id string, payload []byte) ([]byte, error) {
+	reqURL, err := alertURL(endpoint.URL, id)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := endpoint.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var reqBody io.Reader
+	if payload != nil {
+		reqBody = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if endpoint.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+endpoint.Token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// alertURL builds <base>/alerts or <base>/alerts/<id>
+func alertURL(base string, id string) (string, error) {
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("invalid webhook url %s: %w", base, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("invalid webhook url %s: scheme must be http or https", base)
+	}
+	if id == "" {
+		return u.JoinPath(alertsPath).String(), nil
+	}
+	return u.JoinPath(alertsPath, id).String(), nil
+}
+
+// PayloadMatches compares the payload read from the endpoint with the rendered payload semantically, ignoring formatting and key order.
+// Only the keys of the rendered payload are compared so the fields added by the endpoint, for ex: id or timestamps, are not a drift
+func PayloadMatches(remote []byte, rendered []byte) bool {
+	var x, y interface{}
+	if err := json.Unmarshal(remote, &x); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(rendered, &y); err != nil {
+		return false
+	}
+	return valueMatches(x, y)
+}
+
+// valueMatches returns true if the remote value has all the keys of the rendered value with the same values
+func valueMatches(remote interface{}, rendered interface{}) bool {
+	switch r := rendered.(type) {
+	case map[string]interface{}:
+		m, ok := remote.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range r {
+			remoteValue, ok := m[key]
+			if !ok || !valueMatches(remoteValue, value) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		l, ok := remote.([]interface{})
+		if !ok || len(l) != len(r) {
+			return false
+		}
+		for i := range r {
+			if !valueMatches(l[i], r[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(remote, rendered)
+	}
+}
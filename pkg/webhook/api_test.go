@@ -0,0 +1,131 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/keikoproj/alert-manager/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupMockServer returns a server which stores alerts in memory following the webhook contract
+func setupMockServer(t *testing.T, alerts map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/alerts":
+			alerts["test-id"] = string(body)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"test-id"}`))
+		case r.URL.Path == "/api/alerts/test-id":
+			if _, ok := alerts["test-id"]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			switch r.Method {
+			case http.MethodGet:
+				w.Write([]byte(alerts["test-id"]))
+			case http.MethodPut:
+				alerts["test-id"] = string(body)
+			case http.MethodDelete:
+				delete(alerts, "test-id")
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestClient_Lifecycle(t *testing.T) {
+	alerts := map[string]string{}
+	mockServer := setupMockServer(t, alerts)
+	defer mockServer.Close()
+
+	ctx := context.Background()
+	client := webhook.NewClient(ctx)
+	endpoint := webhook.Endpoint{URL: mockServer.URL + "/api", Token: "test-token"}
+
+	id, err := client.CreateAlert(ctx, endpoint, []byte(`{"name":"test-alert"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "test-id", id)
+
+	payload, err := client.ReadAlert(ctx, endpoint, id)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name":"test-alert"}`, string(payload))
+
+	assert.NoError(t, client.UpdateAlert(ctx, endpoint, id, []byte(`{"name":"updated"}`)))
+	assert.JSONEq(t, `{"name":"updated"}`, alerts["test-id"])
+
+	assert.NoError(t, client.DeleteAlert(ctx, endpoint, id))
+	_, err = client.ReadAlert(ctx, endpoint, id)
+	assert.ErrorIs(t, err, webhook.ErrNotFound)
+
+	// deleting an unknown alert is not an error
+	assert.NoError(t, client.DeleteAlert(ctx, endpoint, id))
+}
+
+func TestClient_Errors(t *testing.T) {
+	mockServer := setupMockServer(t, map[string]string{})
+	defer mockServer.Close()
+
+	ctx := context.Background()
+	client := webhook.NewClient(ctx)
+
+	_, err := client.CreateAlert(ctx, webhook.Endpoint{URL: mockServer.URL + "/api"}, []byte(`{}`))
+	assert.Error(t, err, "missing token should be rejected")
+
+	_, err = client.CreateAlert(ctx, webhook.Endpoint{URL: "ftp://example.com"}, []byte(`{}`))
+	assert.Error(t, err, "non http scheme should be rejected")
+
+	err = client.UpdateAlert(ctx, webhook.Endpoint{URL: mockServer.URL + "/api", Token: "test-token"}, "test-id", []byte(`{}`))
+	assert.ErrorIs(t, err, webhook.ErrNotFound)
+}
+
+func TestPayloadMatches(t *testing.T) {
+	assert.True(t, webhook.PayloadMatches([]byte(`{"a":1,"b":"x"}`), []byte(`{ "b": "x", "a": 1 }`)))
+	assert.False(t, webhook.PayloadMatches([]byte(`{"a":1}`), []byte(`{"a":2}`)))
+	assert.False(t, webhook.PayloadMatches([]byte(`not json`), []byte(`{"a":2}`)))
+	// fields added by the endpoint are not a drift, missing or changed fields are
+	assert.True(t, webhook.PayloadMatches([]byte(`{"id":"1","a":{"b":1,"createdAt":"now"},"c":[{"d":1,"e":2}]}`), []byte(`{"a":{"b":1},"c":[{"d":1}]}`)))
+	assert.False(t, webhook.PayloadMatches([]byte(`{"id":"1"}`), []byte(`{"a":1}`)))
+	assert.False(t, webhook.PayloadMatches([]byte(`{"c":[1,2,3]}`), []byte(`{"c":[1,2]}`)))
+	assert.False(t, webhook.PayloadMatches([]byte(`{"a":"1"}`), []byte(`{"a":1}`)))
+}
+
+func TestClient_ReadAlertWithServerFields(t *testing.T) {
+	// endpoint echoes the alert along with the fields it manages
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"test-id","name":"test-alert","createdAt":"2025-01-01T00:00:00Z","updatedAt":"2025-01-02T00:00:00Z"}`))
+	}))
+	defer mockServer.Close()
+
+	ctx := context.Background()
+	client := webhook.NewClient(ctx)
+	remote, err := client.ReadAlert(ctx, webhook.Endpoint{URL: mockServer.URL + "/api", Token: "test-token"}, "test-id")
+	assert.NoError(t, err)
+	assert.True(t, webhook.PayloadMatches(remote, []byte(`{"name":"test-alert"}`)))
+	assert.False(t, webhook.PayloadMatches(remote, []byte(`{"name":"updated"}`)))
+}
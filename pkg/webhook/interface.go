@@ -0,0 +1,29 @@
+/*
+Copyright 2025 Keikoproj authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+)
+
+// Interface defines the webhook alert contract implemented by in-house alerting systems
+type Interface interface {
+	CreateAlert(ctx context.Context, endpoint Endpoint, payload []byte) (id string, err error)
+	ReadAlert(ctx context.Context, endpoint Endpoint, id string) (payload []byte, err error)
+	UpdateAlert(ctx context.Context, endpoint Endpoint, id string, payload []byte) error
+	DeleteAlert(ctx context.Context, endpoint Endpoint, id string) error
+}